@@ -1,64 +1,113 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"matterpoll-bot/config"
 	"matterpoll-bot/internal/entities"
 	"matterpoll-bot/internal/handlers"
+	"matterpoll-bot/internal/retry"
 	"matterpoll-bot/internal/services"
 	"matterpoll-bot/internal/storage"
 	"matterpoll-bot/internal/storage/database"
 	"matterpoll-bot/internal/storage/memory"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/tarantool/go-tarantool/v2"
 )
 
 func main() {
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Ошибка после получения сигнала остановки, например прерванное ожидание зависимостей, не считается сбоем.
+	if err := run(ctx, cfg); err != nil && ctx.Err() == nil {
+		log.Fatal(err)
+	}
+}
+
+// run запускает бота и работает до отмены ctx, после чего дожидается завершения текущих запросов
+// и фоновых задач не дольше cfg.ShutdownTimeout и закрывает соединение с БД.
+// Пока не истек cfg.StartupTimeout, подключение к Tarantool и регистрация команд в Mattermost повторяются.
+func run(ctx context.Context, cfg *config.Config) error {
 	var store storage.StoreInterface
 
-	bot := model.NewAPIv4Client(config.ServerURL)
-	bot.SetToken(config.BotToken)
+	bot := model.NewAPIv4Client(cfg.ServerURL)
+	bot.SetToken(cfg.BotToken)
+
+	startupCtx, cancelStartup := context.WithTimeout(ctx, cfg.StartupTimeout)
+	defer cancelStartup()
 
-	switch config.Mode {
-	case "memory":
+	switch cfg.Mode {
+	case config.ModeMemory:
 		store = memory.NewMemoryStore()
 		log.Println("Using memory store")
-	case "database":
-		ttConf := &entities.TarantoolConfig{
-			Address:  config.DbSocket,
-			User:     "user",
-			Password: "secret",
-		}
-		conn, err := database.NewDatabaseConection(ttConf)
+	case config.ModeDatabase:
+		var conn *tarantool.Connection
+		err := retry.Do(startupCtx, retry.DefaultBackoff, "connect to database", func() (err error) {
+			conn, err = database.NewDatabaseConection(cfg.Database.TarantoolConfig())
+			return err
+		})
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		defer conn.CloseGraceful()
 
 		store = database.NewDatabaseStore(conn)
 		log.Println("Using database store")
-	default:
-		log.Fatalf("config.Mode is empty in /internal/config/config.go")
 	}
 
-	pollService := services.NewPollService(bot, store)
-	if err := pollService.RegisterCommands(); err != nil {
-		log.Fatalln(err)
+	pollService := services.NewPollService(bot, store, cfg)
+	if err := retry.Do(startupCtx, retry.DefaultBackoff, "register commands", pollService.RegisterCommands); err != nil {
+		return err
 	}
 
+	workersCtx, stopWorkers := context.WithCancel(ctx)
+	defer stopWorkers()
+
+	var workers sync.WaitGroup
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		pollService.RunScheduler(workersCtx, 30*time.Second)
+	}()
+
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("/poll-create", handlers.TokenValidatorMiddleware(store, handlers.CreatePoll(pollService)))
-	mux.HandleFunc("/poll-vote", handlers.TokenValidatorMiddleware(store, handlers.Vote(pollService)))
-	mux.HandleFunc("/poll-results", handlers.TokenValidatorMiddleware(store, handlers.GetPollResults(pollService)))
-	mux.HandleFunc("/poll-close", handlers.TokenValidatorMiddleware(store, handlers.ClosePoll(pollService)))
-	mux.HandleFunc("/poll-delete", handlers.TokenValidatorMiddleware(store, handlers.DeletePoll(pollService)))
+	mux.HandleFunc(entities.PollPath, handlers.TokenValidatorMiddleware(cfg, store, handlers.PollCommand(pollService)))
+	mux.HandleFunc("/poll-create", handlers.TokenValidatorMiddleware(cfg, store, handlers.CreatePoll(pollService)))
+	mux.HandleFunc("/poll-vote", handlers.TokenValidatorMiddleware(cfg, store, handlers.Vote(pollService)))
+	mux.HandleFunc("/poll-add-option", handlers.TokenValidatorMiddleware(cfg, store, handlers.AddOption(pollService)))
+	mux.HandleFunc("/poll-retract", handlers.TokenValidatorMiddleware(cfg, store, handlers.RetractVote(pollService)))
+	mux.HandleFunc("/poll-change", handlers.TokenValidatorMiddleware(cfg, store, handlers.ChangeVote(pollService)))
+	mux.HandleFunc("/poll-results", handlers.TokenValidatorMiddleware(cfg, store, handlers.GetPollResults(pollService)))
+	mux.HandleFunc("/poll-close", handlers.TokenValidatorMiddleware(cfg, store, handlers.ClosePoll(pollService)))
+	mux.HandleFunc("/poll-reopen", handlers.TokenValidatorMiddleware(cfg, store, handlers.ReopenPoll(pollService)))
+	mux.HandleFunc("/poll-delete", handlers.TokenValidatorMiddleware(cfg, store, handlers.DeletePoll(pollService)))
+	mux.HandleFunc("/poll-list", handlers.TokenValidatorMiddleware(cfg, store, handlers.ListPolls(pollService)))
+	mux.HandleFunc("/poll-schedule", handlers.TokenValidatorMiddleware(cfg, store, handlers.ScheduleCommand(pollService)))
+	mux.HandleFunc(entities.ActionPath, handlers.ActionValidatorMiddleware(pollService, handlers.PollAction(pollService)))
+	mux.HandleFunc(entities.DialogPath, handlers.DialogValidatorMiddleware(pollService, handlers.SubmitDialog(pollService)))
 
 	serv := &http.Server{
-		Addr:              config.BotSocket,
+		Addr:              cfg.BotSocket,
 		Handler:           mux,
 		ReadTimeout:       5 * time.Second,
 		WriteTimeout:      10 * time.Second,
@@ -67,8 +116,38 @@ func main() {
 		MaxHeaderBytes:    1 << 20,
 	}
 
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- serv.ListenAndServe()
+	}()
 	fmt.Println("Bot is running ...")
-	if err := serv.ListenAndServe(); err != nil {
-		log.Fatal(err.Error())
+
+	var err error
+	select {
+	case err = <-serveErr:
+	case <-ctx.Done():
+		log.Println("Shutting down ...")
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancelShutdown()
+
+	if err := serv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("failed to shut down server: %v\n", err)
 	}
+
+	stopWorkers()
+	drained := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-shutdownCtx.Done():
+		log.Println("failed to wait for background jobs: shutdown timeout exceeded")
+	}
+
+	return err
 }
@@ -0,0 +1,186 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"matterpoll-bot/config"
+
+	"github.com/stretchr/testify/require"
+)
+
+// envNames - переменные окружения, которые читает конфигурация.
+var envNames = []string{
+	"CONFIG_FILE", "MODE", "SERVER_URL", "BOT_SOCKET", "BOT_HOSTNAME", "BOT_TOKEN", "BOT_TOKEN_FILE", "TEAM_NAME",
+	"DB_SOCKET", "DB_USER", "DB_PASSWORD", "DB_PASSWORD_FILE",
+	"DB_CONNECT_TIMEOUT", "DB_REQUEST_TIMEOUT", "DB_RECONNECT_INTERVAL", "DB_MAX_RECONNECTS",
+	"STARTUP_TIMEOUT", "SHUTDOWN_TIMEOUT",
+}
+
+// setEnv очищает переменные окружения конфигурации и задает переменные из env на время теста.
+func setEnv(t *testing.T, env map[string]string) {
+	for _, name := range envNames {
+		t.Setenv(name, env[name])
+	}
+}
+
+// writeFile создает во временной директории файл name с содержимым content и возвращает путь к нему.
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+// validEnv возвращает переменные окружения корректной конфигурации в режиме database.
+func validEnv() map[string]string {
+	return map[string]string{
+		"MODE":         "database",
+		"SERVER_URL":   "http://mattermost:8065",
+		"BOT_HOSTNAME": "matterpoll-bot",
+		"BOT_TOKEN":    "bot_token",
+		"TEAM_NAME":    "test_team",
+		"DB_SOCKET":    "tarantool:3301",
+		"DB_USER":      "user",
+		"DB_PASSWORD":  "secret",
+	}
+}
+
+// TestLoadEnv проверяет загрузку конфигурации из переменных окружения со значениями по умолчанию.
+func TestLoadEnv(t *testing.T) {
+	env := validEnv()
+	env["DB_REQUEST_TIMEOUT"] = "30s"
+	env["DB_MAX_RECONNECTS"] = "3"
+	setEnv(t, env)
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+
+	require.Equal(t, config.ModeDatabase, cfg.Mode)
+	require.Equal(t, "http://mattermost:8065", cfg.ServerURL)
+	require.Equal(t, ":4000", cfg.BotSocket)
+	require.Equal(t, "bot_token", cfg.BotToken)
+	require.Equal(t, "test_team", cfg.TeamName)
+	require.Equal(t, 2*time.Minute, cfg.StartupTimeout)
+	require.Equal(t, 15*time.Second, cfg.ShutdownTimeout)
+
+	ttConf := cfg.Database.TarantoolConfig()
+	require.Equal(t, "tarantool:3301", ttConf.Address)
+	require.Equal(t, "user", ttConf.User)
+	require.Equal(t, "secret", ttConf.Password)
+	require.Equal(t, time.Second, ttConf.ConnectTimeout)
+	require.Equal(t, 30*time.Second, ttConf.RequestTimeout)
+	require.Equal(t, 500*time.Millisecond, ttConf.ReconnectInterval)
+	require.Equal(t, uint(3), ttConf.MaxReconnects)
+}
+
+// TestLoadPriority проверяет, что переменные окружения переопределяют файл, а флаги - переменные окружения.
+func TestLoadPriority(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+mode: memory
+server_url: https://chat.example.com
+bot_socket: ":5000"
+bot_hostname: bot.example.com
+bot_token: file_token
+team_name: file_team
+database:
+  connect_timeout: 5s
+`)
+	setEnv(t, map[string]string{"CONFIG_FILE": path, "TEAM_NAME": "env_team", "BOT_SOCKET": ":6000"})
+
+	cfg, err := config.Load([]string{"-bot-socket", ":7000"})
+	require.NoError(t, err)
+
+	require.Equal(t, config.ModeMemory, cfg.Mode)
+	require.Equal(t, "https://chat.example.com", cfg.ServerURL)
+	require.Equal(t, "file_token", cfg.BotToken)
+	require.Equal(t, "env_team", cfg.TeamName)
+	require.Equal(t, ":7000", cfg.BotSocket)
+	require.Equal(t, 5*time.Second, cfg.Database.ConnectTimeout)
+	require.Equal(t, time.Minute, cfg.Database.RequestTimeout)
+}
+
+// TestLoadSecrets проверяет чтение секретов из файлов.
+func TestLoadSecrets(t *testing.T) {
+	t.Run("env files", func(t *testing.T) {
+		env := validEnv()
+		env["BOT_TOKEN_FILE"] = writeFile(t, "bot_token", "secret_token\n")
+		env["DB_PASSWORD_FILE"] = writeFile(t, "db_password", "secret_password\n")
+		setEnv(t, env)
+
+		cfg, err := config.Load(nil)
+		require.NoError(t, err)
+		require.Equal(t, "secret_token", cfg.BotToken)
+		require.Equal(t, "secret_password", cfg.Database.Password)
+	})
+
+	t.Run("flag file overrides env", func(t *testing.T) {
+		setEnv(t, validEnv())
+
+		cfg, err := config.Load([]string{"-bot-token-file", writeFile(t, "bot_token", "flag_token")})
+		require.NoError(t, err)
+		require.Equal(t, "flag_token", cfg.BotToken)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		env := validEnv()
+		env["BOT_TOKEN_FILE"] = filepath.Join(t.TempDir(), "missing")
+		setEnv(t, env)
+
+		_, err := config.Load(nil)
+		require.ErrorContains(t, err, "failed to read bot token")
+	})
+}
+
+// TestLoadErrors проверяет ошибки загрузки и проверки конфигурации.
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+		err  string
+	}{
+		{"Unknown mode", map[string]string{"MODE": "redis"}, nil, `unknown MODE "redis"`},
+		{"Missing mode", map[string]string{"MODE": ""}, nil, "MODE is required"},
+		{"Bad URL", map[string]string{"SERVER_URL": "mattermost:8065"}, nil, `invalid SERVER_URL "mattermost:8065"`},
+		{"Missing token", map[string]string{"BOT_TOKEN": ""}, nil, "BOT_TOKEN is required"},
+		{"Missing team", map[string]string{"TEAM_NAME": ""}, nil, "TEAM_NAME is required"},
+		{"Bad socket", map[string]string{"BOT_SOCKET": "4000"}, nil, `invalid BOT_SOCKET "4000"`},
+		{"Missing database user", map[string]string{"DB_USER": ""}, nil, "DB_USER is required"},
+		{"Bad timeout", map[string]string{"DB_CONNECT_TIMEOUT": "soon"}, nil, "invalid DB_CONNECT_TIMEOUT"},
+		{"Negative timeout", map[string]string{"DB_REQUEST_TIMEOUT": "-1s"}, nil, "invalid DB_REQUEST_TIMEOUT -1s"},
+		{"Zero shutdown timeout", nil, []string{"-shutdown-timeout", "0s"}, "invalid SHUTDOWN_TIMEOUT 0s"},
+		{"Unknown flag", nil, []string{"-verbose"}, "flag provided but not defined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := validEnv()
+			for name, value := range tt.env {
+				env[name] = value
+			}
+			setEnv(t, env)
+
+			_, err := config.Load(tt.args)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	t.Run("All errors", func(t *testing.T) {
+		setEnv(t, map[string]string{"MODE": "database"})
+
+		_, err := config.Load(nil)
+		require.ErrorContains(t, err, "SERVER_URL is required")
+		require.ErrorContains(t, err, "BOT_TOKEN is required")
+		require.ErrorContains(t, err, "DB_SOCKET")
+	})
+
+	t.Run("Unknown file key", func(t *testing.T) {
+		setEnv(t, validEnv())
+
+		_, err := config.Load([]string{"-config", writeFile(t, "config.yaml", "bot_tokn: x\n")})
+		require.ErrorContains(t, err, "field bot_tokn not found")
+	})
+}
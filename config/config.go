@@ -1,13 +1,307 @@
 package config
 
-import "os"
-
-var (
-	ServerURL   = os.Getenv("SERVER_URL")
-	BotSocket   = os.Getenv("BOT_SOCKET")
-	DbSocket    = os.Getenv("DB_SOCKET")
-	Mode        = os.Getenv("MODE")
-	BotToken    = os.Getenv("BOT_TOKEN")
-	BotHostname = os.Getenv("BOT_HOSTNAME")
-	TeamName    = os.Getenv("TEAM_NAME")
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"matterpoll-bot/internal/entities"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Режимы хранения опросов.
+const (
+	ModeMemory   = "memory"
+	ModeDatabase = "database"
 )
+
+// Config - настройки бота.
+// Источники применяются по возрастанию приоритета: значения по умолчанию, YAML-файл, переменные окружения, флаги.
+type Config struct {
+	Mode         string         `yaml:"mode"`           // Mode - режим хранения опросов: memory или database.
+	ServerURL    string         `yaml:"server_url"`     // ServerURL - адрес сервера Mattermost.
+	BotSocket    string         `yaml:"bot_socket"`     // BotSocket - адрес, на котором бот принимает запросы.
+	BotHostname  string         `yaml:"bot_hostname"`   // BotHostname - имя хоста бота, доступное серверу Mattermost.
+	BotToken     string         `yaml:"bot_token"`      // BotToken - токен доступа бота.
+	BotTokenFile string         `yaml:"bot_token_file"` // BotTokenFile - файл с токеном бота, например Docker secret.
+	TeamName     string         `yaml:"team_name"`      // TeamName - имя команды, в которой регистрируются команды бота.
+	Database     DatabaseConfig `yaml:"database"`       // Database - настройки подключения к Tarantool.
+
+	StartupTimeout  time.Duration `yaml:"startup_timeout"`  // StartupTimeout - время ожидания Mattermost и Tarantool при запуске.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"` // ShutdownTimeout - время завершения текущих запросов и фоновых задач при остановке.
+}
+
+// DatabaseConfig - настройки подключения к Tarantool.
+type DatabaseConfig struct {
+	Socket            string        `yaml:"socket"`             // Socket - адрес Tarantool.
+	User              string        `yaml:"user"`               // User - имя пользователя Tarantool.
+	Password          string        `yaml:"password"`           // Password - пароль пользователя Tarantool.
+	PasswordFile      string        `yaml:"password_file"`      // PasswordFile - файл с паролем, например Docker secret.
+	ConnectTimeout    time.Duration `yaml:"connect_timeout"`    // ConnectTimeout - время ожидания подключения.
+	RequestTimeout    time.Duration `yaml:"request_timeout"`    // RequestTimeout - время ожидания ответа на запрос.
+	ReconnectInterval time.Duration `yaml:"reconnect_interval"` // ReconnectInterval - пауза между попытками переподключения.
+	MaxReconnects     uint          `yaml:"max_reconnects"`     // MaxReconnects - число попыток переподключения.
+}
+
+// Default возвращает конфигурацию со значениями по умолчанию.
+func Default() *Config {
+	return &Config{
+		BotSocket:       ":4000",
+		StartupTimeout:  2 * time.Minute,
+		ShutdownTimeout: 15 * time.Second,
+		Database: DatabaseConfig{
+			ConnectTimeout:    time.Second,
+			RequestTimeout:    time.Minute,
+			ReconnectInterval: 500 * time.Millisecond,
+			MaxReconnects:     10,
+		},
+	}
+}
+
+// Load загружает конфигурацию из YAML-файла, переменных окружения и флагов командной строки args и проверяет ее.
+// Путь к файлу задается флагом -config или переменной CONFIG_FILE; без него файл не читается.
+func Load(args []string) (*Config, error) {
+	path := os.Getenv("CONFIG_FILE")
+	if err := newFlagSet(&Config{}, &path).Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := newFlagSet(cfg, &path).Parse(args); err != nil {
+		return nil, err
+	}
+	if err := cfg.readSecrets(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// newFlagSet возвращает флаги командной строки, записывающие значения в cfg, а путь к файлу конфигурации - в path.
+func newFlagSet(cfg *Config, path *string) *flag.FlagSet {
+	fs := flag.NewFlagSet("matterpoll-bot", flag.ContinueOnError)
+
+	fs.StringVar(path, "config", *path, "path to the YAML config file")
+	fs.StringVar(&cfg.Mode, "mode", cfg.Mode, `store mode: "memory" or "database"`)
+	fs.StringVar(&cfg.ServerURL, "server-url", cfg.ServerURL, "Mattermost server URL")
+	fs.StringVar(&cfg.BotSocket, "bot-socket", cfg.BotSocket, "address the bot listens on")
+	fs.StringVar(&cfg.BotHostname, "bot-hostname", cfg.BotHostname, "bot hostname reachable from Mattermost")
+	fs.StringVar(&cfg.BotTokenFile, "bot-token-file", cfg.BotTokenFile, "file with the bot access token")
+	fs.StringVar(&cfg.TeamName, "team-name", cfg.TeamName, "team to register the bot commands in")
+	fs.DurationVar(&cfg.StartupTimeout, "startup-timeout", cfg.StartupTimeout, "time to wait for Mattermost and Tarantool on startup")
+	fs.DurationVar(&cfg.ShutdownTimeout, "shutdown-timeout", cfg.ShutdownTimeout, "time to finish requests and background jobs on shutdown")
+	fs.StringVar(&cfg.Database.Socket, "db-socket", cfg.Database.Socket, "Tarantool address")
+	fs.StringVar(&cfg.Database.User, "db-user", cfg.Database.User, "Tarantool user")
+	fs.StringVar(&cfg.Database.PasswordFile, "db-password-file", cfg.Database.PasswordFile, "file with the Tarantool password")
+	fs.DurationVar(&cfg.Database.ConnectTimeout, "db-connect-timeout", cfg.Database.ConnectTimeout, "Tarantool connect timeout")
+	fs.DurationVar(&cfg.Database.RequestTimeout, "db-request-timeout", cfg.Database.RequestTimeout, "Tarantool request timeout")
+	fs.DurationVar(&cfg.Database.ReconnectInterval, "db-reconnect-interval", cfg.Database.ReconnectInterval, "pause between Tarantool reconnects")
+	fs.UintVar(&cfg.Database.MaxReconnects, "db-max-reconnects", cfg.Database.MaxReconnects, "number of Tarantool reconnects")
+
+	return fs
+}
+
+// loadFile читает настройки из YAML-файла path. Неизвестные ключи считаются ошибкой.
+func (c *Config) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("failed to parse config file '%s': %w", path, err)
+	}
+
+	return c.readSecrets()
+}
+
+// loadEnv читает настройки из переменных окружения. Пустые переменные не учитываются.
+func (c *Config) loadEnv() error {
+	strs := map[string]*string{
+		"MODE":             &c.Mode,
+		"SERVER_URL":       &c.ServerURL,
+		"BOT_SOCKET":       &c.BotSocket,
+		"BOT_HOSTNAME":     &c.BotHostname,
+		"BOT_TOKEN":        &c.BotToken,
+		"BOT_TOKEN_FILE":   &c.BotTokenFile,
+		"TEAM_NAME":        &c.TeamName,
+		"DB_SOCKET":        &c.Database.Socket,
+		"DB_USER":          &c.Database.User,
+		"DB_PASSWORD":      &c.Database.Password,
+		"DB_PASSWORD_FILE": &c.Database.PasswordFile,
+	}
+	for name, field := range strs {
+		if v := os.Getenv(name); v != "" {
+			*field = v
+		}
+	}
+
+	durations := map[string]*time.Duration{
+		"STARTUP_TIMEOUT":       &c.StartupTimeout,
+		"SHUTDOWN_TIMEOUT":      &c.ShutdownTimeout,
+		"DB_CONNECT_TIMEOUT":    &c.Database.ConnectTimeout,
+		"DB_REQUEST_TIMEOUT":    &c.Database.RequestTimeout,
+		"DB_RECONNECT_INTERVAL": &c.Database.ReconnectInterval,
+	}
+	for name, field := range durations {
+		v := os.Getenv(name)
+		if v == "" {
+			continue
+		}
+
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+		*field = d
+	}
+
+	if v := os.Getenv("DB_MAX_RECONNECTS"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 0)
+		if err != nil {
+			return fmt.Errorf("invalid DB_MAX_RECONNECTS: %w", err)
+		}
+		c.Database.MaxReconnects = uint(n)
+	}
+
+	return c.readSecrets()
+}
+
+// readSecrets читает секреты из файлов, указанных в текущем источнике настроек.
+// Прочитанное значение заменяет секрет, заданный источниками с меньшим приоритетом.
+func (c *Config) readSecrets() error {
+	if c.BotTokenFile != "" {
+		token, err := readSecret(c.BotTokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read bot token: %w", err)
+		}
+		c.BotToken, c.BotTokenFile = token, ""
+	}
+
+	if c.Database.PasswordFile != "" {
+		password, err := readSecret(c.Database.PasswordFile)
+		if err != nil {
+			return fmt.Errorf("failed to read database password: %w", err)
+		}
+		c.Database.Password, c.Database.PasswordFile = password, ""
+	}
+
+	return nil
+}
+
+// readSecret возвращает содержимое файла path без пробельных символов по краям.
+func readSecret(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Validate проверяет конфигурацию и возвращает все найденные ошибки.
+func (c *Config) Validate() error {
+	var errs []error
+
+	switch c.Mode {
+	case ModeMemory, ModeDatabase:
+	case "":
+		errs = append(errs, errors.New(`MODE is required: expected "memory" or "database"`))
+	default:
+		errs = append(errs, fmt.Errorf(`unknown MODE %q: expected "memory" or "database"`, c.Mode))
+	}
+
+	if c.ServerURL == "" {
+		errs = append(errs, errors.New("SERVER_URL is required"))
+	} else if u, err := url.Parse(c.ServerURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("invalid SERVER_URL %q: expected an http(s) URL like http://mattermost:8065", c.ServerURL))
+	}
+
+	if _, _, err := net.SplitHostPort(c.BotSocket); err != nil {
+		errs = append(errs, fmt.Errorf("invalid BOT_SOCKET %q: expected host:port like :4000", c.BotSocket))
+	}
+
+	if c.BotHostname == "" {
+		errs = append(errs, errors.New("BOT_HOSTNAME is required"))
+	}
+
+	if c.BotToken == "" {
+		errs = append(errs, errors.New("BOT_TOKEN is required: set BOT_TOKEN or BOT_TOKEN_FILE"))
+	}
+
+	if c.TeamName == "" {
+		errs = append(errs, errors.New("TEAM_NAME is required"))
+	}
+
+	errs = append(errs, positive("STARTUP_TIMEOUT", c.StartupTimeout), positive("SHUTDOWN_TIMEOUT", c.ShutdownTimeout))
+
+	if c.Mode == ModeDatabase {
+		errs = append(errs, c.Database.validate()...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate проверяет настройки подключения к Tarantool.
+func (d *DatabaseConfig) validate() []error {
+	var errs []error
+
+	if _, _, err := net.SplitHostPort(d.Socket); err != nil {
+		errs = append(errs, fmt.Errorf("invalid DB_SOCKET %q: expected host:port like tarantool:3301", d.Socket))
+	}
+
+	if d.User == "" {
+		errs = append(errs, errors.New("DB_USER is required in database mode"))
+	}
+
+	return append(errs,
+		positive("DB_CONNECT_TIMEOUT", d.ConnectTimeout),
+		positive("DB_REQUEST_TIMEOUT", d.RequestTimeout),
+		positive("DB_RECONNECT_INTERVAL", d.ReconnectInterval),
+	)
+}
+
+// positive возвращает ошибку, если длительность value настройки name не положительна.
+func positive(name string, value time.Duration) error {
+	if value <= 0 {
+		return fmt.Errorf("invalid %s %s: must be positive", name, value)
+	}
+
+	return nil
+}
+
+// TarantoolConfig возвращает настройки подключения к Tarantool для пакета database.
+func (d *DatabaseConfig) TarantoolConfig() *entities.TarantoolConfig {
+	return &entities.TarantoolConfig{
+		Address:           d.Socket,
+		User:              d.User,
+		Password:          d.Password,
+		ConnectTimeout:    d.ConnectTimeout,
+		RequestTimeout:    d.RequestTimeout,
+		ReconnectInterval: d.ReconnectInterval,
+		MaxReconnects:     d.MaxReconnects,
+	}
+}
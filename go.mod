@@ -5,7 +5,10 @@ go 1.23.4
 require (
 	github.com/mattermost/mattermost-server/v6 v6.7.2
 	github.com/stretchr/testify v1.10.0
+	github.com/tarantool/go-iproto v1.1.0
 	github.com/tarantool/go-tarantool/v2 v2.3.0
+	github.com/testcontainers/testcontainers-go v0.36.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -72,8 +75,6 @@ require (
 	github.com/shopspring/decimal v1.3.1 // indirect
 	github.com/sirupsen/logrus v1.9.3 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
-	github.com/tarantool/go-iproto v1.1.0 // indirect
-	github.com/testcontainers/testcontainers-go v0.36.0 // indirect
 	github.com/tinylib/msgp v1.1.6 // indirect
 	github.com/tklauser/go-sysconf v0.3.12 // indirect
 	github.com/tklauser/numcpus v0.6.1 // indirect
@@ -94,5 +95,4 @@ require (
 	gopkg.in/ini.v1 v1.66.4 // indirect
 	gopkg.in/natefinch/lumberjack.v2 v2.0.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -1,5 +1,10 @@
 package entities
 
+import (
+	"matterpoll-bot/internal/i18n"
+	"time"
+)
+
 // Poll представляет сущность опроса.
 // Поля структуры:
 // - PollId: уникальный идентификатор опроса.
@@ -7,21 +12,73 @@ package entities
 // - Options: варианты ответа с количеством голосов за каждый вариант.
 // Poll представляет сущность опроса.
 type Poll struct {
-	PollId   string           // PollId - уникальный идентификатор опроса
-	Question string           // Question - текст вопроса опроса.
-	Options  map[string]int32 // Options - варианты ответа с количеством голосов за каждый вариант.
-	Voters   map[string]bool  // Voters: список пользователей, проголосовавших в опросе (по идентификатору).
-	Creator  string           // Creator - идентификатор создателя опроса.
-	Closed   bool             // Closed - флаг, указывающий, закрыт ли опрос.
+	PollId         string              // PollId - уникальный идентификатор опроса
+	Question       string              // Question - текст вопроса опроса.
+	Options        map[string]int32    // Options - варианты ответа с количеством голосов за каждый вариант.
+	Voters         map[string][]string // Voters: варианты, выбранные каждым проголосовавшим пользователем (по идентификатору).
+	Creator        string              // Creator - идентификатор создателя опроса.
+	Closed         bool                // Closed - флаг, указывающий, закрыт ли опрос.
+	PostId         string              // PostId - идентификатор сообщения с опросом, которое обновляется после каждого изменения.
+	MaxVotes       int32               // MaxVotes - максимальное количество вариантов, которое может выбрать пользователь (0 - без ограничений).
+	Public         bool                // Public - флаг публичного опроса, в результатах которого отображается, кто за что проголосовал.
+	Ranked         bool                // Ranked - флаг рейтингового опроса: Voters хранит бюллетени, а Options - количество первых предпочтений.
+	ChannelId      string              // ChannelId - идентификатор канала, в котором опубликован опрос.
+	EndsAt         int64               // EndsAt - время автоматического закрытия опроса в формате Unix (0 - без срока).
+	TeamId         string              // TeamId - идентификатор команды, в которой создан опрос.
+	CreatedAt      int64               // CreatedAt - время создания опроса в формате Unix.
+	OpenOptions    bool                // OpenOptions - флаг опроса, в который участники могут добавлять свои варианты.
+	HideResults    bool                // HideResults - флаг опроса, результаты которого скрыты от всех, кроме создателя, до его закрытия.
+	Weights        map[string]int32    // Weights - веса голосов пользователей (по идентификатору) во взвешенном опросе; остальные голосуют с весом 1.
+	Quorum         int32               // Quorum - минимальное количество проголосовавших для принятия решения (0 - без ограничения).
+	QuorumPercent  int32               // QuorumPercent - минимальная доля участников канала в процентах, которая должна проголосовать (0 - без ограничения).
+	Threshold      float64             // Threshold - минимальная доля голосов лидирующего варианта для принятия решения, от 0 до 1 (0 - достаточно единственного лидирующего варианта).
+	RequiredVoters int32               // RequiredVoters - количество проголосовавших, необходимое для кворума, вычисленное при закрытии опроса (QuorumUnknown - вычислить не удалось).
 
 }
 
+// QuorumUnknown - значение RequiredVoters опроса, при закрытии которого не удалось получить количество участников канала.
+// Кворум такого опроса считается неизвестным, а не набранным.
+const QuorumUnknown int32 = -1
+
+// LegacyChoice - вариант в Voters пользователя, проголосовавшего до того, как опросы стали хранить выбранные варианты.
+// Голос такого пользователя учтен в Options, но за какой вариант он отдан, неизвестно.
+const LegacyChoice = ""
+
 // Voice представляет сущность голоса пользователя в опросе.
 type Voice struct {
-	PollId string // PollId - уникальный идентификатор опроса
-	UserId string // UserId - идентификатор пользователя, который проголосовал.
-	Option string // Option - выбранный пользователем вариант ответа.
+	PollId  string   // PollId - уникальный идентификатор опроса
+	UserId  string   // UserId - идентификатор пользователя, который проголосовал.
+	Option  string   // Option - выбранный пользователем вариант ответа.
+	Ranking []string // Ranking - варианты ответа в порядке предпочтения пользователя (для рейтингового опроса).
+
+}
+
+// PollFilter представляет условия выборки списка опросов. Пустые поля не ограничивают выборку.
+type PollFilter struct {
+	ChannelId string // ChannelId - идентификатор канала, в котором опубликованы опросы.
+	Creator   string // Creator - идентификатор создателя опросов.
+	Closed    *bool  // Closed - статус опросов: nil - любые, false - открытые, true - закрытые.
+	Offset    int    // Offset - количество пропускаемых опросов с начала списка.
+	Limit     int    // Limit - максимальное количество опросов в выборке (0 - без ограничений).
+}
 
+// Schedule представляет расписание повторяющегося опроса: в каждый момент запуска бот создает
+// и публикует в канале новый опрос с заданными вопросом и вариантами ответа.
+type Schedule struct {
+	ScheduleId string   // ScheduleId - уникальный идентификатор расписания.
+	Cron       string   // Cron - расписание запусков в формате cron (в UTC), например `0 12 * * 5`.
+	Question   string   // Question - текст вопроса создаваемых опросов.
+	Options    []string // Options - варианты ответа создаваемых опросов.
+	Creator    string   // Creator - идентификатор создателя расписания, от имени которого создаются опросы.
+	ChannelId  string   // ChannelId - идентификатор канала, в котором публикуются опросы.
+	TeamId     string   // TeamId - идентификатор команды канала.
+	MaxVotes   int32    // MaxVotes - максимальное количество вариантов, которое может выбрать пользователь (0 - без ограничений).
+	Public     bool     // Public - флаг публичных опросов.
+	Ranked     bool     // Ranked - флаг рейтинговых опросов.
+	Duration   int64    // Duration - длительность опроса в секундах до автоматического закрытия (0 - без срока).
+	Paused     bool     // Paused - флаг приостановленного расписания, по которому опросы не создаются.
+	NextRunAt  int64    // NextRunAt - время следующего запуска в формате Unix.
+	CreatedAt  int64    // CreatedAt - время создания расписания в формате Unix.
 }
 
 // CommandInfo представляет информацию о команде бота.
@@ -33,34 +90,75 @@ type CommandInfo struct {
 	Hint        string // Hint - подсказка или дополнительная информация о том, как использовать команду.
 }
 
+// TarantoolConfig - настройки подключения к Tarantool. Нулевые тайм-ауты заменяются значениями по умолчанию.
 type TarantoolConfig struct {
-	Address  string
-	User     string
-	Password string
+	Address           string
+	User              string
+	Password          string
+	ConnectTimeout    time.Duration // ConnectTimeout - время ожидания подключения.
+	RequestTimeout    time.Duration // RequestTimeout - время ожидания ответа на запрос.
+	ReconnectInterval time.Duration // ReconnectInterval - пауза между попытками переподключения.
+	MaxReconnects     uint          // MaxReconnects - число попыток переподключения.
+}
+
+// Message представляет сообщение пользователю: ключ каталога сообщений и параметры для подстановки.
+// Текст сообщения формируется на языке пользователя при отправке ответа.
+type Message struct {
+	Key    string
+	Params []any
+}
+
+// NewMessage создает новое сообщение с ключом key и параметрами params.
+func NewMessage(key string, params ...any) *Message {
+	return &Message{Key: key, Params: params}
+}
+
+// Localize возвращает текст сообщения на языке locale.
+func (m *Message) Localize(locale string) string {
+	return i18n.T(locale, m.Key, m.Params...)
+}
+
+// String возвращает текст сообщения на языке по умолчанию.
+func (m *Message) String() string {
+	return m.Localize(i18n.DefaultLocale)
 }
 
 // UserError представляет ошибки, которые можно показывать пользователям.
 type UserError struct {
-	Message string
+	Message
 }
 
+// Error возвращает текст ошибки на языке по умолчанию.
 func (e *UserError) Error() string {
-	return e.Message
+	return e.String()
 }
 
-// NewUserError создает новую пользовательскую ошибку.
-func NewUserError(message string) error {
-	return &UserError{Message: message}
+// NewUserError создает новую пользовательскую ошибку с ключом сообщения key и параметрами params.
+func NewUserError(key string, params ...any) error {
+	return &UserError{Message: Message{Key: key, Params: params}}
 }
 
 var (
-	PollsSpaceName  = "polls"      // PollsSpaceName - имя пространства для хранения опросов в Tarantool.
-	TokensSpaceName = "cmd_tokens" // PollsSpaceName - имя пространства для хранения токенов команд в Tarantool.
-	CommandList     = []CommandInfo{
-		{"poll-create", "/poll-create", "Create poll", "Create a new poll", "[\"question\"] [\"option1\"] [\"option2\"] ..."},
-		{"poll-vote", "/poll-vote", "Vote", "Сast a vote", "[\"poll_id\"] [\"option\"]"},
-		{"poll-results", "/poll-results", "Results", "Get poll results", "[\"poll_id\"]"},
+	PollsSpaceName  = "polls"        // PollsSpaceName - имя пространства для хранения опросов в Tarantool.
+	TokensSpaceName = "cmd_tokens"   // PollsSpaceName - имя пространства для хранения токенов команд в Tarantool.
+	ActionPath      = "/poll-action" // ActionPath - путь URL для обработки нажатий на кнопки в сообщениях опросов.
+	DialogPath      = "/poll-dialog" // DialogPath - путь URL для обработки отправки интерактивных диалогов.
+	PollPath        = "/poll"        // PollPath - путь URL команды /poll с подкомандами.
+	// SchedulesSpaceName - имя пространства для хранения расписаний повторяющихся опросов в Tarantool.
+	SchedulesSpaceName = "schedules"
+	// CommandList - команды бота. Отдельные команды /poll-* сохранены как псевдонимы подкоманд /poll.
+	CommandList = []CommandInfo{
+		{"poll", "/poll", "Poll", "Manage polls: create, vote, add-option, results, close, reopen, delete, list, schedule, help", "[command]"},
+		{"poll-create", "/poll-create", "Create poll", "Create a new poll (without arguments opens a dialog)", "[\"question\"] [\"option1\"] [\"option2\"] ... [--max-votes N] [--public] [--ranked] [--open-options] [--hide-results] [--weights \"@user=N, @group=N\"] [--quorum N|N%] [--threshold 2/3] [--ends 2h]"},
+		{"poll-vote", "/poll-vote", "Vote", "Сast a vote (list options in order of preference for ranked polls)", "[\"poll_id\"] [\"option\"] ..."},
+		{"poll-retract", "/poll-retract", "Retract vote", "Retract your vote (all options if none is given)", "[\"poll_id\"] [\"option\"]"},
+		{"poll-change", "/poll-change", "Change vote", "Replace your vote with another option", "[\"poll_id\"] [\"option\"] ..."},
+		{"poll-add-option", "/poll-add-option", "Add option", "Add an option to a poll with open options", "[\"poll_id\"] [\"option\"]"},
+		{"poll-results", "/poll-results", "Results", "Get poll results, post them with a chart or export them as a CSV or JSON file", "[\"poll_id\"] [--format csv|json] [--chart] [--share]"},
 		{"poll-close", "/poll-close", "Close poll", "Close an active poll", "[\"poll_id\"]"},
+		{"poll-reopen", "/poll-reopen", "Reopen poll", "Reopen a closed poll", "[\"poll_id\"]"},
 		{"poll-delete", "/poll-delete", "Delete poll", "Delete an exists poll", "[\"poll_id\"]"},
+		{"poll-list", "/poll-list", "List polls", "List polls of the channel or your own polls", "[--mine] [--channel] [--open | --closed] [--page N]"},
+		{"poll-schedule", "/poll-schedule", "Recurring polls", "Create, list, pause, resume or remove recurring polls", "[create|list|pause|resume|remove] ..."},
 	}
 )
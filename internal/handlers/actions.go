@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/services"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// PollAction обрабатывает нажатия на кнопки в сообщениях опросов.
+// Ожидается, что запрос прошел проверку в ActionValidatorMiddleware,
+// а контекст кнопки содержит поле "action" с названием действия:
+// "vote" — голос за вариант, указанный в поле "option";
+// "retract" — отзыв всех голосов пользователя;
+// "rank" — открытие диалога ранжирования вариантов рейтингового опроса;
+// "add_option" — открытие диалога добавления варианта в опрос с открытыми вариантами.
+// Результат действия возвращается пользователю в виде временного (ephemeral) сообщения на его языке.
+func PollAction(s *services.PollService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := r.Context().Value(actionRequestKey{}).(*model.PostActionIntegrationRequest)
+		if !ok {
+			http.Error(w, "action request is missing", http.StatusBadRequest)
+			return
+		}
+
+		pollId, _ := req.Context["poll_id"].(string)
+		action, _ := req.Context["action"].(string)
+		locale := s.UserLocale(req.UserId)
+
+		var msg *entities.Message
+		var err error
+		switch action {
+		case "vote":
+			option, _ := req.Context["option"].(string)
+			msg, err = s.Vote(&entities.Voice{PollId: pollId, UserId: req.UserId, Option: option})
+		case "retract":
+			msg, err = s.RetractVote(&entities.Voice{PollId: pollId, UserId: req.UserId})
+		case "rank":
+			err = s.OpenRankPollDialog(req.TriggerId, req.UserId, pollId, locale)
+		case "add_option":
+			err = s.OpenAddOptionDialog(req.TriggerId, req.UserId, pollId, locale)
+		default:
+			http.Error(w, "unknown action", http.StatusBadRequest)
+			return
+		}
+
+		if err != nil {
+			if userErr, ok := err.(*entities.UserError); ok {
+				writeActionResponse(w, userErr.Localize(locale))
+				return
+			}
+
+			log.Println(err)
+			http.Error(w, "failed to handle action", http.StatusInternalServerError)
+			return
+		}
+
+		var text string
+		if msg != nil {
+			text = msg.Localize(locale)
+		}
+		writeActionResponse(w, text)
+	}
+}
+
+// writeActionResponse отправляет ответ на нажатие кнопки с временным сообщением text.
+func writeActionResponse(w http.ResponseWriter, text string) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&model.PostActionIntegrationResponse{EphemeralText: text}); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
+	"matterpoll-bot/internal/parser"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// parseArgs разбирает параметр "text" слеш-команды с флагами flags и проверяет количество позиционных аргументов:
+// от minArgs до maxArgs (отрицательный maxArgs снимает ограничение сверху).
+// Если строка некорректна, пользователю отправляется сообщение об ошибке на языке locale
+// с примером example и возвращается nil.
+func parseArgs(w http.ResponseWriter, locale, text string, flags parser.Flags, minArgs, maxArgs int, example string) *parser.Command {
+	cmd, err := parser.Parse(text, flags)
+	if err != nil {
+		writeEphemeral(w, i18n.T(locale, "command.invalid_format_reason", err, example))
+		return nil
+	}
+
+	if len(cmd.Args) < minArgs || (maxArgs >= 0 && len(cmd.Args) > maxArgs) {
+		writeEphemeral(w, i18n.T(locale, "command.invalid_format", example))
+		return nil
+	}
+
+	return cmd
+}
+
+// parseDeadline разбирает срок автоматического закрытия опроса относительно момента now.
+// Срок задается длительностью (`2h`, `90m`) или временем в UTC (`2025-01-02T15:04` или RFC 3339).
+// Возвращает время закрытия в формате Unix или пользовательскую ошибку, если срок некорректен или уже прошел.
+func parseDeadline(value string, now time.Time) (int64, error) {
+	var deadline time.Time
+	if duration, err := time.ParseDuration(value); err == nil {
+		deadline = now.Add(duration)
+	} else if deadline, err = time.Parse(time.RFC3339, value); err != nil {
+		if deadline, err = time.Parse("2006-01-02T15:04", value); err != nil {
+			return 0, entities.NewUserError("poll.invalid_deadline")
+		}
+	}
+
+	if !deadline.After(now) {
+		return 0, entities.NewUserError("poll.past_deadline")
+	}
+
+	return deadline.Unix(), nil
+}
+
+// parseWeights разбирает таблицу весов голосов взвешенного опроса в формате `@alice=3, @committee=2`,
+// где слева от знака равенства указывается имя пользователя или группы Mattermost, а справа - положительный вес.
+// Элементы таблицы разделяются запятыми или пробелами.
+// Возвращает веса по именам или пользовательскую ошибку, если таблица некорректна.
+func parseWeights(value string) (map[string]int32, error) {
+	invalid := entities.NewUserError("poll.invalid_weights")
+
+	items := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	if len(items) == 0 {
+		return nil, invalid
+	}
+
+	weights := make(map[string]int32, len(items))
+	for _, item := range items {
+		name, weightText, ok := strings.Cut(item, "=")
+		name = strings.TrimPrefix(name, "@")
+		if !ok || name == "" {
+			return nil, invalid
+		}
+
+		weight, err := strconv.ParseInt(weightText, 10, 32)
+		if err != nil || weight < 1 {
+			return nil, invalid
+		}
+		weights[name] = int32(weight)
+	}
+
+	return weights, nil
+}
+
+// parseQuorum разбирает кворум опроса: минимальное количество проголосовавших (`10`)
+// или минимальную долю участников канала в процентах (`50%`).
+// Возвращает количество проголосовавших и долю участников (одно из значений равно 0)
+// или пользовательскую ошибку, если кворум некорректен.
+func parseQuorum(value string) (int32, int32, error) {
+	percentText, isPercent := strings.CutSuffix(strings.TrimSpace(value), "%")
+
+	number, err := strconv.ParseInt(percentText, 10, 32)
+	if err != nil || number < 1 || (isPercent && number > 100) {
+		return 0, 0, entities.NewUserError("poll.invalid_quorum")
+	}
+
+	if isPercent {
+		return 0, int32(number), nil
+	}
+	return int32(number), 0, nil
+}
+
+// parseThreshold разбирает порог принятия решения: долю голосов лидирующего варианта
+// в виде дроби (`2/3`) или в процентах (`60%`).
+// Возвращает порог от 0 до 1 или пользовательскую ошибку, если порог некорректен.
+func parseThreshold(value string) (float64, error) {
+	invalid := entities.NewUserError("poll.invalid_threshold")
+
+	value = strings.TrimSpace(value)
+	var numerator, denominator int64
+	var err error
+	if percentText, ok := strings.CutSuffix(value, "%"); ok {
+		denominator = 100
+		numerator, err = strconv.ParseInt(percentText, 10, 32)
+	} else {
+		numeratorText, denominatorText, ok := strings.Cut(value, "/")
+		if !ok {
+			return 0, invalid
+		}
+		if numerator, err = strconv.ParseInt(numeratorText, 10, 32); err == nil {
+			denominator, err = strconv.ParseInt(denominatorText, 10, 32)
+		}
+	}
+
+	if err != nil || numerator < 1 || denominator < 1 || numerator > denominator {
+		return 0, invalid
+	}
+
+	return float64(numerator) / float64(denominator), nil
+}
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
+	"matterpoll-bot/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// SubmitDialog обрабатывает отправку интерактивных диалогов.
+// Ожидается, что запрос прошел проверку в DialogValidatorMiddleware.
+// Для диалога создания опроса проверяются введенные значения: при ошибках они возвращаются
+// в ответе и отображаются Mattermost рядом с соответствующими полями диалога,
+// иначе опрос создается и публикуется в канале, из которого был открыт диалог.
+// Для диалога ранжирования из выбранных вариантов составляется бюллетень рейтингового опроса,
+// а из диалога добавления варианта введенный вариант добавляется в опрос.
+func SubmitDialog(s *services.PollService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := r.Context().Value(dialogRequestKey{}).(*model.SubmitDialogRequest)
+		if !ok {
+			http.Error(w, "dialog request is missing", http.StatusBadRequest)
+			return
+		}
+
+		if req.Cancelled {
+			return
+		}
+
+		dialogId, pollId, _ := strings.Cut(req.CallbackId, ":")
+		locale := s.UserLocale(req.UserId)
+		switch dialogId {
+		case services.CreatePollDialogId:
+			submitCreatePollDialog(s, w, req, locale)
+		case services.RankPollDialogId:
+			submitRankPollDialog(s, w, req, locale, pollId)
+		case services.AddOptionDialogId:
+			submitAddOptionDialog(s, w, req, locale, pollId)
+		default:
+			http.Error(w, "unknown dialog", http.StatusBadRequest)
+		}
+	}
+}
+
+// submitCreatePollDialog проверяет значения диалога создания опроса, создает и публикует опрос.
+// Ошибки проверки возвращаются на языке locale.
+func submitCreatePollDialog(s *services.PollService, w http.ResponseWriter, req *model.SubmitDialogRequest, locale string) {
+	question, _ := req.Submission["question"].(string)
+	optionsText, _ := req.Submission["options"].(string)
+	maxVotesText, _ := req.Submission["max_votes"].(string)
+	public, _ := req.Submission["public"].(bool)
+	ranked, _ := req.Submission["ranked"].(bool)
+	openOptions, _ := req.Submission["open_options"].(bool)
+	hideResults, _ := req.Submission["hide_results"].(bool)
+	weightsText, _ := req.Submission["weights"].(string)
+	quorumText, _ := req.Submission["quorum"].(string)
+	thresholdText, _ := req.Submission["threshold"].(string)
+	ends, _ := req.Submission["ends"].(string)
+
+	question = strings.TrimSpace(question)
+	options := []string{}
+	for _, line := range strings.Split(optionsText, "\n") {
+		if option := strings.TrimSpace(line); option != "" {
+			options = append(options, option)
+		}
+	}
+
+	errs := map[string]string{}
+	if question == "" {
+		errs["question"] = i18n.T(locale, "dialog.question_empty")
+	}
+	if len(options) < 2 {
+		errs["options"] = i18n.T(locale, "dialog.options_few")
+	}
+
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		if seen[option] {
+			errs["options"] = i18n.T(locale, "dialog.option_duplicated", option)
+		}
+		seen[option] = true
+	}
+
+	maxVotes, err := strconv.Atoi(maxVotesText)
+	if err != nil || maxVotes < 0 {
+		errs["max_votes"] = i18n.T(locale, "dialog.max_votes_empty")
+	} else if maxVotes > len(options) {
+		errs["max_votes"] = i18n.T(locale, "dialog.max_votes_too_big")
+	} else if ranked && maxVotes != 1 {
+		errs["max_votes"] = i18n.T(locale, "dialog.max_votes_ranked")
+	}
+
+	var endsAt int64
+	if ends = strings.TrimSpace(ends); ends != "" {
+		if endsAt, err = parseDeadline(ends, time.Now()); err != nil {
+			errs["ends"] = i18n.T(locale, "dialog.invalid_ends")
+		}
+	}
+
+	var quorum, quorumPercent int32
+	if quorumText = strings.TrimSpace(quorumText); quorumText != "" {
+		if quorum, quorumPercent, err = parseQuorum(quorumText); err != nil {
+			errs["quorum"] = i18n.T(locale, "dialog.invalid_quorum")
+		}
+	}
+
+	var threshold float64
+	if thresholdText = strings.TrimSpace(thresholdText); thresholdText != "" {
+		if threshold, err = parseThreshold(thresholdText); err != nil {
+			errs["threshold"] = i18n.T(locale, "dialog.invalid_threshold")
+		}
+	}
+
+	var names map[string]int32
+	if weightsText = strings.TrimSpace(weightsText); weightsText != "" {
+		if names, err = parseWeights(weightsText); err != nil {
+			errs["weights"] = i18n.T(locale, "dialog.invalid_weights")
+		}
+	}
+
+	if len(errs) != 0 {
+		writeDialogResponse(w, &model.SubmitDialogResponse{Errors: errs})
+		return
+	}
+
+	weights, err := s.ResolveWeights(names)
+	if err != nil {
+		if userErr, ok := err.(*entities.UserError); ok {
+			writeDialogResponse(w, &model.SubmitDialogResponse{Errors: map[string]string{"weights": userErr.Localize(locale)}})
+			return
+		}
+
+		log.Println(err)
+		http.Error(w, "failed to resolve weights", http.StatusInternalServerError)
+		return
+	}
+
+	poll := services.NewPoll(question, options, req.UserId)
+	poll.MaxVotes = int32(maxVotes)
+	poll.Public = public
+	poll.Ranked = ranked
+	poll.OpenOptions = openOptions
+	poll.HideResults = hideResults
+	poll.Weights = weights
+	poll.Quorum = quorum
+	poll.QuorumPercent = quorumPercent
+	poll.Threshold = threshold
+	poll.EndsAt = endsAt
+	poll.ChannelId = req.ChannelId
+	poll.TeamId = req.TeamId
+
+	if err := s.CreatePoll(poll); err != nil {
+		if userErr, ok := err.(*entities.UserError); ok {
+			writeDialogResponse(w, &model.SubmitDialogResponse{Error: userErr.Localize(locale)})
+			return
+		}
+
+		log.Println(err)
+		http.Error(w, "failed to create Poll", http.StatusInternalServerError)
+		return
+	}
+
+	if err := s.PostPoll(poll); err != nil {
+		s.DiscardPoll(poll)
+		log.Println(err)
+		http.Error(w, "failed to post Poll", http.StatusInternalServerError)
+	}
+}
+
+// submitRankPollDialog составляет бюллетень из вариантов, выбранных в диалоге ранжирования,
+// и регистрирует голос пользователя в рейтинговом опросе pollId. Ошибки возвращаются на языке locale.
+func submitRankPollDialog(s *services.PollService, w http.ResponseWriter, req *model.SubmitDialogRequest, locale, pollId string) {
+	ranking := []string{}
+	errs := map[string]string{}
+	seen := map[string]bool{}
+	for i := 1; ; i++ {
+		name := "choice_" + strconv.Itoa(i)
+		value, exists := req.Submission[name]
+		if !exists {
+			break
+		}
+
+		option, _ := value.(string)
+		if option == "" {
+			continue
+		}
+		if seen[option] {
+			errs[name] = i18n.T(locale, "dialog.option_ranked", option)
+		}
+		seen[option] = true
+		ranking = append(ranking, option)
+	}
+
+	if len(ranking) == 0 {
+		errs["choice_1"] = i18n.T(locale, "dialog.rank_empty")
+	}
+
+	if len(errs) != 0 {
+		writeDialogResponse(w, &model.SubmitDialogResponse{Errors: errs})
+		return
+	}
+
+	if _, err := s.Vote(&entities.Voice{PollId: pollId, UserId: req.UserId, Ranking: ranking}); err != nil {
+		if userErr, ok := err.(*entities.UserError); ok {
+			writeDialogResponse(w, &model.SubmitDialogResponse{Error: userErr.Localize(locale)})
+			return
+		}
+
+		log.Println(err)
+		http.Error(w, "failed to vote", http.StatusInternalServerError)
+	}
+}
+
+// submitAddOptionDialog добавляет вариант, введенный в диалоге, в опрос pollId с открытыми вариантами.
+// Пользовательские ошибки отображаются на языке locale рядом с полем варианта.
+func submitAddOptionDialog(s *services.PollService, w http.ResponseWriter, req *model.SubmitDialogRequest, locale, pollId string) {
+	option, _ := req.Submission["option"].(string)
+
+	if _, err := s.AddOption(pollId, req.UserId, option); err != nil {
+		if userErr, ok := err.(*entities.UserError); ok {
+			writeDialogResponse(w, &model.SubmitDialogResponse{Errors: map[string]string{"option": userErr.Localize(locale)}})
+			return
+		}
+
+		log.Println(err)
+		http.Error(w, "failed to add option", http.StatusInternalServerError)
+	}
+}
+
+// writeDialogResponse отправляет ответ на отправку диалога с ошибками проверки.
+func writeDialogResponse(w http.ResponseWriter, resp *model.SubmitDialogResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,522 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"matterpoll-bot/config"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/handlers"
+	"matterpoll-bot/internal/services"
+	"matterpoll-bot/internal/services/service_mocks"
+	"matterpoll-bot/internal/storage/store_mocks"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testConfig - конфигурация бота, с которой тесты создают сервис голосований и middleware.
+var testConfig = &config.Config{
+	Mode:        config.ModeDatabase,
+	BotToken:    "bot_token",
+	BotHostname: "localhost",
+	BotSocket:   ":8080",
+	TeamName:    "test_team",
+}
+
+// newCommandRequest создает запрос слеш-команды с параметром "text" от пользователя user1.
+func newCommandRequest(text string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Form = url.Values{"text": {text}, "user_id": {"user1"}, "channel_id": {"channel1"}}
+
+	return req
+}
+
+// newBotMock создает мок API Mattermost, в котором у всех пользователей выбран язык интерфейса locale.
+func newBotMock(t *testing.T, locale string) *service_mocks.BotInterface {
+	mockBot := service_mocks.NewBotInterface(t)
+	mockBot.On("GetUser", mock.Anything, "").Return(&model.User{Locale: locale}, &model.Response{StatusCode: 200}, nil).Maybe()
+
+	return mockBot
+}
+
+// commandResponse декодирует ответ слеш-команды из записанного ответа обработчика.
+func commandResponse(t *testing.T, respRec *httptest.ResponseRecorder) *model.CommandResponse {
+	t.Helper()
+
+	require.Equal(t, "application/json", respRec.Header().Get("Content-Type"))
+
+	var resp model.CommandResponse
+	require.NoError(t, json.Unmarshal(respRec.Body.Bytes(), &resp))
+
+	return &resp
+}
+
+// commandText возвращает текст ответа слеш-команды, видимого только вызвавшему ее пользователю.
+func commandText(t *testing.T, respRec *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	resp := commandResponse(t, respRec)
+	require.Equal(t, model.CommandResponseTypeEphemeral, resp.ResponseType)
+
+	return resp.Text
+}
+
+// TestCommandArgs проверяет разбор аргументов слеш-команд в обработчиках.
+func TestCommandArgs(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := newBotMock(t, "en")
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	t.Run("invalid format", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			handler http.HandlerFunc
+			text    string
+			resp    string
+		}{
+			{"create without options", handlers.CreatePoll(pollService), `"Question"`, "**Invalid format!** *Example*: `/poll-create \"Question\" \"Option1\" \"Option2\" ... [--max-votes N] [--public] [--ranked] [--open-options] [--hide-results] [--weights \"@user=N, @group=N\"] [--quorum N|N%] [--threshold 2/3] [--ends 2h]`"},
+			{"create with unknown flag", handlers.CreatePoll(pollService), `"Q" "A" "B" --secret`, "**Invalid format!** unknown flag at position 13: `--secret`. *Example*: `/poll-create \"Question\" \"Option1\" \"Option2\" ... [--max-votes N] [--public] [--ranked] [--open-options] [--hide-results] [--weights \"@user=N, @group=N\"] [--quorum N|N%] [--threshold 2/3] [--ends 2h]`"},
+			{"create with invalid quorum", handlers.CreatePoll(pollService), `"Q" "A" "B" --quorum 120%`, "**Invalid quorum!** *Expected*: a number of voters or a percent of channel members (e.g. `10` or `50%`)"},
+			{"create with invalid threshold", handlers.CreatePoll(pollService), `"Q" "A" "B" --threshold 3/2`, "**Invalid threshold!** *Expected*: a fraction or a percent of votes (e.g. `2/3` or `60%`)"},
+			{"create with invalid weights", handlers.CreatePoll(pollService), `"Q" "A" "B" --weights "@alice=two"`, "**Invalid weights!** *Expected*: `@user=N` or `@group=N` separated by commas (e.g. `@alice=3, @committee=2`)"},
+			{"vote with unterminated quote", handlers.Vote(pollService), `"poll1" "Option`, "**Invalid format!** unterminated quote at position 9: `\"Option`. *Example*: `/poll-vote \"Poll_ID\" \"Option\" ...`"},
+			{"add option without option", handlers.AddOption(pollService), `"poll1"`, "**Invalid format!** *Example*: `/poll-add-option \"Poll_ID\" \"Option\"`"},
+			{"retract with extra argument", handlers.RetractVote(pollService), `"poll1" "A" "B"`, "**Invalid format!** *Example*: `/poll-retract \"Poll_ID\" [\"Option\"]`"},
+			{"results without poll_id", handlers.GetPollResults(pollService), ``, "**Invalid format!** *Example*: `/poll-results \"Poll_ID\" [--format csv|json] [--chart] [--share]`"},
+			{"close with extra argument", handlers.ClosePoll(pollService), `"poll1" "poll2"`, "**Invalid format!** *Example*: `/poll-close \"Poll_ID\"`"},
+			{"delete without poll_id", handlers.DeletePoll(pollService), `  `, "**Invalid format!** *Example*: `/poll-delete \"Poll_ID\"`"},
+			{"schedule without options", handlers.ScheduleCommand(pollService), `create "0 12 * * 5" "Question"`, "**Invalid format!** *Example*: `/poll-schedule create \"0 12 * * 5\" \"Question\" \"Option1\" \"Option2\" ... [--max-votes N] [--public] [--ranked] [--duration 2h]`"},
+			{"schedule with invalid duration", handlers.ScheduleCommand(pollService), `create "@daily" "Q" "A" "B" --duration 30s`, "**Invalid duration!** *Expected*: a duration of at least a minute (e.g. `2h` or `90m`)"},
+			{"pause schedule without schedule_id", handlers.ScheduleCommand(pollService), `pause`, "**Invalid format!** *Example*: `/poll-schedule pause \"Schedule_ID\"`"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				respRec := httptest.NewRecorder()
+				tt.handler.ServeHTTP(respRec, newCommandRequest(tt.text))
+
+				require.Equal(t, http.StatusOK, respRec.Code)
+				require.Equal(t, tt.resp, commandText(t, respRec))
+			})
+		}
+	})
+
+	t.Run("vote with smart quotes", func(t *testing.T) {
+		voice := &entities.Voice{PollId: "poll1", UserId: "user1", Option: `Option "1"`, Ranking: []string{`Option "1"`}}
+		mockStore.On("Vote", voice).Return(entities.NewMessage("vote.recorded"), nil).Once()
+		mockStore.On("GetPoll", "poll1").Return(&entities.Poll{PollId: "poll1"}, nil).Twice()
+
+		respRec := httptest.NewRecorder()
+		handlers.Vote(pollService).ServeHTTP(respRec, newCommandRequest(`“poll1”   "Option \"1\""`))
+
+		require.Equal(t, "**Voice recorded!**", commandText(t, respRec))
+		mockStore.AssertCalled(t, "Vote", voice)
+	})
+
+	t.Run("results with format", func(t *testing.T) {
+		mockStore.On("GetPoll", "poll1").Return(&entities.Poll{PollId: "poll1", Options: map[string]int32{"A": 0}}, nil).Once()
+		mockBot.On("UploadFile", mock.Anything, "channel1", "poll_poll1.json").Return(&model.FileUploadResponse{FileInfos: []*model.FileInfo{{Id: "file1"}}}, &model.Response{StatusCode: 201}, nil).Once()
+		mockBot.On("CreatePost", mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 201}, nil).Once()
+
+		respRec := httptest.NewRecorder()
+		handlers.GetPollResults(pollService).ServeHTTP(respRec, newCommandRequest(`"poll1" --format JSON`))
+
+		require.Equal(t, "**Results exported!**", commandText(t, respRec))
+	})
+
+	t.Run("results", func(t *testing.T) {
+		for _, tt := range []struct {
+			name         string
+			text         string
+			responseType string
+		}{
+			{"Ephemeral", `"poll1"`, model.CommandResponseTypeEphemeral},
+			{"Shared", `"poll1" --share`, model.CommandResponseTypeInChannel},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				mockStore.On("GetPoll", "poll1").Return(&entities.Poll{PollId: "poll1", Question: "Lunch?", Options: map[string]int32{"A": 0}}, nil).Once()
+
+				respRec := httptest.NewRecorder()
+				handlers.GetPollResults(pollService).ServeHTTP(respRec, newCommandRequest(tt.text))
+
+				resp := commandResponse(t, respRec)
+				require.Equal(t, tt.responseType, resp.ResponseType)
+				require.Empty(t, resp.Text)
+				require.Len(t, resp.Attachments, 1)
+				require.Contains(t, resp.Attachments[0].Text, "Lunch?")
+				require.Equal(t, resp.Attachments[0].Text, resp.Attachments[0].Fallback)
+			})
+		}
+	})
+
+	t.Run("shared results hidden from creator", func(t *testing.T) {
+		poll := &entities.Poll{PollId: "poll1", Creator: "user1", HideResults: true, Options: map[string]int32{"A": 1}}
+		mockStore.On("GetPoll", "poll1").Return(poll, nil).Once()
+
+		respRec := httptest.NewRecorder()
+		handlers.GetPollResults(pollService).ServeHTTP(respRec, newCommandRequest(`"poll1" --share`))
+
+		resp := commandResponse(t, respRec)
+		require.Equal(t, model.CommandResponseTypeInChannel, resp.ResponseType)
+		require.Contains(t, resp.Attachments[0].Text, "*Results are hidden until the poll is closed.*")
+	})
+
+	t.Run("shared results for a non-member", func(t *testing.T) {
+		mockStore.On("GetPoll", "poll1").Return(&entities.Poll{PollId: "poll1", ChannelId: "channel2", Options: map[string]int32{"A": 1}}, nil).Once()
+		mockBot.On("GetChannelMember", "channel2", "user1", "").Return(nil, &model.Response{StatusCode: 404}, errors.New("not found")).Once()
+
+		respRec := httptest.NewRecorder()
+		handlers.GetPollResults(pollService).ServeHTTP(respRec, newCommandRequest(`"poll1" --share`))
+
+		resp := commandResponse(t, respRec)
+		require.Equal(t, model.CommandResponseTypeEphemeral, resp.ResponseType)
+		require.Equal(t, "**Only members of the poll's channel can see its results!**", resp.Text)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		mockStore.On("GetPoll", "poll2").Return(nil, errors.New("connection refused")).Once()
+
+		respRec := httptest.NewRecorder()
+		handlers.GetPollResults(pollService).ServeHTTP(respRec, newCommandRequest(`"poll2"`))
+
+		require.Equal(t, http.StatusOK, respRec.Code)
+		require.Equal(t, "**Failed to get poll results!** Please try again later.", commandText(t, respRec))
+	})
+
+	t.Run("user error", func(t *testing.T) {
+		mockStore.On("GetPoll", "poll3").Return(nil, entities.NewUserError("**Poll not found!**")).Once()
+
+		respRec := httptest.NewRecorder()
+		handlers.GetPollResults(pollService).ServeHTTP(respRec, newCommandRequest(`"poll3" --share`))
+
+		require.Equal(t, http.StatusOK, respRec.Code)
+		require.Equal(t, "**Poll not found!**", commandText(t, respRec))
+	})
+
+	t.Run("create with flags", func(t *testing.T) {
+		mockStore.On("CreatePoll", mock.Anything).Return(nil).Once()
+		mockBot.On("CreatePost", mock.Anything).Return(&model.Post{Id: "post1"}, &model.Response{StatusCode: 201}, nil).Once()
+		mockStore.On("SetPollPost", mock.Anything, "post1").Return(nil).Once()
+
+		respRec := httptest.NewRecorder()
+		handlers.CreatePoll(pollService).ServeHTTP(respRec, newCommandRequest(`--public "Question" "Option 1" "Option 2" --max-votes 0 --open-options --hide-results`))
+
+		require.Equal(t, http.StatusOK, respRec.Code)
+		mockStore.AssertCalled(t, "CreatePoll", mock.MatchedBy(func(poll *entities.Poll) bool {
+			_, hasOption := poll.Options["Option 1"]
+			return poll.Question == "Question" && hasOption && len(poll.Options) == 2 &&
+				poll.Public && poll.OpenOptions && poll.HideResults && poll.MaxVotes == 0 && poll.ChannelId == "channel1"
+		}))
+	})
+
+	t.Run("create with failed post", func(t *testing.T) {
+		mockStore.On("CreatePoll", mock.Anything).Return(nil).Once()
+		mockBot.On("CreatePost", mock.Anything).Return(nil, &model.Response{StatusCode: 403}, errors.New("forbidden")).Once()
+		mockStore.On("DeletePoll", mock.Anything, "user1", false).Return(entities.NewMessage("poll.deleted", "poll1"), nil).Once()
+
+		respRec := httptest.NewRecorder()
+		handlers.CreatePoll(pollService).ServeHTTP(respRec, newCommandRequest(`"Question" "Option 1" "Option 2"`))
+
+		require.Equal(t, "**Failed to post poll!** Please try again later.", commandText(t, respRec))
+		mockStore.AssertCalled(t, "DeletePoll", mock.Anything, "user1", false)
+	})
+}
+
+// TestSubmitCreatePollDialog проверяет создание опроса из диалога.
+func TestSubmitCreatePollDialog(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := newBotMock(t, "en")
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+	handler := handlers.DialogValidatorMiddleware(pollService, handlers.SubmitDialog(pollService))
+
+	newRequest := func() *http.Request {
+		body, err := json.Marshal(&model.SubmitDialogRequest{
+			CallbackId: services.CreatePollDialogId,
+			UserId:     "user1",
+			ChannelId:  "channel1",
+			State:      pollService.SignAction(services.DialogDomain, "user1"),
+			Submission: map[string]interface{}{"question": "Question", "options": "Option 1\nOption 2", "max_votes": "1"},
+		})
+		require.NoError(t, err)
+
+		return httptest.NewRequest(http.MethodPost, "/poll-dialog", bytes.NewReader(body))
+	}
+
+	t.Run("failed to post poll", func(t *testing.T) {
+		mockStore.On("CreatePoll", mock.Anything).Return(nil).Once()
+		mockBot.On("CreatePost", mock.Anything).Return(nil, &model.Response{StatusCode: 403}, errors.New("forbidden")).Once()
+		mockStore.On("DeletePoll", mock.Anything, "user1", false).Return(entities.NewMessage("poll.deleted", "poll1"), nil).Once()
+
+		respRec := httptest.NewRecorder()
+		handler.ServeHTTP(respRec, newRequest())
+
+		require.Equal(t, http.StatusInternalServerError, respRec.Code)
+		mockStore.AssertCalled(t, "DeletePoll", mock.Anything, "user1", false)
+	})
+}
+
+// TestPollCommand проверяет обработку подкоманд команды /poll.
+func TestPollCommand(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := newBotMock(t, "en")
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+	handler := handlers.PollCommand(pollService)
+
+	t.Run("help", func(t *testing.T) {
+		for _, text := range []string{"", "help"} {
+			respRec := httptest.NewRecorder()
+			handler.ServeHTTP(respRec, newCommandRequest(text))
+
+			require.Contains(t, commandText(t, respRec), "**Available commands:**")
+			require.Contains(t, commandText(t, respRec), "- `/poll results \"poll_id\" [--format csv|json] [--chart] [--share]` — Get poll results, post them with a chart or export them as a file")
+			require.Contains(t, commandText(t, respRec), "- `/poll list [--mine] [--channel] [--open | --closed] [--page N]` — List polls of the channel or your own polls")
+		}
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		respRec := httptest.NewRecorder()
+		handler.ServeHTTP(respRec, newCommandRequest("remove poll1"))
+
+		require.Contains(t, commandText(t, respRec), "**Unknown command** `remove`!")
+		require.Contains(t, commandText(t, respRec), "**Available commands:**")
+	})
+
+	t.Run("subcommand", func(t *testing.T) {
+		voice := &entities.Voice{PollId: "poll1", UserId: "user1", Option: "Option 1", Ranking: []string{"Option 1"}}
+		mockStore.On("Vote", voice).Return(entities.NewMessage("vote.recorded"), nil).Once()
+		mockStore.On("GetPoll", "poll1").Return(&entities.Poll{PollId: "poll1"}, nil).Twice()
+
+		respRec := httptest.NewRecorder()
+		handler.ServeHTTP(respRec, newCommandRequest(`vote "poll1" "Option 1"`))
+
+		require.Equal(t, "**Voice recorded!**", commandText(t, respRec))
+	})
+
+	t.Run("subcommand invalid format", func(t *testing.T) {
+		respRec := httptest.NewRecorder()
+		handler.ServeHTTP(respRec, newCommandRequest(`close`))
+
+		require.Equal(t, "**Invalid format!** *Example*: `/poll-close \"Poll_ID\"`", commandText(t, respRec))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		filter := &entities.PollFilter{ChannelId: "channel1", Limit: services.PollListPageSize}
+		mockStore.On("ListPolls", filter).Return([]*entities.Poll{{PollId: "poll1", Question: "Question"}}, 1, nil).Once()
+
+		respRec := httptest.NewRecorder()
+		handler.ServeHTTP(respRec, newCommandRequest("list"))
+
+		require.Contains(t, commandText(t, respRec), "| `poll1` | Question | `0` | 🟢 (Active) |")
+	})
+}
+
+// TestListPolls проверяет разбор фильтров команды получения списка опросов.
+func TestListPolls(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	pollService := services.NewPollService(newBotMock(t, "en"), mockStore, testConfig)
+	handler := handlers.ListPolls(pollService)
+
+	open, closed := false, true
+	tests := []struct {
+		name   string
+		text   string
+		filter *entities.PollFilter
+	}{
+		{"Channel", "", &entities.PollFilter{ChannelId: "channel1", Limit: 10}},
+		{"Mine", "--mine", &entities.PollFilter{Creator: "user1", Limit: 10}},
+		{"Mine in channel", "--mine --channel", &entities.PollFilter{ChannelId: "channel1", Creator: "user1", Limit: 10}},
+		{"Open", "--open", &entities.PollFilter{ChannelId: "channel1", Closed: &open, Limit: 10}},
+		{"Closed", "--closed --page 3", &entities.PollFilter{ChannelId: "channel1", Closed: &closed, Offset: 20, Limit: 10}},
+		{"Open and closed", "--open --closed", &entities.PollFilter{ChannelId: "channel1", Limit: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore.On("ListPolls", tt.filter).Return([]*entities.Poll{}, 0, nil).Once()
+
+			respRec := httptest.NewRecorder()
+			handler.ServeHTTP(respRec, newCommandRequest(tt.text))
+
+			require.Equal(t, "**No polls found!**", commandText(t, respRec))
+		})
+	}
+
+	t.Run("Pagination", func(t *testing.T) {
+		filter := &entities.PollFilter{ChannelId: "channel1", Offset: 10, Limit: 10}
+		mockStore.On("ListPolls", filter).Return([]*entities.Poll{{PollId: "poll11", Question: "Question"}}, 11, nil).Once()
+
+		respRec := httptest.NewRecorder()
+		handler.ServeHTTP(respRec, newCommandRequest("--page 2"))
+
+		require.Equal(t, "| Poll_ID | Question | Voters | Status |\n"+
+			"|---------|----------|--------|--------|\n"+
+			"| `poll11` | Question | `0` | 🟢 (Active) |\n\n"+
+			"*Page 2 of 2* (polls found: 11)", commandText(t, respRec))
+	})
+
+	t.Run("Invalid page", func(t *testing.T) {
+		respRec := httptest.NewRecorder()
+		handler.ServeHTTP(respRec, newCommandRequest("--page 0"))
+
+		require.Equal(t, "**Invalid format!** `--page` must be a positive number", commandText(t, respRec))
+	})
+}
+
+// TestCreatePollContext проверяет, что опрос сохраняется с каналом, командой и временем создания.
+func TestCreatePollContext(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := newBotMock(t, "en")
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	req := newCommandRequest(`"Question" "Option 1" "Option 2"`)
+	req.Form.Set("team_id", "team1")
+
+	mockStore.On("CreatePoll", mock.MatchedBy(func(poll *entities.Poll) bool {
+		return poll.ChannelId == "channel1" && poll.TeamId == "team1" && poll.CreatedAt > 0
+	})).Return(nil).Once()
+	mockBot.On("CreatePost", mock.Anything).Return(&model.Post{Id: "post1"}, &model.Response{StatusCode: 201}, nil).Once()
+	mockStore.On("SetPollPost", mock.Anything, "post1").Return(nil).Once()
+
+	respRec := httptest.NewRecorder()
+	handlers.CreatePoll(pollService).ServeHTTP(respRec, req)
+
+	require.Equal(t, http.StatusOK, respRec.Code)
+}
+
+// TestCreateWeightedPoll проверяет создание взвешенного опроса с весами пользователей.
+func TestCreateWeightedPoll(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := newBotMock(t, "en")
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	mockBot.On("GetUserByUsername", "alice", "").Return(&model.User{Id: "user2"}, &model.Response{StatusCode: 200}, nil).Once()
+	mockBot.On("GetUserByUsername", "bob", "").Return(&model.User{Id: "user3"}, &model.Response{StatusCode: 200}, nil).Once()
+	mockStore.On("CreatePoll", mock.MatchedBy(func(poll *entities.Poll) bool {
+		return len(poll.Weights) == 2 && poll.Weights["user2"] == 3 && poll.Weights["user3"] == 2
+	})).Return(nil).Once()
+	mockBot.On("CreatePost", mock.Anything).Return(&model.Post{Id: "post1"}, &model.Response{StatusCode: 201}, nil).Once()
+	mockStore.On("SetPollPost", mock.Anything, "post1").Return(nil).Once()
+
+	respRec := httptest.NewRecorder()
+	handlers.CreatePoll(pollService).ServeHTTP(respRec, newCommandRequest(`"Question" "Option 1" "Option 2" --weights "@alice=3, bob=2"`))
+
+	require.Equal(t, http.StatusOK, respRec.Code)
+}
+
+// TestCreateDecisionPoll проверяет создание опроса с кворумом и порогом принятия решения.
+func TestCreateDecisionPoll(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := newBotMock(t, "en")
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	mockStore.On("CreatePoll", mock.MatchedBy(func(poll *entities.Poll) bool {
+		return poll.Quorum == 0 && poll.QuorumPercent == 50 && poll.Threshold == 2.0/3
+	})).Return(nil).Once()
+	mockBot.On("CreatePost", mock.Anything).Return(&model.Post{Id: "post1"}, &model.Response{StatusCode: 201}, nil).Once()
+	mockStore.On("SetPollPost", mock.Anything, "post1").Return(nil).Once()
+
+	respRec := httptest.NewRecorder()
+	handlers.CreatePoll(pollService).ServeHTTP(respRec, newCommandRequest(`"Question" "Option 1" "Option 2" --quorum 50% --threshold 2/3`))
+
+	require.Equal(t, http.StatusOK, respRec.Code)
+}
+
+// TestScheduleCommand проверяет действия команды управления повторяющимися опросами.
+func TestScheduleCommand(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := newBotMock(t, "en")
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	t.Run("create", func(t *testing.T) {
+		mockStore.On("CreateSchedule", mock.MatchedBy(func(schedule *entities.Schedule) bool {
+			return schedule.Cron == "0 12 * * 5" && schedule.Question == "Where do we lunch?" && len(schedule.Options) == 2 &&
+				schedule.Creator == "user1" && schedule.ChannelId == "channel1" && schedule.Duration == 7200 && schedule.Public
+		})).Return(nil).Once()
+
+		respRec := httptest.NewRecorder()
+		handlers.ScheduleCommand(pollService).ServeHTTP(respRec, newCommandRequest(`create "0 12 * * 5" "Where do we lunch?" "Pizza" "Sushi" --duration 2h --public`))
+
+		require.Equal(t, http.StatusOK, respRec.Code)
+		require.Contains(t, commandText(t, respRec), "**has been created!**")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		mockStore.On("ListSchedules", "channel1").Return([]*entities.Schedule{}, nil).Once()
+
+		respRec := httptest.NewRecorder()
+		handlers.ScheduleCommand(pollService).ServeHTTP(respRec, newCommandRequest(`list`))
+
+		require.Equal(t, "**No schedules found!**", commandText(t, respRec))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		mockStore.On("DeleteSchedule", "schedule1", "user1").Return(entities.NewMessage("schedule.removed", "schedule1"), nil).Once()
+
+		respRec := httptest.NewRecorder()
+		handlers.ScheduleCommand(pollService).ServeHTTP(respRec, newCommandRequest(`remove "schedule1"`))
+
+		require.Equal(t, "*Schedule*: `schedule1` **has been removed!**", commandText(t, respRec))
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		respRec := httptest.NewRecorder()
+		handlers.ScheduleCommand(pollService).ServeHTTP(respRec, newCommandRequest(`stop "schedule1"`))
+
+		require.Contains(t, commandText(t, respRec), "**Unknown action** `stop`!")
+		require.Contains(t, commandText(t, respRec), "/poll-schedule list")
+	})
+}
+
+// TestLocalizedResponses проверяет, что ответы на команды выводятся на языке интерфейса пользователя.
+func TestLocalizedResponses(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := newBotMock(t, "ru")
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		text    string
+		setup   func()
+		resp    string
+	}{
+		{"invalid format", handlers.ClosePoll(pollService), ``, nil, "**Неверный формат!** *Пример*: `/poll-close \"Poll_ID\"`"},
+		{"invalid flag value", handlers.ListPolls(pollService), `--page 0`, nil, "**Неверный формат!** `--page` должен быть положительным числом"},
+		{"unknown command", handlers.PollCommand(pollService), `remove poll1`, nil, "**Неизвестная команда** `remove`!"},
+		{"poll help", handlers.PollCommand(pollService), `help`, nil, "**Доступные команды:**\n- `/poll create"},
+		{"schedule help", handlers.ScheduleCommand(pollService), ``, nil, "- `/poll-schedule list` — Показать повторяющиеся опросы канала"},
+		{"poll list", handlers.ListPolls(pollService), ``, func() {
+			mockStore.On("ListPolls", mock.Anything).Return([]*entities.Poll{}, 0, nil).Once()
+		}, "**Опросы не найдены!**"},
+		{"success message", handlers.RetractVote(pollService), `"poll1"`, func() {
+			mockStore.On("RetractVote", &entities.Voice{PollId: "poll1", UserId: "user1"}).Return(entities.NewMessage("vote.retracted"), nil).Once()
+			mockStore.On("GetPoll", "poll1").Return(&entities.Poll{PollId: "poll1"}, nil).Once()
+		}, "**Голос отозван!**"},
+		{"user error", handlers.ReopenPoll(pollService), `"poll1"`, func() {
+			mockStore.On("GetPoll", "poll1").Return(nil, entities.NewUserError("poll.not_found")).Once()
+		}, "**Неверный Poll_ID или опрос не существует!**"},
+		{"internal error", handlers.DeletePoll(pollService), `"poll1"`, func() {
+			mockStore.On("GetPoll", "poll1").Return(nil, errors.New("connection refused")).Once()
+		}, "**Не удалось удалить опрос!** Попробуйте позже."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			respRec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(respRec, newCommandRequest(tt.text))
+
+			require.Equal(t, http.StatusOK, respRec.Code)
+			require.Contains(t, commandText(t, respRec), tt.resp)
+		})
+	}
+}
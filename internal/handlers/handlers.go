@@ -1,113 +1,166 @@
 package handlers
 
 import (
-	"fmt"
-	"log"
 	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
+	"matterpoll-bot/internal/parser"
 	"matterpoll-bot/internal/services"
 	"net/http"
+	"strconv"
 	"strings"
-
-	"github.com/mattermost/mattermost-server/v6/model"
+	"time"
 )
 
+// createPollFlags - флаги команды создания опроса.
+var createPollFlags = parser.Flags{"max-votes": true, "public": false, "ranked": false, "open-options": false, "hide-results": false, "weights": true, "quorum": true, "threshold": true, "ends": true}
+
 // CreatePoll обрабатывает HTTP-запрос и разбирает полученные параметры в соответствии с примером:
-// "text": строка в формате `/poll-create "Question" "Option1" "Option2" ...`,
-// где Question — вопрос для голосвания, а Option1, Option2  — варианты для голоса.
+// "text": строка в формате `/poll-create "Question" "Option1" "Option2" ... [--max-votes N] [--public]`,
+// где Question — вопрос для голосвания, а Option1, Option2  — варианты для голоса,
+// N — количество вариантов, которое может выбрать пользователь (0 - без ограничений, по умолчанию 1),
+// --public — публичный опрос, в результатах которого отображаются имена проголосовавших,
+// --ranked — рейтинговый опрос, результаты которого подсчитываются методом мгновенного второго тура,
+// --open-options — опрос, в который участники могут добавлять свои варианты,
+// --hide-results — опрос, результаты которого скрыты от всех, кроме создателя, до его закрытия,
+// --weights — веса голосов пользователей и групп Mattermost во взвешенном опросе (`@alice=3, @committee=2`),
+// --quorum — кворум: минимальное количество проголосовавших (`10`) или доля участников канала (`50%`),
+// --threshold — порог принятия решения: доля голосов лидирующего варианта (`2/3`, `60%`),
+// --ends — срок автоматического закрытия опроса: длительность (`2h`, `90m`) или время (`2025-01-02T15:04`, в UTC).
 // Обработчик разбирает параметр "text", чтобы извлечь вопрос и варианты ответа.
-// Если создание голосования прошло успешно, возвращается сообщение с результатом
+// Если создание голосования прошло успешно, в канал отправляется сообщение с опросом и кнопками для голосования.
 // Если формат параметра "text" некорректен, возвращается сообщение об ошибке с примером правильного формата.
+// Если параметр "text" пуст, пользователю открывается интерактивный диалог создания опроса.
 // Создает новые опросы, закрепляя за ними id создателя.
 func CreatePoll(s *services.PollService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		text := r.Form.Get("text")
-		args := strings.Split(text, `" "`)
-		if len(args) < 2 {
-			w.Write([]byte("**Invalid format!** *Example*: `/poll-create \"Question\" \"Option1\" \"Option2\" ...`"))
+		if strings.TrimSpace(text) == "" {
+			openCreatePollDialog(s, w, r)
 			return
 		}
 
-		question := strings.Trim(args[0], `"`)
-		options := args[1:]
+		locale := s.UserLocale(r.Form.Get("user_id"))
 
-		voices := make(map[string]int32, len(options))
-		for i := range options {
-			options[i] = strings.Trim(options[i], `"`)
-			voices[options[i]] = 0
+		cmd := parseArgs(w, locale, text, createPollFlags, 2, -1, `/poll-create "Question" "Option1" "Option2" ... [--max-votes N] [--public] [--ranked] [--open-options] [--hide-results] [--weights "@user=N, @group=N"] [--quorum N|N%] [--threshold 2/3] [--ends 2h]`)
+		if cmd == nil {
+			return
 		}
 
-		optionsStr := strings.Join(options, "` `")
-		optionsStr = "`" + optionsStr + "`"
+		question := cmd.Args[0]
+		options := cmd.Args[1:]
 
-		id := model.NewId()
 		userId := r.Form.Get("user_id")
 		if userId == "" {
 			http.Error(w, "'user_id' is empty in the form data", http.StatusBadRequest)
 			return
 		}
 
-		poll := &entities.Poll{
-			PollId:   id,
-			Question: question,
-			Options:  voices,
-			Creator:  userId,
-			Voters:   map[string]bool{},
-			Closed:   false,
+		channelId := r.Form.Get("channel_id")
+		if channelId == "" {
+			http.Error(w, "'channel_id' is empty in the form data", http.StatusBadRequest)
+			return
 		}
 
-		err := s.CreatePoll(poll)
-		if err != nil {
-			if userErr, ok := err.(*entities.UserError); ok {
-				w.Write([]byte(userErr.Error()))
+		poll := services.NewPoll(question, options, userId)
+		if value, ok := cmd.Flags["max-votes"]; ok {
+			maxVotes, err := strconv.Atoi(value)
+			if err != nil {
+				writeEphemeral(w, i18n.T(locale, "command.invalid_max_votes"))
 				return
 			}
-			log.Println(err)
-			http.Error(w, "failed to create Poll", http.StatusInternalServerError)
-			return
+			poll.MaxVotes = int32(maxVotes)
 		}
-
-		channelId := r.Form.Get("channel_id")
-		if channelId == "" {
-			http.Error(w, "'channel_id' is empty in the form data", http.StatusBadRequest)
-			return
+		poll.Public = cmd.Bool("public")
+		poll.Ranked = cmd.Bool("ranked")
+		poll.OpenOptions = cmd.Bool("open-options")
+		poll.HideResults = cmd.Bool("hide-results")
+		if value, ok := cmd.Flags["ends"]; ok {
+			endsAt, err := parseDeadline(value, time.Now())
+			if err != nil {
+				writeCommandError(w, locale, err, "action.create_poll")
+				return
+			}
+			poll.EndsAt = endsAt
+		}
+		if value, ok := cmd.Flags["quorum"]; ok {
+			quorum, quorumPercent, err := parseQuorum(value)
+			if err != nil {
+				writeCommandError(w, locale, err, "action.create_poll")
+				return
+			}
+			poll.Quorum, poll.QuorumPercent = quorum, quorumPercent
+		}
+		if value, ok := cmd.Flags["threshold"]; ok {
+			threshold, err := parseThreshold(value)
+			if err != nil {
+				writeCommandError(w, locale, err, "action.create_poll")
+				return
+			}
+			poll.Threshold = threshold
 		}
+		if value, ok := cmd.Flags["weights"]; ok {
+			names, err := parseWeights(value)
+			if err != nil {
+				writeCommandError(w, locale, err, "action.create_poll")
+				return
+			}
 
-		post := &model.Post{ChannelId: channelId, Message: fmt.Sprintf("**Poll created!** *Poll_ID*: `%s` *Question*: `%s` *Options*: %s", id, question, optionsStr)}
-		_, resp, err := s.Bot.CreatePost(post)
-		if err != nil {
-			if userErr, ok := err.(*entities.UserError); ok {
-				w.Write([]byte(userErr.Error()))
+			poll.Weights, err = s.ResolveWeights(names)
+			if err != nil {
+				writeCommandError(w, locale, err, "action.resolve_weights")
 				return
 			}
+		}
+		poll.ChannelId = channelId
+		poll.TeamId = r.Form.Get("team_id")
 
-			log.Println(err)
-			http.Error(w, "failed to create Poll", http.StatusInternalServerError)
+		if err := s.CreatePoll(poll); err != nil {
+			writeCommandError(w, locale, err, "action.create_poll")
+			return
 		}
 
-		if resp == nil || resp.StatusCode != 201 {
-			w.Write([]byte(fmt.Sprintf("failed to get team: unexpected status code %d", resp.StatusCode)))
+		if err := s.PostPoll(poll); err != nil {
+			s.DiscardPoll(poll)
+			writeCommandError(w, locale, err, "action.post_poll")
 		}
 	}
 }
 
+// openCreatePollDialog открывает диалог создания опроса, используя "trigger_id" из параметров команды.
+func openCreatePollDialog(s *services.PollService, w http.ResponseWriter, r *http.Request) {
+	triggerId := r.Form.Get("trigger_id")
+	userId := r.Form.Get("user_id")
+	if triggerId == "" || userId == "" {
+		http.Error(w, "'trigger_id' or 'user_id' are empty in the form data", http.StatusBadRequest)
+		return
+	}
+
+	locale := s.UserLocale(userId)
+	if err := s.OpenCreatePollDialog(triggerId, userId, locale); err != nil {
+		writeCommandError(w, locale, err, "action.open_dialog")
+	}
+}
+
 // Vote обрабатывает HTTP-запрос для голосования в опросе.
 // Ожидается, что запрос будет содержать параметры формы:
 // "text": строка в формате `"Poll_ID" "Option"`, где Poll_ID — идентификатор опроса, а Option — выбранный вариант.
+// Для рейтингового опроса указывается несколько вариантов в порядке предпочтения: `"Poll_ID" "Option1" "Option2" ...`.
 // Обработчик разбирает параметр "text", чтобы извлечь идентификатор опроса и вариант ответа для голоса.
 // Если голосование успешно, возвращается сообщение с результатом.
 // Если формат параметра "text" некорректен, возвращается сообщение об ошибке с примером правильного формата.
-// В случае ошибки возвращается соответствующее сообщение об ошибке или статус HTTP 500 для внутренних ошибок сервера.
+// В случае ошибки пользователю возвращается видимое только ему сообщение об ошибке (для внутренних ошибок - общее).
 func Vote(s *services.PollService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		locale := s.UserLocale(r.Form.Get("user_id"))
 		text := r.Form.Get("text")
-		args := strings.Split(text, `" "`)
-		if len(args) != 2 {
-			w.Write([]byte("**Invalid format!** *Example*: `/poll-vote \"Poll_ID\" \"Option\"`"))
+		cmd := parseArgs(w, locale, text, nil, 2, -1, `/poll-vote "Poll_ID" "Option" ...`)
+		if cmd == nil {
 			return
 		}
 
-		pollId := strings.Trim(args[0], `"`)
-		option := strings.Trim(args[1], `"`)
+		pollId := cmd.Args[0]
+		ranking := cmd.Args[1:]
+		option := ranking[0]
 
 		userId := r.Form.Get("user_id")
 		if userId == "" {
@@ -115,51 +168,188 @@ func Vote(s *services.PollService) http.HandlerFunc {
 			return
 		}
 
-		voice := &entities.Voice{PollId: pollId, UserId: userId, Option: option}
+		voice := &entities.Voice{PollId: pollId, UserId: userId, Option: option, Ranking: ranking}
 		msg, err := s.Vote(voice)
 		if err != nil {
-			if userErr, ok := err.(*entities.UserError); ok {
-				w.Write([]byte(userErr.Error()))
-				return
-			}
-			log.Println(err)
-			http.Error(w, "failed to vote", http.StatusInternalServerError)
+			writeCommandError(w, locale, err, "action.vote")
+			return
+		}
+
+		writeMessage(w, locale, msg)
+	}
+}
+
+// AddOption обрабатывает HTTP-запрос для добавления варианта в опрос с открытыми вариантами.
+// Ожидается, что запрос будет содержать параметры формы:
+// "text": строка в формате `"Poll_ID" "Option"`, где Poll_ID — идентификатор опроса, а Option — новый вариант.
+// Если формат параметра "text" некорректен, возвращается сообщение об ошибке с примером правильного формата.
+// В случае ошибки пользователю возвращается видимое только ему сообщение об ошибке (для внутренних ошибок - общее).
+func AddOption(s *services.PollService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		locale := s.UserLocale(r.Form.Get("user_id"))
+		text := r.Form.Get("text")
+		cmd := parseArgs(w, locale, text, nil, 2, 2, `/poll-add-option "Poll_ID" "Option"`)
+		if cmd == nil {
+			return
+		}
+
+		pollId := cmd.Args[0]
+		option := cmd.Args[1]
+
+		userId := r.Form.Get("user_id")
+		if userId == "" {
+			http.Error(w, "'user_id' is empty in the form data", http.StatusBadRequest)
+			return
+		}
+
+		msg, err := s.AddOption(pollId, userId, option)
+		if err != nil {
+			writeCommandError(w, locale, err, "action.add_option")
+			return
+		}
+
+		writeMessage(w, locale, msg)
+	}
+}
+
+// RetractVote обрабатывает HTTP-запрос для отзыва голоса в опросе.
+// Ожидается, что запрос будет содержать параметры формы:
+// "text": строка в формате `"Poll_ID" ["Option"]`, где Poll_ID — идентификатор опроса, а Option — отзываемый вариант.
+// Если вариант не указан, отзываются все варианты, выбранные пользователем.
+// Если формат параметра "text" некорректен, возвращается сообщение об ошибке с примером правильного формата.
+// В случае ошибки пользователю возвращается видимое только ему сообщение об ошибке (для внутренних ошибок - общее).
+func RetractVote(s *services.PollService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		locale := s.UserLocale(r.Form.Get("user_id"))
+		text := r.Form.Get("text")
+		cmd := parseArgs(w, locale, text, nil, 1, 2, `/poll-retract "Poll_ID" ["Option"]`)
+		if cmd == nil {
+			return
+		}
+
+		pollId := cmd.Args[0]
+		var option string
+		if len(cmd.Args) == 2 {
+			option = cmd.Args[1]
+		}
+
+		userId := r.Form.Get("user_id")
+		if userId == "" {
+			http.Error(w, "'user_id' is empty in the form data", http.StatusBadRequest)
+			return
+		}
+
+		msg, err := s.RetractVote(&entities.Voice{PollId: pollId, UserId: userId, Option: option})
+		if err != nil {
+			writeCommandError(w, locale, err, "action.retract_vote")
+			return
+		}
+
+		writeMessage(w, locale, msg)
+	}
+}
+
+// ChangeVote обрабатывает HTTP-запрос для изменения голоса в опросе.
+// Ожидается, что запрос будет содержать параметры формы:
+// "text": строка в формате `"Poll_ID" "Option"`, где Poll_ID — идентификатор опроса, а Option — новый вариант.
+// Все ранее выбранные пользователем варианты заменяются на указанный.
+// Для рейтингового опроса указывается новый бюллетень: `"Poll_ID" "Option1" "Option2" ...`.
+// Если формат параметра "text" некорректен, возвращается сообщение об ошибке с примером правильного формата.
+// В случае ошибки пользователю возвращается видимое только ему сообщение об ошибке (для внутренних ошибок - общее).
+func ChangeVote(s *services.PollService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		locale := s.UserLocale(r.Form.Get("user_id"))
+		text := r.Form.Get("text")
+		cmd := parseArgs(w, locale, text, nil, 2, -1, `/poll-change "Poll_ID" "Option" ...`)
+		if cmd == nil {
+			return
+		}
+
+		pollId := cmd.Args[0]
+		ranking := cmd.Args[1:]
+		option := ranking[0]
+
+		userId := r.Form.Get("user_id")
+		if userId == "" {
+			http.Error(w, "'user_id' is empty in the form data", http.StatusBadRequest)
+			return
+		}
+
+		msg, err := s.ChangeVote(&entities.Voice{PollId: pollId, UserId: userId, Option: option, Ranking: ranking})
+		if err != nil {
+			writeCommandError(w, locale, err, "action.change_vote")
+			return
 		}
 
-		w.Write([]byte(msg))
+		writeMessage(w, locale, msg)
 	}
 }
 
+// resultsFlags - флаги команды получения результатов опроса.
+var resultsFlags = parser.Flags{"format": true, "chart": false, "share": false}
+
 // GetPollResults обрабатывает HTTP-запрос для получения результатов опроса.
 // Ожидается, что запрос будет содержать параметр формы:
-// "text": строка в формате `"Poll_ID"`, где Poll_ID — идентификатор опроса.
+// "text": строка в формате `"Poll_ID" [--format csv|json] [--chart] [--share]`, где Poll_ID — идентификатор опроса,
+// --format — формат файла, в который выгружаются результаты,
+// --chart — публикация результатов в канале вместе с диаграммой,
+// --share — публикация результатов в ответе на команду, видимом всем участникам канала.
 // Обработчик разбирает параметр "text", чтобы извлечь идентификатор опроса.
 // Если формат параметра "text" некорректен, возвращается сообщение об ошибке с примером правильного формата.
-// Если получение результатов прошло успешно, возвращается сообщение с результатами опроса.
-// В случае ошибки возвращается соответствующее сообщение об ошибке или статус HTTP 500 для внутренних ошибок сервера.
+// Если получение результатов прошло успешно, результаты опроса возвращаются во вложении сообщения
+// (без --share их видит только вызвавший команду пользователь),
+// а при указании --format или --chart файл с результатами или диаграммой публикуется в канале команды.
+// Результаты, скрытые до закрытия опроса, при --share не раскрываются даже создателю.
+// В случае ошибки пользователю возвращается видимое только ему сообщение об ошибке (для внутренних ошибок - общее).
 func GetPollResults(s *services.PollService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		locale := s.UserLocale(r.Form.Get("user_id"))
 		text := r.Form.Get("text")
-		args := strings.Split(text, `" "`)
-		if len(args) != 1 {
-			w.Write([]byte("**Invalid format!** *Example*: `/poll-results \"Poll_ID\"`"))
+		cmd := parseArgs(w, locale, text, resultsFlags, 1, 1, `/poll-results "Poll_ID" [--format csv|json] [--chart] [--share]`)
+		if cmd == nil {
 			return
 		}
 
-		pollId := strings.Trim(args[0], `"`)
-		msg, err := s.GetPollResult(pollId)
-		if err != nil {
-			if userErr, ok := err.(*entities.UserError); ok {
-				w.Write([]byte(userErr.Error()))
+		userId := r.Form.Get("user_id")
+		if userId == "" {
+			http.Error(w, "'user_id' is empty in the form data", http.StatusBadRequest)
+			return
+		}
+
+		pollId := cmd.Args[0]
+		format, export := cmd.Flags["format"]
+		chart := cmd.Bool("chart")
+		channelId := r.Form.Get("channel_id")
+		if (export || chart) && channelId == "" {
+			http.Error(w, "'channel_id' is empty in the form data", http.StatusBadRequest)
+			return
+		}
+
+		if export || chart {
+			var msg *entities.Message
+			var err error
+			if export {
+				msg, err = s.ExportPollResult(pollId, userId, channelId, strings.ToLower(format))
+			} else {
+				msg, err = s.PostPollResult(pollId, userId, channelId)
+			}
+			if err != nil {
+				writeCommandError(w, locale, err, "action.get_results")
 				return
 			}
 
-			log.Println(err)
-			http.Error(w, "failed to get poll results", http.StatusInternalServerError)
+			writeMessage(w, locale, msg)
 			return
 		}
 
-		w.Write([]byte(msg))
+		share := cmd.Bool("share")
+		msg, err := s.GetPollResult(pollId, userId, locale, share)
+		if err != nil {
+			writeCommandError(w, locale, err, "action.get_results")
+			return
+		}
+
+		writeResults(w, msg, share)
 	}
 }
 
@@ -169,17 +359,17 @@ func GetPollResults(s *services.PollService) http.HandlerFunc {
 // Обработчик разбирает параметр "text", чтобы извлечь идентификатор опроса.
 // Если формат параметра "text" некорректен, возвращается сообщение об ошибке с примером правильного формата.
 // Если получение результатов прошло успешно, возвращается сообщение с результатами опроса.
-// В случае ошибки возвращается соответствующее сообщение об ошибке или статус HTTP 500 для внутренних ошибок сервера.
+// В случае ошибки пользователю возвращается видимое только ему сообщение об ошибке (для внутренних ошибок - общее).
 func ClosePoll(s *services.PollService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		locale := s.UserLocale(r.Form.Get("user_id"))
 		text := r.Form.Get("text")
-		args := strings.Split(text, `" "`)
-		if len(args) != 1 {
-			w.Write([]byte("**Неверный формат!** *Пример*: `/poll-close \"Poll_ID\"`"))
+		cmd := parseArgs(w, locale, text, nil, 1, 1, `/poll-close "Poll_ID"`)
+		if cmd == nil {
 			return
 		}
 
-		pollId := strings.Trim(args[0], `"`)
+		pollId := cmd.Args[0]
 		userId := r.Form.Get("user_id")
 		if userId == "" {
 			http.Error(w, "'user_id' is empty in the form data", http.StatusBadRequest)
@@ -188,17 +378,42 @@ func ClosePoll(s *services.PollService) http.HandlerFunc {
 
 		msg, err := s.ClosePoll(pollId, userId)
 		if err != nil {
-			if userErr, ok := err.(*entities.UserError); ok {
-				w.Write([]byte(userErr.Error()))
-				return
-			}
+			writeCommandError(w, locale, err, "action.close_poll")
+			return
+		}
 
-			log.Println(err)
-			http.Error(w, "failed to close poll", http.StatusInternalServerError)
+		writeMessage(w, locale, msg)
+	}
+}
+
+// ReopenPoll обрабатывает HTTP-запрос для повторного открытия закрытого опроса.
+// Ожидается, что запрос будет содержать следующие параметры формы:
+// "text": строка в формате `"Poll_ID"`, где Poll_ID — идентификатор опроса.
+// Если формат параметра "text" некорректен, возвращается сообщение об ошибке с примером правильного формата.
+// В случае ошибки пользователю возвращается видимое только ему сообщение об ошибке (для внутренних ошибок - общее).
+func ReopenPoll(s *services.PollService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		locale := s.UserLocale(r.Form.Get("user_id"))
+		text := r.Form.Get("text")
+		cmd := parseArgs(w, locale, text, nil, 1, 1, `/poll-reopen "Poll_ID"`)
+		if cmd == nil {
 			return
 		}
 
-		w.Write([]byte(msg))
+		pollId := cmd.Args[0]
+		userId := r.Form.Get("user_id")
+		if userId == "" {
+			http.Error(w, "'user_id' is empty in the form data", http.StatusBadRequest)
+			return
+		}
+
+		msg, err := s.ReopenPoll(pollId, userId)
+		if err != nil {
+			writeCommandError(w, locale, err, "action.reopen_poll")
+			return
+		}
+
+		writeMessage(w, locale, msg)
 	}
 }
 
@@ -209,17 +424,17 @@ func ClosePoll(s *services.PollService) http.HandlerFunc {
 // Обработчик разбирает параметр "text", чтобы извлечь идентификатор опроса.
 // Если формат параметра "text" некорректен, возвращается сообщение об ошибке с примером правильного формата.
 // Если получение результатов прошло успешно, возвращается сообщение с результатами опроса.
-// В случае ошибки возвращается соответствующее сообщение об ошибке или статус HTTP 500 для внутренних ошибок сервера.
+// В случае ошибки пользователю возвращается видимое только ему сообщение об ошибке (для внутренних ошибок - общее).
 func DeletePoll(s *services.PollService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		locale := s.UserLocale(r.Form.Get("user_id"))
 		text := r.Form.Get("text")
-		args := strings.Split(text, `" "`)
-		if len(args) != 1 {
-			w.Write([]byte("**Invalid format!** *Example*: `/poll-close \"Poll_ID\"`"))
+		cmd := parseArgs(w, locale, text, nil, 1, 1, `/poll-delete "Poll_ID"`)
+		if cmd == nil {
 			return
 		}
 
-		pollId := strings.Trim(args[0], `"`)
+		pollId := cmd.Args[0]
 		userId := r.Form.Get("user_id")
 		if userId == "" {
 			http.Error(w, "'user_id' is empty in the form data", http.StatusBadRequest)
@@ -228,16 +443,71 @@ func DeletePoll(s *services.PollService) http.HandlerFunc {
 
 		msg, err := s.DeletePoll(pollId, userId)
 		if err != nil {
-			if userErr, ok := err.(*entities.UserError); ok {
-				w.Write([]byte(userErr.Error()))
+			writeCommandError(w, locale, err, "action.delete_poll")
+			return
+		}
+
+		writeMessage(w, locale, msg)
+	}
+}
+
+// listPollsFlags - флаги команды получения списка опросов.
+var listPollsFlags = parser.Flags{"mine": false, "channel": false, "open": false, "closed": false, "page": true}
+
+// ListPolls обрабатывает HTTP-запрос для получения списка опросов.
+// Ожидается, что запрос будет содержать параметры формы "user_id", "channel_id" и
+// "text": строка в формате `[--mine] [--channel] [--open | --closed] [--page N]`.
+// По умолчанию выводятся опросы текущего канала; --mine выводит опросы пользователя из всех каналов,
+// а вместе с --channel - только из текущего. --open и --closed отбирают открытые или закрытые опросы,
+// --page задает номер страницы (по services.PollListPageSize опросов на странице).
+// В случае ошибки пользователю возвращается видимое только ему сообщение об ошибке (для внутренних ошибок - общее).
+func ListPolls(s *services.PollService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		locale := s.UserLocale(r.Form.Get("user_id"))
+		cmd := parseArgs(w, locale, r.Form.Get("text"), listPollsFlags, 0, 0, "/poll-list [--mine] [--channel] [--open | --closed] [--page N]")
+		if cmd == nil {
+			return
+		}
+
+		userId := r.Form.Get("user_id")
+		if userId == "" {
+			http.Error(w, "'user_id' is empty in the form data", http.StatusBadRequest)
+			return
+		}
+
+		channelId := r.Form.Get("channel_id")
+		if channelId == "" {
+			http.Error(w, "'channel_id' is empty in the form data", http.StatusBadRequest)
+			return
+		}
+
+		filter := &entities.PollFilter{ChannelId: channelId, Limit: services.PollListPageSize}
+		if cmd.Bool("mine") {
+			filter.Creator = userId
+			if !cmd.Bool("channel") {
+				filter.ChannelId = ""
+			}
+		}
+
+		if open, closed := cmd.Bool("open"), cmd.Bool("closed"); open != closed {
+			filter.Closed = &closed
+		}
+
+		if value, ok := cmd.Flags["page"]; ok {
+			page, err := strconv.Atoi(value)
+			if err != nil || page < 1 {
+				writeEphemeral(w, i18n.T(locale, "command.invalid_page"))
 				return
 			}
+			filter.Offset = (page - 1) * filter.Limit
+		}
 
-			log.Println(err)
-			http.Error(w, "failed to delete", http.StatusInternalServerError)
+		msg, err := s.ListPolls(filter, locale)
+		if err != nil {
+			writeCommandError(w, locale, err, "action.list_polls")
 			return
 		}
 
-		w.Write([]byte(msg))
+		writeEphemeral(w, msg)
 	}
 }
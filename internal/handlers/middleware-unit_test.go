@@ -1,13 +1,16 @@
 package handlers_test
 
 import (
-	"matterpoll-bot/config"
+	"bytes"
+	"encoding/json"
 	"matterpoll-bot/internal/handlers"
+	"matterpoll-bot/internal/services"
 	"matterpoll-bot/internal/storage/store_mocks"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -22,9 +25,8 @@ func TestTokenValidatorMiddleware(t *testing.T) {
 		w.Write([]byte("OK"))
 	})
 
-	config.Mode = "database"
 	cmdPath := "test_path"
-	handler := handlers.TokenValidatorMiddleware(mockStore, nextHandler)
+	handler := handlers.TokenValidatorMiddleware(testConfig, mockStore, nextHandler)
 
 	t.Run("valid token", func(t *testing.T) {
 		token := "valid_token"
@@ -70,7 +72,7 @@ func TestTokenValidatorMiddleware(t *testing.T) {
 		require.Contains(t, actualResponse, expectedResponse)
 		mockStore.AssertCalled(t, "ValidateCmdToken", cmdPath, token)
 
-        // Проверяем обработку статуса ответа при передачи пустого токена
+		// Проверяем обработку статуса ответа при передачи пустого токена
 		token = ""
 
 		req = httptest.NewRequest(http.MethodGet, "/", nil)
@@ -90,3 +92,111 @@ func TestTokenValidatorMiddleware(t *testing.T) {
 		require.Contains(t, actualResponse, expectedResponse)
 	})
 }
+
+// TestActionValidatorMiddleware проверяет работу middleware для проверки подписи кнопок сообщений.
+func TestActionValidatorMiddleware(t *testing.T) {
+	pollService := services.NewPollService(nil, nil, testConfig)
+	pollId := "poll1"
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	})
+	handler := handlers.ActionValidatorMiddleware(pollService, nextHandler)
+
+	newRequest := func(context map[string]interface{}) *http.Request {
+		body, err := json.Marshal(&model.PostActionIntegrationRequest{UserId: "user1", Context: context})
+		require.NoError(t, err)
+
+		return httptest.NewRequest(http.MethodPost, "/poll-action", bytes.NewReader(body))
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		respRec := httptest.NewRecorder()
+		req := newRequest(map[string]interface{}{"poll_id": pollId, "token": pollService.SignAction(services.ActionDomain, pollId)})
+
+		handler.ServeHTTP(respRec, req)
+
+		require.Equal(t, http.StatusOK, respRec.Code)
+		require.Contains(t, respRec.Body.String(), "OK")
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		// Проверяем обработку подписи, выданной для другого опроса
+		respRec := httptest.NewRecorder()
+		req := newRequest(map[string]interface{}{"poll_id": pollId, "token": pollService.SignAction(services.ActionDomain, "poll2")})
+
+		handler.ServeHTTP(respRec, req)
+
+		require.Equal(t, http.StatusUnauthorized, respRec.Code)
+		require.Contains(t, respRec.Body.String(), "Invalid token")
+
+		// Проверяем обработку пустой подписи
+		respRec = httptest.NewRecorder()
+		req = newRequest(map[string]interface{}{"poll_id": pollId})
+
+		handler.ServeHTTP(respRec, req)
+
+		require.Equal(t, http.StatusBadRequest, respRec.Code)
+		require.Contains(t, respRec.Body.String(), "'poll_id' or 'token' are empty in the action context")
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		respRec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/poll-action", bytes.NewReader([]byte("not json")))
+
+		handler.ServeHTTP(respRec, req)
+
+		require.Equal(t, http.StatusBadRequest, respRec.Code)
+		require.Contains(t, respRec.Body.String(), "Invalid request body")
+	})
+}
+
+// TestDialogValidatorMiddleware проверяет работу middleware для проверки подписи интерактивных диалогов.
+func TestDialogValidatorMiddleware(t *testing.T) {
+	pollService := services.NewPollService(nil, nil, testConfig)
+	userId := "user1"
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	})
+	handler := handlers.DialogValidatorMiddleware(pollService, nextHandler)
+
+	newRequest := func(userId, state string) *http.Request {
+		body, err := json.Marshal(&model.SubmitDialogRequest{UserId: userId, State: state})
+		require.NoError(t, err)
+
+		return httptest.NewRequest(http.MethodPost, "/poll-dialog", bytes.NewReader(body))
+	}
+
+	t.Run("valid state", func(t *testing.T) {
+		respRec := httptest.NewRecorder()
+		handler.ServeHTTP(respRec, newRequest(userId, pollService.SignAction(services.DialogDomain, userId)))
+
+		require.Equal(t, http.StatusOK, respRec.Code)
+		require.Contains(t, respRec.Body.String(), "OK")
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		// Проверяем обработку подписи, выданной другому пользователю
+		respRec := httptest.NewRecorder()
+		handler.ServeHTTP(respRec, newRequest(userId, pollService.SignAction(services.DialogDomain, "user2")))
+
+		require.Equal(t, http.StatusUnauthorized, respRec.Code)
+		require.Contains(t, respRec.Body.String(), "Invalid token")
+
+		// Проверяем обработку подписи того же значения, выданной для кнопок сообщений
+		respRec = httptest.NewRecorder()
+		handler.ServeHTTP(respRec, newRequest(userId, pollService.SignAction(services.ActionDomain, userId)))
+
+		require.Equal(t, http.StatusUnauthorized, respRec.Code)
+
+		// Проверяем обработку пустой подписи
+		respRec = httptest.NewRecorder()
+		handler.ServeHTTP(respRec, newRequest(userId, ""))
+
+		require.Equal(t, http.StatusBadRequest, respRec.Code)
+		require.Contains(t, respRec.Body.String(), "'user_id' or 'state' are empty in the dialog submission")
+	})
+}
@@ -1,13 +1,18 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"matterpoll-bot/config"
+	"matterpoll-bot/internal/services"
 	"matterpoll-bot/internal/storage"
 	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
 )
 
-// TokenValidatorMiddleware проверяет полученный токен из тела запроса (только для режима "database").
-func TokenValidatorMiddleware(store storage.StoreInterface, next http.HandlerFunc) http.HandlerFunc {
+// TokenValidatorMiddleware проверяет полученный токен из тела запроса (только в режиме хранения "database").
+func TokenValidatorMiddleware(cfg *config.Config, store storage.StoreInterface, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if err := r.ParseForm(); err != nil {
 			http.Error(w, "Invalid form data", http.StatusBadRequest)
@@ -21,7 +26,7 @@ func TokenValidatorMiddleware(store storage.StoreInterface, next http.HandlerFun
 			return
 		}
 
-		if config.Mode == "database" && !store.ValidateCmdToken(cmdPath, token) {
+		if cfg.Mode == config.ModeDatabase && !store.ValidateCmdToken(cmdPath, token) {
 			http.Error(w, "Invalid token", http.StatusUnauthorized)
 			return
 		}
@@ -29,3 +34,59 @@ func TokenValidatorMiddleware(store storage.StoreInterface, next http.HandlerFun
 		next(w, r)
 	}
 }
+
+// actionRequestKey - ключ контекста запроса, под которым хранится разобранный запрос от кнопки сообщения.
+type actionRequestKey struct{}
+
+// ActionValidatorMiddleware разбирает запрос, отправленный Mattermost при нажатии на кнопку сообщения,
+// и проверяет подпись из контекста кнопки. Разобранный запрос передается обработчику через контекст.
+func ActionValidatorMiddleware(s *services.PollService, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req model.PostActionIntegrationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		pollId, _ := req.Context["poll_id"].(string)
+		token, _ := req.Context["token"].(string)
+		if pollId == "" || token == "" {
+			http.Error(w, "'poll_id' or 'token' are empty in the action context", http.StatusBadRequest)
+			return
+		}
+
+		if !s.VerifyAction(services.ActionDomain, pollId, token) {
+			http.Error(w, "Invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), actionRequestKey{}, &req)))
+	}
+}
+
+// dialogRequestKey - ключ контекста запроса, под которым хранится разобранный запрос отправки диалога.
+type dialogRequestKey struct{}
+
+// DialogValidatorMiddleware разбирает запрос, отправленный Mattermost при отправке интерактивного диалога,
+// и проверяет подпись пользователя из поля State. Разобранный запрос передается обработчику через контекст.
+func DialogValidatorMiddleware(s *services.PollService, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req model.SubmitDialogRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		if req.UserId == "" || req.State == "" {
+			http.Error(w, "'user_id' or 'state' are empty in the dialog submission", http.StatusBadRequest)
+			return
+		}
+
+		if !s.VerifyAction(services.DialogDomain, req.UserId, req.State) {
+			http.Error(w, "Invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), dialogRequestKey{}, &req)))
+	}
+}
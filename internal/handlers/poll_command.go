@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"fmt"
+	"matterpoll-bot/internal/i18n"
+	"matterpoll-bot/internal/services"
+	"net/http"
+	"strings"
+)
+
+// PollCommand обрабатывает команду /poll с подкомандами.
+// Первое слово параметра "text" определяет подкоманду, а оставшаяся часть передается
+// ее обработчику как параметр "text", поэтому `/poll vote "Poll_ID" "Option"` обрабатывается так же,
+// как `/poll-vote "Poll_ID" "Option"`. Без подкоманды или для подкоманды "help" возвращается справка.
+func PollCommand(s *services.PollService) http.HandlerFunc {
+	subcommands := map[string]http.HandlerFunc{
+		"create":     CreatePoll(s),
+		"vote":       Vote(s),
+		"add-option": AddOption(s),
+		"retract":    RetractVote(s),
+		"change":     ChangeVote(s),
+		"results":    GetPollResults(s),
+		"close":      ClosePoll(s),
+		"reopen":     ReopenPoll(s),
+		"delete":     DeletePoll(s),
+		"list":       ListPolls(s),
+		"schedule":   ScheduleCommand(s),
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		name, text, _ := strings.Cut(strings.TrimSpace(r.Form.Get("text")), " ")
+		if name == "" || name == "help" {
+			writeEphemeral(w, pollHelp(s.UserLocale(r.Form.Get("user_id"))))
+			return
+		}
+
+		handler, ok := subcommands[name]
+		if !ok {
+			locale := s.UserLocale(r.Form.Get("user_id"))
+			writeEphemeral(w, i18n.T(locale, "command.unknown", name, pollHelp(locale)))
+			return
+		}
+
+		r.Form.Set("text", text)
+		handler(w, r)
+	}
+}
+
+// pollHelp возвращает справку по подкомандам /poll на языке locale, составленную по дереву автодополнения.
+func pollHelp(locale string) string {
+	var sb strings.Builder
+
+	sb.WriteString(i18n.T(locale, "help.commands") + "\n")
+	for _, sub := range services.NewPollAutocompleteData().SubCommands {
+		usage := strings.TrimSpace(fmt.Sprintf("/poll %s %s", sub.Trigger, sub.Hint))
+		sb.WriteString(fmt.Sprintf("- `%s` — %s\n", usage, i18n.T(locale, "help.poll."+sub.Trigger)))
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// resultsColor - цвет полосы вложения с результатами опроса.
+const resultsColor = "#1c58d9"
+
+// writeCommandResponse отправляет ответ на слеш-команду в формате JSON.
+func writeCommandResponse(w http.ResponseWriter, resp *model.CommandResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Println(err)
+	}
+}
+
+// writeEphemeral отправляет ответ на слеш-команду, который видит только вызвавший ее пользователь.
+func writeEphemeral(w http.ResponseWriter, text string) {
+	writeCommandResponse(w, &model.CommandResponse{ResponseType: model.CommandResponseTypeEphemeral, Text: text})
+}
+
+// writeResults отправляет результаты опроса во вложении сообщения.
+// Если share равен true, результаты публикуются в канале и видны всем его участникам,
+// иначе их видит только вызвавший команду пользователь.
+func writeResults(w http.ResponseWriter, text string, share bool) {
+	responseType := model.CommandResponseTypeEphemeral
+	if share {
+		responseType = model.CommandResponseTypeInChannel
+	}
+
+	writeCommandResponse(w, &model.CommandResponse{
+		ResponseType: responseType,
+		Attachments: []*model.SlackAttachment{
+			{Fallback: text, Text: text, Color: resultsColor},
+		},
+	})
+}
+
+// writeMessage отправляет ответ на слеш-команду с сообщением msg на языке locale,
+// который видит только вызвавший ее пользователь.
+func writeMessage(w http.ResponseWriter, locale string, msg *entities.Message) {
+	writeEphemeral(w, msg.Localize(locale))
+}
+
+// writeCommandError отправляет пользователю сообщение об ошибке выполнения команды на языке locale, видимое только ему.
+// Пользовательские ошибки выводятся как есть, а внутренние ошибки логируются,
+// и пользователь получает общее сообщение о том, что действие не удалось выполнить.
+// Ключ action задает название действия в каталоге сообщений (например, "action.vote").
+func writeCommandError(w http.ResponseWriter, locale string, err error, action string) {
+	if userErr, ok := err.(*entities.UserError); ok {
+		writeEphemeral(w, userErr.Localize(locale))
+		return
+	}
+
+	log.Println(err)
+	writeEphemeral(w, i18n.T(locale, "command.failed", i18n.T(locale, action)))
+}
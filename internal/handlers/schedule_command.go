@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"fmt"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
+	"matterpoll-bot/internal/parser"
+	"matterpoll-bot/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleFlags - флаги команды создания расписания повторяющегося опроса.
+var scheduleFlags = parser.Flags{"max-votes": true, "public": false, "ranked": false, "duration": true}
+
+// scheduleExample - пример команды создания расписания повторяющегося опроса.
+const scheduleExample = `/poll-schedule create "0 12 * * 5" "Question" "Option1" "Option2" ... [--max-votes N] [--public] [--ranked] [--duration 2h]`
+
+// ScheduleCommand обрабатывает команду /poll-schedule управления повторяющимися опросами.
+// Первое слово параметра "text" определяет действие:
+// create — создание расписания в формате `"Cron" "Question" "Option1" "Option2" ... [--max-votes N] [--public] [--ranked] [--duration 2h]`,
+// где Cron — расписание из пяти полей cron в UTC (`0 12 * * 5` — каждую пятницу в 12:00),
+// а --duration — длительность каждого опроса до автоматического закрытия;
+// list — список расписаний текущего канала;
+// pause, resume и remove — приостановка, возобновление и удаление расписания `"Schedule_ID"`.
+// Без действия или для действия "help" возвращается справка.
+// В случае ошибки пользователю возвращается видимое только ему сообщение об ошибке (для внутренних ошибок - общее).
+func ScheduleCommand(s *services.PollService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		action, text, _ := strings.Cut(strings.TrimSpace(r.Form.Get("text")), " ")
+
+		userId := r.Form.Get("user_id")
+		if userId == "" {
+			http.Error(w, "'user_id' is empty in the form data", http.StatusBadRequest)
+			return
+		}
+
+		channelId := r.Form.Get("channel_id")
+		if channelId == "" {
+			http.Error(w, "'channel_id' is empty in the form data", http.StatusBadRequest)
+			return
+		}
+
+		locale := s.UserLocale(userId)
+		var msg *entities.Message
+		var err error
+		switch action {
+		case "", "help":
+			writeEphemeral(w, scheduleHelp(locale))
+			return
+		case "create":
+			schedule := parseSchedule(w, locale, text)
+			if schedule == nil {
+				return
+			}
+			schedule.Creator = userId
+			schedule.ChannelId = channelId
+			schedule.TeamId = r.Form.Get("team_id")
+			msg, err = s.CreateSchedule(schedule, time.Now())
+		case "list":
+			if parseArgs(w, locale, text, nil, 0, 0, "/poll-schedule list") == nil {
+				return
+			}
+
+			list, err := s.ListSchedules(channelId, locale)
+			if err != nil {
+				writeCommandError(w, locale, err, "action.list_schedules")
+				return
+			}
+
+			writeEphemeral(w, list)
+			return
+		case "pause", "resume", "remove":
+			cmd := parseArgs(w, locale, text, nil, 1, 1, fmt.Sprintf(`/poll-schedule %s "Schedule_ID"`, action))
+			if cmd == nil {
+				return
+			}
+			scheduleId := cmd.Args[0]
+
+			switch action {
+			case "pause":
+				msg, err = s.PauseSchedule(scheduleId, userId)
+			case "resume":
+				msg, err = s.ResumeSchedule(scheduleId, userId, time.Now())
+			default:
+				msg, err = s.DeleteSchedule(scheduleId, userId)
+			}
+		default:
+			writeEphemeral(w, i18n.T(locale, "schedule.unknown_action", action, scheduleHelp(locale)))
+			return
+		}
+		if err != nil {
+			writeCommandError(w, locale, err, "action."+action+"_schedule")
+			return
+		}
+
+		writeMessage(w, locale, msg)
+	}
+}
+
+// parseSchedule разбирает аргументы действия create команды /poll-schedule.
+// Если аргументы некорректны, пользователю отправляется сообщение об ошибке на языке locale и возвращается nil.
+func parseSchedule(w http.ResponseWriter, locale, text string) *entities.Schedule {
+	cmd := parseArgs(w, locale, text, scheduleFlags, 3, -1, scheduleExample)
+	if cmd == nil {
+		return nil
+	}
+
+	schedule := &entities.Schedule{
+		Cron:     cmd.Args[0],
+		Question: cmd.Args[1],
+		Options:  cmd.Args[2:],
+		MaxVotes: 1,
+		Public:   cmd.Bool("public"),
+		Ranked:   cmd.Bool("ranked"),
+	}
+	if value, ok := cmd.Flags["max-votes"]; ok {
+		maxVotes, err := strconv.Atoi(value)
+		if err != nil {
+			writeEphemeral(w, i18n.T(locale, "command.invalid_max_votes"))
+			return nil
+		}
+		schedule.MaxVotes = int32(maxVotes)
+	}
+	if value, ok := cmd.Flags["duration"]; ok {
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration < time.Minute {
+			writeEphemeral(w, i18n.T(locale, "schedule.invalid_duration"))
+			return nil
+		}
+		schedule.Duration = int64(duration / time.Second)
+	}
+
+	return schedule
+}
+
+// scheduleHelp возвращает справку по действиям команды /poll-schedule на языке locale.
+func scheduleHelp(locale string) string {
+	actions := []struct{ action, usage string }{
+		{"create", scheduleExample},
+		{"list", "/poll-schedule list"},
+		{"pause", `/poll-schedule pause "Schedule_ID"`},
+		{"resume", `/poll-schedule resume "Schedule_ID"`},
+		{"remove", `/poll-schedule remove "Schedule_ID"`},
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(i18n.T(locale, "help.actions") + "\n")
+	for _, a := range actions {
+		sb.WriteString(fmt.Sprintf("- `%s` — %s\n", a.usage, i18n.T(locale, "help.schedule."+a.action)))
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
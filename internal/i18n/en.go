@@ -0,0 +1,256 @@
+package i18n
+
+// en - каталог сообщений на английском языке.
+var en = map[string]string{
+	// Разбор команд.
+	"command.invalid_format":        "**Invalid format!** *Example*: `%s`",
+	"command.invalid_format_reason": "**Invalid format!** %s. *Example*: `%s`",
+	"command.invalid_max_votes":     "**Invalid format!** `--max-votes` must be a number",
+	"command.invalid_page":          "**Invalid format!** `--page` must be a positive number",
+	"command.unknown":               "**Unknown command** `%s`!\n\n%s",
+	"command.failed":                "**Failed to %s!** Please try again later.",
+
+	// Ошибки разбора аргументов и расписаний.
+	"parser.error":              "%s at position %d: `%s`",
+	"parser.unterminated_quote": "unterminated quote",
+	"parser.unknown_flag":       "unknown flag",
+	"parser.duplicated_flag":    "duplicated flag",
+	"parser.missing_value":      "missing value for flag",
+	"cron.field_count":          "expected %d fields, got %d",
+	"cron.invalid_step":         "invalid step in %s field: `%s`",
+	"cron.invalid_value":        "invalid %s: `%s`",
+	"cron.out_of_range":         "%s out of range %d-%d: `%s`",
+	"cron.minute":               "minute",
+	"cron.hour":                 "hour",
+	"cron.day_of_month":         "day of month",
+	"cron.month":                "month",
+	"cron.day_of_week":          "day of week",
+
+	// Действия для сообщения command.failed.
+	"action.create_poll":     "create poll",
+	"action.post_poll":       "post poll",
+	"action.open_dialog":     "open dialog",
+	"action.resolve_weights": "resolve weights",
+	"action.vote":            "vote",
+	"action.add_option":      "add option",
+	"action.retract_vote":    "retract vote",
+	"action.change_vote":     "change vote",
+	"action.get_results":     "get poll results",
+	"action.close_poll":      "close poll",
+	"action.reopen_poll":     "reopen poll",
+	"action.delete_poll":     "delete poll",
+	"action.list_polls":      "list polls",
+	"action.create_schedule": "create schedule",
+	"action.list_schedules":  "list schedules",
+	"action.pause_schedule":  "pause schedule",
+	"action.resume_schedule": "resume schedule",
+	"action.remove_schedule": "remove schedule",
+
+	// Настройки опроса.
+	"poll.invalid_deadline":       "**Invalid deadline!** *Expected*: a duration (e.g. `2h`) or a time in UTC (e.g. `2025-01-02T15:04`)",
+	"poll.past_deadline":          "**The deadline must be in the future!**",
+	"poll.invalid_weights":        "**Invalid weights!** *Expected*: `@user=N` or `@group=N` separated by commas (e.g. `@alice=3, @committee=2`)",
+	"poll.invalid_weight":         "**Invalid weight!** *Expected*: from `1` to `%d`",
+	"poll.invalid_quorum":         "**Invalid quorum!** *Expected*: a number of voters or a percent of channel members (e.g. `10` or `50%%`)",
+	"poll.invalid_quorum_percent": "**Invalid quorum!** *Expected*: a percent of channel members from `1%%` to `100%%`",
+	"poll.invalid_threshold":      "**Invalid threshold!** *Expected*: a fraction or a percent of votes (e.g. `2/3` or `60%%`)",
+	"poll.invalid_max_votes":      "**Invalid number of votes per user!** *Expected*: from `0` (unlimited) to `%d`",
+	"poll.ranked_multiple_choice": "**Ranked polls don't support multiple choice!**",
+	"poll.user_not_found":         "**User or group `@%s` not found!**",
+
+	// Управление опросами.
+	"poll.not_found":        "**Invalid Poll_ID or not exists!**",
+	"poll.already_closed":   "*Poll*: `%s` **is already closed!**",
+	"poll.not_closed":       "*Poll*: `%s` **is not closed!**",
+	"poll.close_forbidden":  "**You don't have the permission to close a vote!**",
+	"poll.reopen_forbidden": "**You don't have the permission to reopen a vote!**",
+	"poll.delete_forbidden": "**You don't have the permission to delete a vote!**",
+	"poll.closed":           "*Poll*: `%s` **has been successfully closed!**",
+	"poll.reopened":         "*Poll*: `%s` **has been successfully reopened!**",
+	"poll.deleted":          "*Poll*: `%s` **has been successfully deleted!**",
+
+	// Голосование.
+	"vote.not_member":           "**Only members of the poll's channel can vote!**",
+	"vote.not_ranked":           "**This poll is not ranked, choose one option!**",
+	"vote.invalid_option":       "**Invalid option!**",
+	"vote.again":                "**You can't vote again!**",
+	"vote.too_many_options":     "**You can't choose more than %d options!**",
+	"vote.duplicate":            "**You have already voted for this option!**",
+	"vote.empty_ranking":        "**Rank at least one option!**",
+	"vote.ranked_twice":         "**Option `%s` is ranked more than once!**",
+	"vote.not_voted":            "**You haven't voted in this poll!**",
+	"vote.option_not_voted":     "**You haven't voted for this option!**",
+	"vote.ranked_retract_whole": "**Ranked ballots can only be retracted entirely!**",
+	"vote.legacy":               "**Your vote was cast before the bot started storing chosen options, so it can't be retracted or changed!**",
+	"vote.recorded":             "**Voice recorded!**",
+	"vote.retracted":            "**Voice retracted!**",
+	"vote.changed":              "**Voice changed!**",
+
+	// Добавление вариантов.
+	"option.not_allowed": "**This poll doesn't allow adding options!**",
+	"option.empty":       "**Option can't be empty!**",
+	"option.too_long":    "**Option is too long!** *Expected*: up to `%d` characters",
+	"option.exists":      "**Option `%s` already exists!**",
+	"option.too_many":    "**The poll already has the maximum of %d options!**",
+	"option.added":       "**Option added!**",
+
+	// Результаты.
+	"results.hidden":             "**Results are hidden until the poll is closed!**",
+	"results.not_member":         "**Only members of the poll's channel can see its results!**",
+	"results.unsupported_format": "**Unsupported format** `%s`! *Expected*: `csv` or `json`",
+	"results.posted":             "**Results posted!**",
+	"results.exported":           "**Results exported!**",
+
+	// Повторяющиеся опросы.
+	"schedule.unknown_action":    "**Unknown action** `%s`!\n\n%s",
+	"schedule.invalid_duration":  "**Invalid duration!** *Expected*: a duration of at least a minute (e.g. `2h` or `90m`)",
+	"schedule.invalid_cron":      "**Invalid schedule!** %s. *Expected*: five cron fields in UTC (e.g. `0 12 * * 5`)",
+	"schedule.never_runs":        "**Invalid schedule!** `%s` never runs",
+	"schedule.not_found":         "**Invalid Schedule_ID or not exists!**",
+	"schedule.pause_forbidden":   "**You don't have the permission to pause a schedule!**",
+	"schedule.resume_forbidden":  "**You don't have the permission to resume a schedule!**",
+	"schedule.remove_forbidden":  "**You don't have the permission to remove a schedule!**",
+	"schedule.already_paused":    "*Schedule*: `%s` **is already paused!**",
+	"schedule.not_paused":        "*Schedule*: `%s` **is not paused!**",
+	"schedule.created":           "*Schedule*: `%s` **has been created!** *Next poll*: %s",
+	"schedule.paused":            "*Schedule*: `%s` **has been paused!**",
+	"schedule.resumed":           "*Schedule*: `%s` **has been resumed!**",
+	"schedule.resumed_next_poll": "*Schedule*: `%s` **has been resumed!** *Next poll*: %s",
+	"schedule.removed":           "*Schedule*: `%s` **has been removed!**",
+
+	// Проверка диалогов.
+	"dialog.question_empty":    "Question can't be empty.",
+	"dialog.options_few":       "Enter at least two options, one per line.",
+	"dialog.option_duplicated": "Option \"%s\" is duplicated.",
+	"dialog.max_votes_empty":   "Select the number of options a user can choose.",
+	"dialog.max_votes_too_big": "Can't be greater than the number of options.",
+	"dialog.max_votes_ranked":  "Ranked polls support single choice only.",
+	"dialog.invalid_ends":      "Enter a duration (e.g. 2h) or a future time in UTC (e.g. 2025-01-02T15:04).",
+	"dialog.invalid_quorum":    "Enter a number of voters (e.g. 10) or a percent of channel members (e.g. 50%%).",
+	"dialog.invalid_threshold": "Enter a fraction (e.g. 2/3) or a percent of votes (e.g. 60%%).",
+	"dialog.invalid_weights":   "Enter weights as @user=N or @group=N separated by commas.",
+	"dialog.option_ranked":     "Option \"%s\" is already ranked.",
+	"dialog.rank_empty":        "Select at least one option.",
+
+	// Интерактивные диалоги.
+	"dialog.create_title":        "Create poll",
+	"dialog.create_submit":       "Create",
+	"dialog.question":            "Question",
+	"dialog.options":             "Options",
+	"dialog.options_help":        "One option per line.",
+	"dialog.max_votes":           "Votes per user",
+	"dialog.max_votes_single":    "Single choice",
+	"dialog.max_votes_up_to":     "Up to %d options",
+	"dialog.max_votes_unlimited": "Unlimited",
+	"dialog.public":              "Public",
+	"dialog.public_help":         "Show who voted for what",
+	"dialog.ranked":              "Ranked",
+	"dialog.ranked_help":         "Rank options and count with instant runoff",
+	"dialog.open_options":        "Open options",
+	"dialog.open_options_help":   "Let participants add their own options",
+	"dialog.hide_results":        "Hide results",
+	"dialog.hide_results_help":   "Hide results until the poll is closed",
+	"dialog.weights":             "Weights",
+	"dialog.weights_help":        "Vote weights of users and groups, e.g. @alice=3, @committee=2. Everyone else votes with weight 1.",
+	"dialog.quorum":              "Quorum",
+	"dialog.quorum_help":         "Minimum number of voters (e.g. 10) or percent of channel members (e.g. 50%%).",
+	"dialog.threshold":           "Pass threshold",
+	"dialog.threshold_help":      "Share of votes the leading option needs to pass, e.g. 2/3 or 60%%.",
+	"dialog.ends":                "Ends",
+	"dialog.ends_help":           "Close automatically after a duration (e.g. 2h) or at a time in UTC (e.g. 2025-01-02T15:04).",
+	"dialog.rank_title":          "Rank options",
+	"dialog.rank_submit":         "Vote",
+	"dialog.choice":              "Choice #%d",
+	"dialog.add_option_title":    "Add option",
+	"dialog.add_option_submit":   "Add",
+	"dialog.option":              "Option",
+
+	// Подписи диаграммы результатов.
+	"chart.weighted": "votes: %d, weight: %d (%.1f%%)",
+
+	// Таблицы результатов и списков.
+	"table.options":      "Options",
+	"table.voices":       "Voices",
+	"table.weighted":     "Weighted",
+	"table.percent":      "Percent",
+	"table.voters":       "Voters",
+	"table.question":     "Question",
+	"table.total_weight": "Total weight",
+	"table.status":       "Status",
+	"table.schedule":     "Schedule",
+	"table.next_poll":    "Next poll",
+	"table.completed":    "🔴 (Completed)",
+	"table.active":       "🟢 (Active)",
+
+	// Результаты и списки.
+	"results.hidden_note":    "*Results are hidden until the poll is closed.*",
+	"list.empty":             "**No polls found!**",
+	"list.page":              "*Page %d of %d* (polls found: %d)",
+	"schedule.list_empty":    "**No schedules found!**",
+	"schedule.status_active": "🟢 (Active)",
+	"schedule.status_paused": "⏸️ (Paused)",
+
+	// Сообщения опросов.
+	"post.poll":               "*Poll_ID*: `%s`\n\n%s",
+	"post.deleted":            "*Poll*: `%s` **has been deleted!**",
+	"post.closed":             "*Poll*: `%s` **has been closed!**\n\n%s",
+	"post.closed_at_deadline": "*Poll*: `%s` **has been closed at the deadline!**\n\n%s",
+	"post.export":             "*Poll_ID*: `%s` results (%s)",
+	"post.rank":               "Rank options",
+	"post.add_option":         "Add option",
+	"post.retract":            "Retract vote",
+	"post.ranked_note":        "*Ranked-choice poll: rank the options in order of preference.*",
+	"post.any_number_note":    "*You can choose any number of options.*",
+	"post.up_to_note":         "*You can choose up to %d options.*",
+	"post.public_note":        "*This poll is public: everyone can see who voted for what.*",
+	"post.open_options_note":  "*Participants can add their own options.*",
+	"post.closes_at_note":     "*The poll closes automatically at %s.*",
+
+	// Подсчет рейтингового опроса.
+	"runoff.round":      "*Round %d*: %s",
+	"runoff.eliminated": " — eliminated `%s`",
+	"runoff.winner":     "**Winner**: `%s`",
+	"runoff.no_winner":  "**No winner**",
+
+	// Итоги опросов для принятия решения.
+	"decision.no_quorum":           "**Decision**: ⚪ NO QUORUM (voters: `%d` of `%d` required)",
+	"decision.quorum_unknown":      "**Decision**: ⚪ QUORUM UNKNOWN (voters: `%d`, the number of channel members was unavailable when the poll was closed)",
+	"decision.passed":              "**Decision**: ✅ PASSED — `%s` (%s)",
+	"decision.failed":              "**Decision**: ❌ FAILED — `%s` (%s)",
+	"decision.failed_no_leader":    "**Decision**: ❌ FAILED — no single leading option",
+	"decision.share":               "`%.1f％` of votes",
+	"decision.share_threshold":     "`%.1f％` of votes, `%.1f％` required",
+	"decision.rules":               "*Decision poll: %s.*",
+	"decision.rule_quorum":         "at least %d voters",
+	"decision.rule_quorum_percent": "at least %d%% of channel members",
+	"decision.rule_threshold":      "%.1f%% of votes to pass",
+
+	// Уведомления о модерации.
+	"moderation.closed":           "*Poll*: `%s` (%s) **has been closed by a %s!**",
+	"moderation.reopened":         "*Poll*: `%s` (%s) **has been reopened by a %s!**",
+	"moderation.deleted":          "*Poll*: `%s` (%s) **has been deleted by a %s!**",
+	"moderation.by_system_admin":  "system admin",
+	"moderation.by_team_admin":    "team admin",
+	"moderation.by_channel_admin": "channel admin",
+
+	// Справка по командам.
+	"help.commands":        "**Available commands:**",
+	"help.actions":         "**Available actions:**",
+	"help.poll.create":     "Create a new poll (without arguments opens a dialog)",
+	"help.poll.vote":       "Cast a vote (list options in order of preference for ranked polls)",
+	"help.poll.add-option": "Add an option to a poll with open options",
+	"help.poll.retract":    "Retract your vote (all options if none is given)",
+	"help.poll.change":     "Replace your vote with another option",
+	"help.poll.results":    "Get poll results, post them with a chart or export them as a file",
+	"help.poll.close":      "Close an active poll",
+	"help.poll.reopen":     "Reopen a closed poll",
+	"help.poll.delete":     "Delete an exists poll",
+	"help.poll.list":       "List polls of the channel or your own polls",
+	"help.poll.schedule":   "Create, list, pause, resume or remove recurring polls",
+	"help.poll.help":       "Show available commands",
+	"help.schedule.create": "Create a recurring poll (schedule in UTC)",
+	"help.schedule.list":   "List recurring polls of the channel",
+	"help.schedule.pause":  "Pause a recurring poll",
+	"help.schedule.resume": "Resume a paused recurring poll",
+	"help.schedule.remove": "Remove a recurring poll",
+}
@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// verbRe находит глаголы формата fmt, кроме экранированного знака процента.
+var verbRe = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+
+// TestCatalogs проверяет, что каталоги всех языков содержат одни и те же ключи
+// с одинаковыми глаголами формата и форматируются без ошибок.
+func TestCatalogs(t *testing.T) {
+	for lang, catalog := range catalogs {
+		t.Run(lang, func(t *testing.T) {
+			require.Len(t, catalog, len(en))
+
+			for key, format := range catalog {
+				base, ok := en[key]
+				require.True(t, ok, "unknown key %q", key)
+
+				verbs := verbRe.FindAllString(unescape(format), -1)
+				require.Equal(t, verbRe.FindAllString(unescape(base), -1), verbs, "verbs of %q", key)
+
+				params := make([]any, len(verbs))
+				for i, verb := range verbs {
+					switch verb[len(verb)-1] {
+					case 'd':
+						params[i] = 1
+					case 'f':
+						params[i] = 1.5
+					default:
+						params[i] = "x"
+					}
+				}
+				require.NotContains(t, fmt.Sprintf(format, params...), "%!", "format of %q", key)
+			}
+		})
+	}
+}
+
+// unescape удаляет из строки формата экранированные знаки процента.
+func unescape(format string) string {
+	return strings.ReplaceAll(format, "%%", "")
+}
+
+// TestT проверяет выбор языка по локали пользователя и подстановку параметров.
+func TestT(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		key    string
+		params []any
+		msg    string
+	}{
+		{"English", "en", "poll.closed", []any{"poll1"}, "*Poll*: `poll1` **has been successfully closed!**"},
+		{"Russian", "ru", "poll.closed", []any{"poll1"}, "*Опрос*: `poll1` **успешно закрыт!**"},
+		{"Region", "ru-RU", "vote.recorded", nil, "**Голос учтен!**"},
+		{"Unsupported locale", "pt-BR", "vote.recorded", nil, "**Voice recorded!**"},
+		{"Empty locale", "", "vote.recorded", nil, "**Voice recorded!**"},
+		{"Escaped percent", "en", "poll.invalid_quorum_percent", nil, "**Invalid quorum!** *Expected*: a percent of channel members from `1%` to `100%`"},
+		{"Unknown key", "ru", "**Custom message!**", nil, "**Custom message!**"},
+		{"Localized param", "ru", "moderation.closed", []any{"poll1", "Q", Key("moderation.by_team_admin")}, "*Опрос*: `poll1` (Q) **закрыт администратором команды!**"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.msg, T(tt.locale, tt.key, tt.params...))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultLocale - язык сообщений по умолчанию: используется для сообщений в каналах, в логах
+// и для пользователей, язык интерфейса которых не поддерживается.
+const DefaultLocale = "en"
+
+// catalogs - каталоги сообщений по поддерживаемым языкам.
+// Ключ сообщения сопоставлен строке формата для fmt.Sprintf, поэтому знак процента в тексте записывается как `%%`.
+var catalogs = map[string]map[string]string{
+	"en": en,
+	"ru": ru,
+}
+
+// Language возвращает поддерживаемый язык для локали Mattermost locale (например, `ru` для `ru-RU`)
+// или DefaultLocale, если язык не поддерживается.
+func Language(locale string) string {
+	lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(locale)), "-")
+	lang, _, _ = strings.Cut(lang, "_")
+	if _, ok := catalogs[lang]; ok {
+		return lang
+	}
+
+	return DefaultLocale
+}
+
+// Localizer - параметр сообщения, текст которого зависит от языка (например, ошибка разбора команды).
+type Localizer interface {
+	Localize(locale string) string
+}
+
+// Key - ключ сообщения без параметров, подставляемый в другое сообщение на том же языке.
+type Key string
+
+// Localize возвращает сообщение k на языке locale.
+func (k Key) Localize(locale string) string {
+	return T(locale, string(k))
+}
+
+// T возвращает сообщение key на языке locale с подставленными параметрами params.
+// Параметры, реализующие Localizer, подставляются на том же языке.
+// Если сообщения нет в каталоге языка, используется каталог DefaultLocale,
+// а неизвестный ключ возвращается как есть.
+func T(locale, key string, params ...any) string {
+	format, ok := catalogs[Language(locale)][key]
+	if !ok {
+		if format, ok = catalogs[DefaultLocale][key]; !ok {
+			return key
+		}
+	}
+
+	localized := make([]any, len(params))
+	for i, param := range params {
+		if l, ok := param.(Localizer); ok {
+			localized[i] = l.Localize(locale)
+			continue
+		}
+		localized[i] = param
+	}
+
+	return fmt.Sprintf(format, localized...)
+}
@@ -0,0 +1,256 @@
+package i18n
+
+// ru - каталог сообщений на русском языке.
+var ru = map[string]string{
+	// Разбор команд.
+	"command.invalid_format":        "**Неверный формат!** *Пример*: `%s`",
+	"command.invalid_format_reason": "**Неверный формат!** %s. *Пример*: `%s`",
+	"command.invalid_max_votes":     "**Неверный формат!** `--max-votes` должен быть числом",
+	"command.invalid_page":          "**Неверный формат!** `--page` должен быть положительным числом",
+	"command.unknown":               "**Неизвестная команда** `%s`!\n\n%s",
+	"command.failed":                "**Не удалось %s!** Попробуйте позже.",
+
+	// Ошибки разбора аргументов и расписаний.
+	"parser.error":              "%s в позиции %d: `%s`",
+	"parser.unterminated_quote": "незакрытая кавычка",
+	"parser.unknown_flag":       "неизвестный флаг",
+	"parser.duplicated_flag":    "повторяющийся флаг",
+	"parser.missing_value":      "не указано значение флага",
+	"cron.field_count":          "ожидается полей: %d, указано: %d",
+	"cron.invalid_step":         "неверный шаг в поле «%s»: `%s`",
+	"cron.invalid_value":        "неверное значение поля «%s»: `%s`",
+	"cron.out_of_range":         "поле «%s» вне диапазона %d-%d: `%s`",
+	"cron.minute":               "минута",
+	"cron.hour":                 "час",
+	"cron.day_of_month":         "день месяца",
+	"cron.month":                "месяц",
+	"cron.day_of_week":          "день недели",
+
+	// Действия для сообщения command.failed.
+	"action.create_poll":     "создать опрос",
+	"action.post_poll":       "опубликовать опрос",
+	"action.open_dialog":     "открыть диалог",
+	"action.resolve_weights": "найти пользователей и группы для весов",
+	"action.vote":            "проголосовать",
+	"action.add_option":      "добавить вариант",
+	"action.retract_vote":    "отозвать голос",
+	"action.change_vote":     "изменить голос",
+	"action.get_results":     "получить результаты опроса",
+	"action.close_poll":      "закрыть опрос",
+	"action.reopen_poll":     "открыть опрос повторно",
+	"action.delete_poll":     "удалить опрос",
+	"action.list_polls":      "получить список опросов",
+	"action.create_schedule": "создать расписание",
+	"action.list_schedules":  "получить список расписаний",
+	"action.pause_schedule":  "приостановить расписание",
+	"action.resume_schedule": "возобновить расписание",
+	"action.remove_schedule": "удалить расписание",
+
+	// Настройки опроса.
+	"poll.invalid_deadline":       "**Неверный срок!** *Ожидается*: длительность (например, `2h`) или время в UTC (например, `2025-01-02T15:04`)",
+	"poll.past_deadline":          "**Срок должен быть в будущем!**",
+	"poll.invalid_weights":        "**Неверные веса!** *Ожидается*: `@user=N` или `@group=N` через запятую (например, `@alice=3, @committee=2`)",
+	"poll.invalid_weight":         "**Неверный вес!** *Ожидается*: от `1` до `%d`",
+	"poll.invalid_quorum":         "**Неверный кворум!** *Ожидается*: число проголосовавших или процент участников канала (например, `10` или `50%%`)",
+	"poll.invalid_quorum_percent": "**Неверный кворум!** *Ожидается*: процент участников канала от `1%%` до `100%%`",
+	"poll.invalid_threshold":      "**Неверный порог!** *Ожидается*: доля или процент голосов (например, `2/3` или `60%%`)",
+	"poll.invalid_max_votes":      "**Неверное количество голосов на пользователя!** *Ожидается*: от `0` (без ограничений) до `%d`",
+	"poll.ranked_multiple_choice": "**Рейтинговые опросы не поддерживают множественный выбор!**",
+	"poll.user_not_found":         "**Пользователь или группа `@%s` не найдены!**",
+
+	// Управление опросами.
+	"poll.not_found":        "**Неверный Poll_ID или опрос не существует!**",
+	"poll.already_closed":   "*Опрос*: `%s` **уже закрыт!**",
+	"poll.not_closed":       "*Опрос*: `%s` **не закрыт!**",
+	"poll.close_forbidden":  "**У вас нет прав на закрытие опроса!**",
+	"poll.reopen_forbidden": "**У вас нет прав на повторное открытие опроса!**",
+	"poll.delete_forbidden": "**У вас нет прав на удаление опроса!**",
+	"poll.closed":           "*Опрос*: `%s` **успешно закрыт!**",
+	"poll.reopened":         "*Опрос*: `%s` **успешно открыт повторно!**",
+	"poll.deleted":          "*Опрос*: `%s` **успешно удален!**",
+
+	// Голосование.
+	"vote.not_member":           "**Голосовать могут только участники канала опроса!**",
+	"vote.not_ranked":           "**Это не рейтинговый опрос, выберите один вариант!**",
+	"vote.invalid_option":       "**Неверный вариант!**",
+	"vote.again":                "**Вы не можете проголосовать повторно!**",
+	"vote.too_many_options":     "**Нельзя выбрать больше %d вариантов!**",
+	"vote.duplicate":            "**Вы уже проголосовали за этот вариант!**",
+	"vote.empty_ranking":        "**Расставьте хотя бы один вариант!**",
+	"vote.ranked_twice":         "**Вариант `%s` указан в бюллетене несколько раз!**",
+	"vote.not_voted":            "**Вы не голосовали в этом опросе!**",
+	"vote.option_not_voted":     "**Вы не голосовали за этот вариант!**",
+	"vote.ranked_retract_whole": "**Бюллетень рейтингового опроса можно отозвать только целиком!**",
+	"vote.legacy":               "**Ваш голос отдан до того, как бот начал сохранять выбранные варианты, поэтому его нельзя отозвать или изменить!**",
+	"vote.recorded":             "**Голос учтен!**",
+	"vote.retracted":            "**Голос отозван!**",
+	"vote.changed":              "**Голос изменен!**",
+
+	// Добавление вариантов.
+	"option.not_allowed": "**В этот опрос нельзя добавлять варианты!**",
+	"option.empty":       "**Вариант не может быть пустым!**",
+	"option.too_long":    "**Вариант слишком длинный!** *Ожидается*: не более `%d` символов",
+	"option.exists":      "**Вариант `%s` уже существует!**",
+	"option.too_many":    "**В опросе уже максимальное количество вариантов: %d!**",
+	"option.added":       "**Вариант добавлен!**",
+
+	// Результаты.
+	"results.hidden":             "**Результаты скрыты до закрытия опроса!**",
+	"results.not_member":         "**Результаты опроса доступны только участникам его канала!**",
+	"results.unsupported_format": "**Неподдерживаемый формат** `%s`! *Ожидается*: `csv` или `json`",
+	"results.posted":             "**Результаты опубликованы!**",
+	"results.exported":           "**Результаты выгружены!**",
+
+	// Повторяющиеся опросы.
+	"schedule.unknown_action":    "**Неизвестное действие** `%s`!\n\n%s",
+	"schedule.invalid_duration":  "**Неверная длительность!** *Ожидается*: не меньше минуты (например, `2h` или `90m`)",
+	"schedule.invalid_cron":      "**Неверное расписание!** %s. *Ожидается*: пять полей cron в UTC (например, `0 12 * * 5`)",
+	"schedule.never_runs":        "**Неверное расписание!** `%s` никогда не срабатывает",
+	"schedule.not_found":         "**Неверный Schedule_ID или расписание не существует!**",
+	"schedule.pause_forbidden":   "**У вас нет прав на приостановку расписания!**",
+	"schedule.resume_forbidden":  "**У вас нет прав на возобновление расписания!**",
+	"schedule.remove_forbidden":  "**У вас нет прав на удаление расписания!**",
+	"schedule.already_paused":    "*Расписание*: `%s` **уже приостановлено!**",
+	"schedule.not_paused":        "*Расписание*: `%s` **не приостановлено!**",
+	"schedule.created":           "*Расписание*: `%s` **создано!** *Следующий опрос*: %s",
+	"schedule.paused":            "*Расписание*: `%s` **приостановлено!**",
+	"schedule.resumed":           "*Расписание*: `%s` **возобновлено!**",
+	"schedule.resumed_next_poll": "*Расписание*: `%s` **возобновлено!** *Следующий опрос*: %s",
+	"schedule.removed":           "*Расписание*: `%s` **удалено!**",
+
+	// Проверка диалогов.
+	"dialog.question_empty":    "Вопрос не может быть пустым.",
+	"dialog.options_few":       "Введите не меньше двух вариантов, по одному в строке.",
+	"dialog.option_duplicated": "Вариант \"%s\" повторяется.",
+	"dialog.max_votes_empty":   "Выберите, сколько вариантов может выбрать пользователь.",
+	"dialog.max_votes_too_big": "Не может быть больше количества вариантов.",
+	"dialog.max_votes_ranked":  "Рейтинговые опросы поддерживают только одиночный выбор.",
+	"dialog.invalid_ends":      "Введите длительность (например, 2h) или будущее время в UTC (например, 2025-01-02T15:04).",
+	"dialog.invalid_quorum":    "Введите число проголосовавших (например, 10) или процент участников канала (например, 50%%).",
+	"dialog.invalid_threshold": "Введите долю (например, 2/3) или процент голосов (например, 60%%).",
+	"dialog.invalid_weights":   "Введите веса в виде @user=N или @group=N через запятую.",
+	"dialog.option_ranked":     "Вариант \"%s\" уже выбран.",
+	"dialog.rank_empty":        "Выберите хотя бы один вариант.",
+
+	// Интерактивные диалоги.
+	"dialog.create_title":        "Создание опроса",
+	"dialog.create_submit":       "Создать",
+	"dialog.question":            "Вопрос",
+	"dialog.options":             "Варианты",
+	"dialog.options_help":        "По одному варианту в строке.",
+	"dialog.max_votes":           "Голосов на пользователя",
+	"dialog.max_votes_single":    "Один вариант",
+	"dialog.max_votes_up_to":     "До %d вариантов",
+	"dialog.max_votes_unlimited": "Без ограничений",
+	"dialog.public":              "Публичный",
+	"dialog.public_help":         "Показывать, кто за что проголосовал",
+	"dialog.ranked":              "Рейтинговый",
+	"dialog.ranked_help":         "Ранжировать варианты и подсчитывать голоса с выбыванием",
+	"dialog.open_options":        "Открытые варианты",
+	"dialog.open_options_help":   "Разрешить участникам добавлять свои варианты",
+	"dialog.hide_results":        "Скрыть результаты",
+	"dialog.hide_results_help":   "Скрывать результаты до закрытия опроса",
+	"dialog.weights":             "Веса",
+	"dialog.weights_help":        "Веса голосов пользователей и групп, например @alice=3, @committee=2. Остальные голосуют с весом 1.",
+	"dialog.quorum":              "Кворум",
+	"dialog.quorum_help":         "Минимальное число проголосовавших (например, 10) или процент участников канала (например, 50%%).",
+	"dialog.threshold":           "Порог принятия",
+	"dialog.threshold_help":      "Доля голосов, которую должен набрать лидирующий вариант, например 2/3 или 60%%.",
+	"dialog.ends":                "Окончание",
+	"dialog.ends_help":           "Закрыть автоматически через заданное время (например, 2h) или в момент времени в UTC (например, 2025-01-02T15:04).",
+	"dialog.rank_title":          "Ранжирование вариантов",
+	"dialog.rank_submit":         "Проголосовать",
+	"dialog.choice":              "Место №%d",
+	"dialog.add_option_title":    "Добавление варианта",
+	"dialog.add_option_submit":   "Добавить",
+	"dialog.option":              "Вариант",
+
+	// Подписи диаграммы результатов.
+	"chart.weighted": "голоса: %d, вес: %d (%.1f%%)",
+
+	// Таблицы результатов и списков.
+	"table.options":      "Варианты",
+	"table.voices":       "Голоса",
+	"table.weighted":     "Вес",
+	"table.percent":      "Процент",
+	"table.voters":       "Проголосовавшие",
+	"table.question":     "Вопрос",
+	"table.total_weight": "Общий вес",
+	"table.status":       "Статус",
+	"table.schedule":     "Расписание",
+	"table.next_poll":    "Следующий опрос",
+	"table.completed":    "🔴 (Завершен)",
+	"table.active":       "🟢 (Активен)",
+
+	// Результаты и списки.
+	"results.hidden_note":    "*Результаты скрыты до закрытия опроса.*",
+	"list.empty":             "**Опросы не найдены!**",
+	"list.page":              "*Страница %d из %d* (найдено опросов: %d)",
+	"schedule.list_empty":    "**Расписания не найдены!**",
+	"schedule.status_active": "🟢 (Активно)",
+	"schedule.status_paused": "⏸️ (Приостановлено)",
+
+	// Сообщения опросов.
+	"post.poll":               "*Poll_ID*: `%s`\n\n%s",
+	"post.deleted":            "*Опрос*: `%s` **удален!**",
+	"post.closed":             "*Опрос*: `%s` **закрыт!**\n\n%s",
+	"post.closed_at_deadline": "*Опрос*: `%s` **закрыт по истечении срока!**\n\n%s",
+	"post.export":             "*Poll_ID*: `%s`, результаты (%s)",
+	"post.rank":               "Ранжировать варианты",
+	"post.add_option":         "Добавить вариант",
+	"post.retract":            "Отозвать голос",
+	"post.ranked_note":        "*Рейтинговый опрос: расставьте варианты в порядке предпочтения.*",
+	"post.any_number_note":    "*Можно выбрать любое количество вариантов.*",
+	"post.up_to_note":         "*Можно выбрать до %d вариантов.*",
+	"post.public_note":        "*Это публичный опрос: все видят, кто за что проголосовал.*",
+	"post.open_options_note":  "*Участники могут добавлять свои варианты.*",
+	"post.closes_at_note":     "*Опрос закроется автоматически %s.*",
+
+	// Подсчет рейтингового опроса.
+	"runoff.round":      "*Тур %d*: %s",
+	"runoff.eliminated": " — выбывает `%s`",
+	"runoff.winner":     "**Победитель**: `%s`",
+	"runoff.no_winner":  "**Победителя нет**",
+
+	// Итоги опросов для принятия решения.
+	"decision.no_quorum":           "**Решение**: ⚪ НЕТ КВОРУМА (проголосовало: `%d` из `%d` необходимых)",
+	"decision.quorum_unknown":      "**Решение**: ⚪ КВОРУМ НЕИЗВЕСТЕН (проголосовало: `%d`, количество участников канала при закрытии опроса получить не удалось)",
+	"decision.passed":              "**Решение**: ✅ ПРИНЯТО — `%s` (%s)",
+	"decision.failed":              "**Решение**: ❌ НЕ ПРИНЯТО — `%s` (%s)",
+	"decision.failed_no_leader":    "**Решение**: ❌ НЕ ПРИНЯТО — нет единственного лидирующего варианта",
+	"decision.share":               "`%.1f％` голосов",
+	"decision.share_threshold":     "`%.1f％` голосов, необходимо `%.1f％`",
+	"decision.rules":               "*Опрос для принятия решения: %s.*",
+	"decision.rule_quorum":         "не меньше %d проголосовавших",
+	"decision.rule_quorum_percent": "не меньше %d%% участников канала",
+	"decision.rule_threshold":      "%.1f%% голосов для принятия",
+
+	// Уведомления о модерации.
+	"moderation.closed":           "*Опрос*: `%s` (%s) **закрыт %s!**",
+	"moderation.reopened":         "*Опрос*: `%s` (%s) **открыт повторно %s!**",
+	"moderation.deleted":          "*Опрос*: `%s` (%s) **удален %s!**",
+	"moderation.by_system_admin":  "администратором системы",
+	"moderation.by_team_admin":    "администратором команды",
+	"moderation.by_channel_admin": "администратором канала",
+
+	// Справка по командам.
+	"help.commands":        "**Доступные команды:**",
+	"help.actions":         "**Доступные действия:**",
+	"help.poll.create":     "Создать опрос (без аргументов открывает диалог)",
+	"help.poll.vote":       "Проголосовать (для рейтингового опроса варианты перечисляются в порядке предпочтения)",
+	"help.poll.add-option": "Добавить вариант в опрос с открытыми вариантами",
+	"help.poll.retract":    "Отозвать голос (за все варианты, если вариант не указан)",
+	"help.poll.change":     "Заменить голос другим вариантом",
+	"help.poll.results":    "Получить результаты опроса, опубликовать их с диаграммой или выгрузить в файл",
+	"help.poll.close":      "Закрыть активный опрос",
+	"help.poll.reopen":     "Повторно открыть закрытый опрос",
+	"help.poll.delete":     "Удалить существующий опрос",
+	"help.poll.list":       "Показать опросы канала или ваши опросы",
+	"help.poll.schedule":   "Создать, показать, приостановить, возобновить или удалить повторяющиеся опросы",
+	"help.poll.help":       "Показать доступные команды",
+	"help.schedule.create": "Создать повторяющийся опрос (расписание в UTC)",
+	"help.schedule.list":   "Показать повторяющиеся опросы канала",
+	"help.schedule.pause":  "Приостановить повторяющийся опрос",
+	"help.schedule.resume": "Возобновить приостановленный повторяющийся опрос",
+	"help.schedule.remove": "Удалить повторяющийся опрос",
+}
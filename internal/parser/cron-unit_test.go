@@ -0,0 +1,74 @@
+package parser_test
+
+import (
+	"matterpoll-bot/internal/parser"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCronNext проверяет вычисление ближайшего запуска по расписанию.
+func TestCronNext(t *testing.T) {
+	// 2025-01-01 - среда.
+	after := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		spec string
+		next time.Time
+	}{
+		{"Every minute", "* * * * *", time.Date(2025, 1, 1, 10, 31, 0, 0, time.UTC)},
+		{"Every 15 minutes", "*/15 * * * *", time.Date(2025, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"Friday noon", "0 12 * * 5", time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC)},
+		{"Sunday as 7", "0 9 * * 7", time.Date(2025, 1, 5, 9, 0, 0, 0, time.UTC)},
+		{"Weekdays range", "0 9 * * 1-5", time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)},
+		{"Later today", "0,45 10,18 * * *", time.Date(2025, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"Day of month", "0 0 15 * *", time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"Day of month or weekday", "0 0 15 * 5", time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"Leap day", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"Weekly alias", "@weekly", time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"Impossible date", "0 0 31 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := parser.ParseCron(tt.spec)
+			require.NoError(t, err)
+			require.Equal(t, tt.next, cron.Next(after))
+		})
+	}
+}
+
+// TestParseCronErrors проверяет сообщения об ошибках разбора расписания.
+func TestParseCronErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		err  string
+	}{
+		{"Empty", "", "expected 5 fields, got 0"},
+		{"Too many fields", "0 12 * * 5 2025", "expected 5 fields, got 6"},
+		{"Not a number", "0 noon * * 5", "invalid hour: `noon`"},
+		{"Out of range", "60 12 * * 5", "minute out of range 0-59: `60`"},
+		{"Reversed range", "0 12 * * 5-1", "day of week out of range 0-7: `5-1`"},
+		{"Invalid step", "*/0 * * * *", "invalid step in minute field: `*/0`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parser.ParseCron(tt.spec)
+			require.Error(t, err)
+			require.Equal(t, tt.err, err.Error())
+		})
+	}
+}
+
+// TestCronErrorLocalize проверяет описание ошибки расписания на языке пользователя.
+func TestCronErrorLocalize(t *testing.T) {
+	_, err := parser.ParseCron("0 12 * * 5-1")
+
+	var cronErr *parser.CronError
+	require.ErrorAs(t, err, &cronErr)
+	require.Equal(t, "поле «день недели» вне диапазона 0-7: `5-1`", cronErr.Localize("ru"))
+}
@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"matterpoll-bot/internal/i18n"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronAliases - сокращенные записи часто используемых расписаний.
+var cronAliases = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// cronField описывает поле расписания: ключ его названия в каталоге сообщений и допустимый диапазон значений.
+type cronField struct {
+	name     i18n.Key
+	min, max int
+}
+
+// cronFields - поля расписания в порядке следования: минута, час, день месяца, месяц, день недели.
+var cronFields = []cronField{
+	{"cron.minute", 0, 59},
+	{"cron.hour", 0, 23},
+	{"cron.day_of_month", 1, 31},
+	{"cron.month", 1, 12},
+	{"cron.day_of_week", 0, 7},
+}
+
+// CronError описывает ошибку разбора расписания: ключ сообщения в каталоге и его параметры.
+type CronError struct {
+	Key    string
+	Params []any
+}
+
+func (e *CronError) Error() string {
+	return e.Localize(i18n.DefaultLocale)
+}
+
+// Localize возвращает описание ошибки на языке locale.
+func (e *CronError) Localize(locale string) string {
+	return i18n.T(locale, e.Key, e.Params...)
+}
+
+// Cron представляет разобранное расписание в формате cron: для каждого поля хранятся допустимые значения.
+type Cron struct {
+	minutes, hours, days, months, weekdays map[int]bool
+	anyDay, anyWeekday                     bool // anyDay, anyWeekday - поля дня месяца и дня недели заданы как `*`.
+}
+
+// ParseCron разбирает расписание из пяти полей в формате cron: `минута час день месяц день_недели`
+// (например, `0 12 * * 5` - каждую пятницу в 12:00 UTC) или одно из сокращений
+// `@hourly`, `@daily`, `@weekly`, `@monthly`. Поле может содержать `*`, число, диапазон (`1-5`),
+// шаг (`*/15`, `0-30/10`) и их перечисление через запятую. Воскресенье обозначается `0` или `7`.
+// Как и в cron, если заданы и день месяца, и день недели, подходит любой из них.
+//
+// Возвращает ошибку *CronError, если расписание некорректно.
+func ParseCron(spec string) (*Cron, error) {
+	spec = strings.TrimSpace(spec)
+	if alias, ok := cronAliases[strings.ToLower(spec)]; ok {
+		spec = alias
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, &CronError{Key: "cron.field_count", Params: []any{len(cronFields), len(fields)}}
+	}
+
+	values := make([]map[int]bool, len(fields))
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = set
+	}
+
+	if values[4][7] {
+		values[4][0] = true
+		delete(values[4], 7)
+	}
+
+	return &Cron{
+		minutes:    values[0],
+		hours:      values[1],
+		days:       values[2],
+		months:     values[3],
+		weekdays:   values[4],
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField разбирает значение поля расписания и возвращает множество допустимых значений.
+func parseCronField(text string, field cronField) (map[int]bool, error) {
+	set := map[int]bool{}
+	for _, part := range strings.Split(text, ",") {
+		rangeText, stepText, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step < 1 {
+				return nil, &CronError{Key: "cron.invalid_step", Params: []any{field.name, part}}
+			}
+		}
+
+		low, high := field.min, field.max
+		if rangeText != "*" {
+			lowText, highText, isRange := strings.Cut(rangeText, "-")
+
+			var err error
+			if low, err = strconv.Atoi(lowText); err != nil {
+				return nil, &CronError{Key: "cron.invalid_value", Params: []any{field.name, part}}
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highText); err != nil {
+					return nil, &CronError{Key: "cron.invalid_value", Params: []any{field.name, part}}
+				}
+			} else if hasStep {
+				high = field.max
+			}
+		}
+
+		if low < field.min || high > field.max || low > high {
+			return nil, &CronError{Key: "cron.out_of_range", Params: []any{field.name, field.min, field.max, part}}
+		}
+		for value := low; value <= high; value += step {
+			set[value] = true
+		}
+	}
+
+	return set, nil
+}
+
+// Next возвращает ближайший момент после after (с точностью до минуты, в UTC), подходящий под расписание.
+// Если такого момента нет в ближайшие пять лет (например, для 31 февраля), возвращается нулевое время.
+func (c *Cron) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !c.months[int(t.Month())]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !c.hours[t.Hour()]:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !c.minutes[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// matchDay сообщает, подходит ли день t под поля дня месяца и дня недели.
+func (c *Cron) matchDay(t time.Time) bool {
+	day, weekday := c.days[t.Day()], c.weekdays[int(t.Weekday())]
+	switch {
+	case c.anyDay && c.anyWeekday:
+		return true
+	case c.anyDay:
+		return weekday
+	case c.anyWeekday:
+		return day
+	}
+
+	return day || weekday
+}
@@ -0,0 +1,145 @@
+package parser_test
+
+import (
+	"matterpoll-bot/internal/parser"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testFlags = parser.Flags{"max-votes": true, "ends": true, "public": false}
+
+// TestParse проверяет разбор строк аргументов слеш-команд.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		args  []string
+		flags map[string]string
+	}{
+		{"Empty", "", []string{}, map[string]string{}},
+		{"Quoted arguments", `"Question" "Option 1" "Option 2"`, []string{"Question", "Option 1", "Option 2"}, map[string]string{}},
+		{"Extra spaces", "  \"Question\"   \"Option\"\t ", []string{"Question", "Option"}, map[string]string{}},
+		{"Unquoted arguments", `poll_id Option`, []string{"poll_id", "Option"}, map[string]string{}},
+		{"Smart quotes", `“Question” „Option 1“ «Option 2»`, []string{"Question", "Option 1", "Option 2"}, map[string]string{}},
+		{"Separator inside option", `"Q" "a\" \"b"`, []string{"Q", `a" "b`}, map[string]string{}},
+		{"Escaped backslash", `"C:\\path" "C:\dir"`, []string{`C:\path`, `C:\dir`}, map[string]string{}},
+		{"Escaped space", `Option\ 1`, []string{"Option 1"}, map[string]string{}},
+		{"Empty argument", `"" "Option"`, []string{"", "Option"}, map[string]string{}},
+		{"Flags", `"Q" "A" "B" --max-votes 2 --public --ends "2h"`, []string{"Q", "A", "B"}, map[string]string{"max-votes": "2", "public": "", "ends": "2h"}},
+		{"Flags before arguments", `--public "Q" "A"`, []string{"Q", "A"}, map[string]string{"public": ""}},
+		{"Boolean flag value", `"Q" --public false "true"`, []string{"Q", "true"}, map[string]string{"public": "false"}},
+		{"Quoted flag is argument", `"Q" "--public"`, []string{"Q", "--public"}, map[string]string{}},
+		{"End of flags", `"Q" -- --public`, []string{"Q", "--public"}, map[string]string{}},
+		{"Cyrillic", `"Вопрос" "Вариант 1"`, []string{"Вопрос", "Вариант 1"}, map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := parser.Parse(tt.text, testFlags)
+			require.NoError(t, err)
+			require.Equal(t, tt.args, cmd.Args)
+			require.Equal(t, tt.flags, cmd.Flags)
+		})
+	}
+}
+
+// TestParseErrors проверяет сообщения об ошибках разбора.
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		err  string
+	}{
+		{"Unterminated quote", `"Question" "Option`, "unterminated quote at position 12: `\"Option`"},
+		{"Unterminated smart quote", `"Вопрос" “Вариант`, "unterminated quote at position 10: `“Вариант`"},
+		{"Unknown flag", `"Q" "A" --anonymous`, "unknown flag at position 9: `--anonymous`"},
+		{"Missing value", `"Q" "A" --max-votes`, "missing value for flag at position 9: `--max-votes`"},
+		{"Flag instead of value", `"Q" --ends --public`, "missing value for flag at position 5: `--ends`"},
+		{"Duplicated flag", `"Q" --public --public`, "duplicated flag at position 14: `--public`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := parser.Parse(tt.text, testFlags)
+			require.Nil(t, cmd)
+			require.Error(t, err)
+			require.Equal(t, tt.err, err.Error())
+
+			var parseErr *parser.Error
+			require.ErrorAs(t, err, &parseErr)
+		})
+	}
+}
+
+// TestErrorLocalize проверяет описание ошибки разбора на языке пользователя.
+func TestErrorLocalize(t *testing.T) {
+	_, err := parser.Parse(`"Q" "A" --anonymous`, testFlags)
+
+	var parseErr *parser.Error
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, "неизвестный флаг в позиции 9: `--anonymous`", parseErr.Localize("ru"))
+}
+
+// TestQuote проверяет, что экранированные аргументы разбираются в исходные строки.
+func TestQuote(t *testing.T) {
+	args := []string{"", "Option 1", `a" "b`, `C:\path\`, "«quoted»", "--public"}
+
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		quoted = append(quoted, parser.Quote(arg))
+	}
+
+	cmd, err := parser.Parse(strings.Join(quoted, " "), testFlags)
+	require.NoError(t, err)
+	require.Equal(t, args, cmd.Args)
+	require.Empty(t, cmd.Flags)
+}
+
+// FuzzParse проверяет, что разбор произвольной строки не приводит к панике,
+// а аргументы успешно разобранной строки после экранирования разбираются без изменений.
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		`"Question" "Option 1" "Option 2" --max-votes 2 --public`,
+		`“Question” «Option»`,
+		`"a\" \"b" C:\path --`,
+		`"unterminated`,
+		"\t--ends \"2h\"\n",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		cmd, err := parser.Parse(text, testFlags)
+		if err != nil {
+			var parseErr *parser.Error
+			require.ErrorAs(t, err, &parseErr)
+			require.Positive(t, parseErr.Pos)
+			return
+		}
+
+		quoted := make([]string, 0, len(cmd.Args))
+		for _, arg := range cmd.Args {
+			quoted = append(quoted, parser.Quote(arg))
+		}
+
+		again, err := parser.Parse(strings.Join(quoted, " "), testFlags)
+		require.NoError(t, err)
+		require.Equal(t, cmd.Args, again.Args)
+	})
+}
+
+// TestCommandBool проверяет значения логических флагов.
+func TestCommandBool(t *testing.T) {
+	for text, expected := range map[string]bool{
+		`"Q"`:                false,
+		`"Q" --public`:       true,
+		`"Q" --public true`:  true,
+		`"Q" --public false`: false,
+	} {
+		cmd, err := parser.Parse(text, testFlags)
+		require.NoError(t, err)
+		require.Equal(t, expected, cmd.Bool("public"), text)
+	}
+}
@@ -0,0 +1,189 @@
+package parser
+
+import (
+	"matterpoll-bot/internal/i18n"
+	"strings"
+	"unicode"
+)
+
+// Flags описывает флаги, поддерживаемые командой: имя флага без префикса "--"
+// и признак того, что флаг принимает значение (флаги без значения являются логическими).
+type Flags map[string]bool
+
+// Command представляет разобранную строку аргументов слеш-команды.
+type Command struct {
+	Args  []string          // Args - позиционные аргументы в порядке следования.
+	Flags map[string]string // Flags - значения указанных флагов (пустая строка для логических флагов).
+}
+
+// Has сообщает, был ли указан флаг name.
+func (c *Command) Has(name string) bool {
+	_, ok := c.Flags[name]
+	return ok
+}
+
+// Bool сообщает, включен ли логический флаг name: флаг указан без значения или со значением `true`.
+func (c *Command) Bool(name string) bool {
+	value, ok := c.Flags[name]
+	return ok && value != "false"
+}
+
+// Error описывает ошибку разбора с указанием позиции (в символах, начиная с 1) и токена, на котором она возникла.
+type Error struct {
+	Pos   int    // Pos - позиция начала токена в строке.
+	Token string // Token - токен, вызвавший ошибку.
+	Key   string // Key - ключ описания ошибки в каталоге сообщений.
+}
+
+func (e *Error) Error() string {
+	return e.Localize(i18n.DefaultLocale)
+}
+
+// Localize возвращает описание ошибки на языке locale.
+func (e *Error) Localize(locale string) string {
+	return i18n.T(locale, "parser.error", i18n.Key(e.Key), e.Pos, e.Token)
+}
+
+// token представляет отдельный токен строки аргументов.
+type token struct {
+	value  string // value - значение токена без кавычек и экранирования.
+	raw    string // raw - исходный текст токена.
+	pos    int    // pos - позиция начала токена (в символах, начиная с 1).
+	quoted bool   // quoted - содержит ли токен кавычки или экранирование (такой токен не может быть флагом).
+}
+
+// Parse разбирает строку аргументов слеш-команды по правилам, похожим на командную оболочку:
+//   - аргументы разделяются любым количеством пробельных символов;
+//   - аргумент с пробелами заключается в двойные кавычки, в том числе типографские (“ ” „ « »),
+//     которые подставляют мобильные клавиатуры;
+//   - обратная косая черта экранирует кавычку, пробельный символ или саму себя;
+//   - токен вида `--name` вне кавычек является флагом из flags, флаг со значением
+//     берет его из следующего токена, а логический флаг — только если это `true` или `false` без кавычек;
+//     токен `--` завершает список флагов.
+//
+// Возвращает ошибку *Error с позицией и текстом некорректного токена.
+func Parse(text string, flags Flags) (*Command, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := &Command{Args: []string{}, Flags: map[string]string{}}
+	flagsEnded := false
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if flagsEnded || tok.quoted || !strings.HasPrefix(tok.value, "--") {
+			cmd.Args = append(cmd.Args, tok.value)
+			continue
+		}
+
+		if tok.value == "--" {
+			flagsEnded = true
+			continue
+		}
+
+		name := strings.TrimPrefix(tok.value, "--")
+		takesValue, known := flags[name]
+		if !known {
+			return nil, &Error{Pos: tok.pos, Token: tok.raw, Key: "parser.unknown_flag"}
+		}
+		if cmd.Has(name) {
+			return nil, &Error{Pos: tok.pos, Token: tok.raw, Key: "parser.duplicated_flag"}
+		}
+
+		if !takesValue {
+			cmd.Flags[name] = ""
+			if i+1 < len(tokens) && !tokens[i+1].quoted && (tokens[i+1].value == "true" || tokens[i+1].value == "false") {
+				i++
+				cmd.Flags[name] = tokens[i].value
+			}
+			continue
+		}
+
+		if i+1 >= len(tokens) || (!tokens[i+1].quoted && strings.HasPrefix(tokens[i+1].value, "--")) {
+			return nil, &Error{Pos: tok.pos, Token: tok.raw, Key: "parser.missing_value"}
+		}
+		i++
+		cmd.Flags[name] = tokens[i].value
+	}
+
+	return cmd, nil
+}
+
+// Quote возвращает аргумент в виде, который Parse разберет обратно в исходную строку.
+func Quote(arg string) string {
+	var sb strings.Builder
+
+	sb.WriteByte('"')
+	for _, r := range arg {
+		if isQuote(r) || r == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// tokenize разбивает строку на токены с учетом кавычек и экранирования.
+func tokenize(text string) ([]token, error) {
+	runes := []rune(text)
+
+	var tokens []token
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		var sb strings.Builder
+		quoted := false
+		inQuotes := false
+		quoteStart := 0
+
+	scan:
+		for ; i < len(runes); i++ {
+			r := runes[i]
+			switch {
+			case r == '\\' && i+1 < len(runes) && isEscapable(runes[i+1]):
+				i++
+				sb.WriteRune(runes[i])
+				quoted = true
+			case isQuote(r):
+				if !inQuotes {
+					quoteStart = i
+				}
+				inQuotes = !inQuotes
+				quoted = true
+			case unicode.IsSpace(r) && !inQuotes:
+				break scan
+			default:
+				sb.WriteRune(r)
+			}
+		}
+
+		if inQuotes {
+			return nil, &Error{Pos: quoteStart + 1, Token: string(runes[quoteStart:]), Key: "parser.unterminated_quote"}
+		}
+
+		tokens = append(tokens, token{value: sb.String(), raw: string(runes[start:i]), pos: start + 1, quoted: quoted})
+	}
+
+	return tokens, nil
+}
+
+// isQuote сообщает, является ли символ двойной кавычкой (в том числе типографской).
+func isQuote(r rune) bool {
+	switch r {
+	case '"', '“', '”', '„', '«', '»':
+		return true
+	}
+	return false
+}
+
+// isEscapable сообщает, может ли символ быть экранирован обратной косой чертой.
+func isEscapable(r rune) bool {
+	return isQuote(r) || r == '\\' || unicode.IsSpace(r)
+}
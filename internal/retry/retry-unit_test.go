@@ -0,0 +1,74 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"matterpoll-bot/internal/retry"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testBackoff - короткие паузы, чтобы тесты не ждали.
+var testBackoff = retry.Backoff{Initial: time.Millisecond, Max: 4 * time.Millisecond}
+
+// TestDo проверяет повторные попытки до успеха и остановку по завершении контекста.
+func TestDo(t *testing.T) {
+	testErr := errors.New("connection refused")
+
+	t.Run("first attempt", func(t *testing.T) {
+		calls := 0
+		err := retry.Do(context.Background(), testBackoff, "connect", func() error {
+			calls++
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("success after retries", func(t *testing.T) {
+		calls := 0
+		err := retry.Do(context.Background(), testBackoff, "connect", func() error {
+			calls++
+			if calls < 4 {
+				return testErr
+			}
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, 4, calls)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		calls := 0
+		err := retry.Do(ctx, testBackoff, "connect", func() error {
+			calls++
+			return testErr
+		})
+
+		require.ErrorIs(t, err, testErr)
+		require.ErrorContains(t, err, "failed to connect after")
+		require.Greater(t, calls, 1)
+	})
+
+	t.Run("canceled before retry", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		calls := 0
+		err := retry.Do(ctx, retry.DefaultBackoff, "connect", func() error {
+			calls++
+			return testErr
+		})
+
+		require.ErrorIs(t, err, testErr)
+		require.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,42 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Backoff - параметры повторных попыток с экспоненциально растущей паузой.
+type Backoff struct {
+	Initial time.Duration // Initial - пауза перед второй попыткой.
+	Max     time.Duration // Max - наибольшая пауза между попытками.
+}
+
+// DefaultBackoff - паузы между попытками подключения к зависимостям при запуске бота.
+var DefaultBackoff = Backoff{Initial: time.Second, Max: 30 * time.Second}
+
+// Do вызывает fn, пока она не завершится без ошибки, удваивая паузу между попытками до b.Max.
+// Ошибки попыток логируются с описанием действия action. Если ctx завершается раньше,
+// возвращается последняя ошибка fn.
+func Do(ctx context.Context, b Backoff, action string, fn func() error) error {
+	delay := b.Initial
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		log.Printf("failed to %s (attempt %d), retrying in %s: %v\n", action, attempt, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("failed to %s after %d attempts: %w", action, attempt, err)
+		case <-timer.C:
+		}
+
+		delay = min(2*delay, b.Max)
+	}
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Области подписей. Значение подписывается вместе с областью, поэтому подпись,
+// выданная для одной цели, например идентификатора пользователя в диалоге, не подходит для другой.
+const (
+	ActionDomain = "action" // ActionDomain - подпись идентификатора опроса в контексте кнопок сообщений.
+	DialogDomain = "dialog" // DialogDomain - подпись идентификатора пользователя в поле State интерактивного диалога.
+)
+
+// SignAction возвращает подпись значения value в области domain, которая передается
+// в контексте кнопок сообщений или в поле State диалога.
+// Подпись вычисляется с помощью HMAC-SHA256 на основе токена бота,
+// поэтому остается действительной после перезапуска бота.
+func (ps *PollService) SignAction(domain, value string) string {
+	mac := hmac.New(sha256.New, []byte(ps.cfg.BotToken))
+	mac.Write([]byte(domain + ":" + value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyAction проверяет, что token является подписью значения value в области domain.
+func (ps *PollService) VerifyAction(domain, value, token string) bool {
+	return hmac.Equal([]byte(ps.SignAction(domain, value)), []byte(token))
+}
@@ -0,0 +1,88 @@
+package services_test
+
+import (
+	"testing"
+
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/services"
+	"matterpoll-bot/internal/services/service_mocks"
+	"matterpoll-bot/internal/storage/store_mocks"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewPollAutocompleteData проверяет дерево автодополнения команды /poll.
+func TestNewPollAutocompleteData(t *testing.T) {
+	data := services.NewPollAutocompleteData()
+	require.NoError(t, data.IsValid())
+	require.Equal(t, "poll", data.Trigger)
+
+	triggers := make([]string, 0, len(data.SubCommands))
+	for _, sub := range data.SubCommands {
+		triggers = append(triggers, sub.Trigger)
+	}
+	require.Equal(t, []string{"create", "vote", "add-option", "retract", "change", "results", "close", "reopen", "delete", "list", "schedule", "help"}, triggers)
+}
+
+// TestNewCommandAutocompleteData проверяет подсказки аргументов отдельных команд.
+func TestNewCommandAutocompleteData(t *testing.T) {
+	for _, urlPath := range []string{"/poll-vote", "/poll-results", "/poll-close", "/poll-delete"} {
+		t.Run(urlPath, func(t *testing.T) {
+			data := services.NewCommandAutocompleteData(entities.CommandInfo{Trigger: urlPath[1:], URLPath: urlPath})
+			require.NotNil(t, data)
+			require.NoError(t, data.IsValid())
+			require.Equal(t, urlPath[1:], data.Trigger)
+			require.Equal(t, model.AutocompleteArgTypeText, data.Arguments[0].Type)
+			require.Equal(t, `"poll_id"`, data.Arguments[0].Data.(*model.AutocompleteTextArg).Hint)
+		})
+	}
+
+	require.Nil(t, services.NewCommandAutocompleteData(entities.CommandInfo{Trigger: "poll-create", URLPath: "/poll-create"}))
+
+	t.Run("/poll-schedule", func(t *testing.T) {
+		data := services.NewCommandAutocompleteData(entities.CommandInfo{Trigger: "poll-schedule", URLPath: "/poll-schedule"})
+		require.NotNil(t, data)
+		require.NoError(t, data.IsValid())
+
+		triggers := make([]string, 0, len(data.SubCommands))
+		for _, sub := range data.SubCommands {
+			triggers = append(triggers, sub.Trigger)
+		}
+		require.Equal(t, []string{"create", "list", "pause", "resume", "remove"}, triggers)
+	})
+}
+
+// TestRegisterPollCommand проверяет регистрацию команды /poll с деревом автодополнения.
+func TestRegisterPollCommand(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	commandList := entities.CommandList
+	t.Cleanup(func() { entities.CommandList = commandList })
+	entities.CommandList = []entities.CommandInfo{
+		{Trigger: "poll", URLPath: entities.PollPath, DisplayName: "Poll"},
+		{Trigger: "poll-vote", URLPath: "/poll-vote", DisplayName: "Vote"},
+		{Trigger: "poll-create", URLPath: "/poll-create", DisplayName: "Create poll"},
+	}
+
+	team := &model.Team{Id: "team_id"}
+	mockBot.On("GetTeamByName", testConfig.TeamName, "").Return(team, &model.Response{StatusCode: 200}, nil)
+	mockBot.On("ListCommands", team.Id, false).Return([]*model.Command{}, &model.Response{StatusCode: 200}, nil)
+	mockBot.On("CreateCommand", mock.Anything).Return(&model.Command{Token: "new_token"}, &model.Response{StatusCode: 201}, nil)
+	mockStore.On("AddCmdToken", mock.Anything, "new_token").Return(nil)
+
+	err := pollService.RegisterCommands()
+	require.NoError(t, err)
+	mockBot.AssertCalled(t, "CreateCommand", mock.MatchedBy(func(cmd *model.Command) bool {
+		return cmd.Trigger == "poll" && cmd.AutocompleteData != nil && cmd.AutocompleteData.IsValid() == nil
+	}))
+	mockBot.AssertCalled(t, "CreateCommand", mock.MatchedBy(func(cmd *model.Command) bool {
+		return cmd.Trigger == "poll-vote" && cmd.AutocompleteData != nil && cmd.AutocompleteData.IsValid() == nil
+	}))
+	mockBot.AssertCalled(t, "CreateCommand", mock.MatchedBy(func(cmd *model.Command) bool {
+		return cmd.Trigger == "poll-create" && cmd.AutocompleteData == nil
+	}))
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"matterpoll-bot/internal/entities"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// NewCommandAutocompleteData формирует дерево автодополнения для команды бота cmd.
+// Для команд без подсказок аргументов возвращается nil.
+func NewCommandAutocompleteData(cmd entities.CommandInfo) *model.AutocompleteData {
+	var data *model.AutocompleteData
+	switch cmd.URLPath {
+	case entities.PollPath:
+		return NewPollAutocompleteData()
+	case "/poll-vote":
+		data = model.NewAutocompleteData(cmd.Trigger, cmd.Hint, cmd.Description)
+		addPollIdArgument(data)
+		data.AddTextArgument("Option to vote for", `"option" ...`, "")
+	case "/poll-add-option":
+		data = model.NewAutocompleteData(cmd.Trigger, cmd.Hint, cmd.Description)
+		addPollIdArgument(data)
+		data.AddTextArgument("New option", `"option"`, "")
+	case "/poll-results":
+		data = model.NewAutocompleteData(cmd.Trigger, cmd.Hint, cmd.Description)
+		addPollIdArgument(data)
+		addResultsArguments(data)
+	case "/poll-close":
+		data = model.NewAutocompleteData(cmd.Trigger, cmd.Hint, cmd.Description)
+		addPollIdArgument(data)
+	case "/poll-reopen":
+		data = model.NewAutocompleteData(cmd.Trigger, cmd.Hint, cmd.Description)
+		addPollIdArgument(data)
+	case "/poll-delete":
+		data = model.NewAutocompleteData(cmd.Trigger, cmd.Hint, cmd.Description)
+		addPollIdArgument(data)
+	case "/poll-list":
+		data = model.NewAutocompleteData(cmd.Trigger, cmd.Hint, cmd.Description)
+		addListArguments(data)
+	case "/poll-schedule":
+		data = newScheduleAutocompleteData(cmd.Trigger, cmd.Description)
+	}
+
+	return data
+}
+
+// NewPollAutocompleteData формирует дерево автодополнения команды /poll:
+// по одной подкоманде на каждое действие с подсказками для их аргументов.
+func NewPollAutocompleteData() *model.AutocompleteData {
+	poll := model.NewAutocompleteData("poll", "[command]", "Manage polls")
+
+	create := model.NewAutocompleteData("create", `"question" "option1" "option2" ... [--max-votes N] [--public] [--ranked] [--open-options] [--hide-results] [--weights "@user=N, @group=N"] [--quorum N|N%] [--threshold 2/3] [--ends 2h]`, "Create a new poll (without arguments opens a dialog)")
+	create.AddTextArgument("Question of the poll", `"question"`, "")
+	create.AddTextArgument("Options of the poll", `"option1" "option2" ...`, "")
+	create.AddNamedTextArgument("max-votes", "Number of options a user can choose (0 - unlimited)", "N", `^\d+$`, false)
+	create.AddNamedStaticListArgument("public", "Show who voted for what", false, boolListItems())
+	create.AddNamedStaticListArgument("ranked", "Rank options and count with instant runoff", false, boolListItems())
+	create.AddNamedStaticListArgument("open-options", "Let participants add their own options", false, boolListItems())
+	create.AddNamedStaticListArgument("hide-results", "Hide results until the poll is closed", false, boolListItems())
+	create.AddNamedTextArgument("weights", "Vote weights of users and groups", `"@user=N, @group=N"`, "", false)
+	create.AddNamedTextArgument("quorum", "Minimum number of voters or percent of channel members", "N|N%", "", false)
+	create.AddNamedTextArgument("threshold", "Share of votes the leading option needs to pass", "2/3|60%", "", false)
+	create.AddNamedTextArgument("ends", "Close automatically after a duration or at a time in UTC", "2h", "", false)
+	poll.AddCommand(create)
+
+	vote := model.NewAutocompleteData("vote", `"poll_id" "option" ...`, "Cast a vote (list options in order of preference for ranked polls)")
+	addPollIdArgument(vote)
+	vote.AddTextArgument("Option to vote for", `"option" ...`, "")
+	poll.AddCommand(vote)
+
+	addOption := model.NewAutocompleteData("add-option", `"poll_id" "option"`, "Add an option to a poll with open options")
+	addPollIdArgument(addOption)
+	addOption.AddTextArgument("New option", `"option"`, "")
+	poll.AddCommand(addOption)
+
+	retract := model.NewAutocompleteData("retract", `"poll_id" ["option"]`, "Retract your vote (all options if none is given)")
+	retract.AddTextArgument("ID of the poll", `"poll_id"`, "")
+	poll.AddCommand(retract)
+
+	change := model.NewAutocompleteData("change", `"poll_id" "option" ...`, "Replace your vote with another option")
+	change.AddTextArgument("ID of the poll", `"poll_id"`, "")
+	change.AddTextArgument("New option", `"option" ...`, "")
+	poll.AddCommand(change)
+
+	results := model.NewAutocompleteData("results", `"poll_id" [--format csv|json] [--chart] [--share]`, "Get poll results, post them with a chart or export them as a file")
+	addPollIdArgument(results)
+	addResultsArguments(results)
+	poll.AddCommand(results)
+
+	for _, cmd := range []struct{ trigger, helpText string }{
+		{"close", "Close an active poll"},
+		{"reopen", "Reopen a closed poll"},
+		{"delete", "Delete an exists poll"},
+	} {
+		sub := model.NewAutocompleteData(cmd.trigger, `"poll_id"`, cmd.helpText)
+		addPollIdArgument(sub)
+		poll.AddCommand(sub)
+	}
+
+	list := model.NewAutocompleteData("list", "[--mine] [--channel] [--open | --closed] [--page N]", "List polls of the channel or your own polls")
+	addListArguments(list)
+	poll.AddCommand(list)
+	poll.AddCommand(newScheduleAutocompleteData("schedule", "Create, list, pause, resume or remove recurring polls"))
+	poll.AddCommand(model.NewAutocompleteData("help", "", "Show available commands"))
+
+	return poll
+}
+
+// newScheduleAutocompleteData формирует дерево автодополнения команды управления повторяющимися опросами
+// с триггером trigger: по одной подкоманде на каждое действие с расписаниями.
+func newScheduleAutocompleteData(trigger, helpText string) *model.AutocompleteData {
+	schedule := model.NewAutocompleteData(trigger, "[create|list|pause|resume|remove]", helpText)
+
+	create := model.NewAutocompleteData("create", `"cron" "question" "option1" "option2" ... [--max-votes N] [--public] [--ranked] [--duration 2h]`, "Create a recurring poll (schedule in UTC)")
+	create.AddTextArgument("Schedule in cron format (minute hour day month weekday)", `"0 12 * * 5"`, "")
+	create.AddTextArgument("Question of the poll", `"question"`, "")
+	create.AddTextArgument("Options of the poll", `"option1" "option2" ...`, "")
+	create.AddNamedTextArgument("max-votes", "Number of options a user can choose (0 - unlimited)", "N", `^\d+$`, false)
+	create.AddNamedStaticListArgument("public", "Show who voted for what", false, boolListItems())
+	create.AddNamedStaticListArgument("ranked", "Rank options and count with instant runoff", false, boolListItems())
+	create.AddNamedTextArgument("duration", "Close each poll automatically after a duration", "2h", "", false)
+	schedule.AddCommand(create)
+
+	schedule.AddCommand(model.NewAutocompleteData("list", "", "List recurring polls of the channel"))
+	for _, cmd := range []struct{ trigger, helpText string }{
+		{"pause", "Pause a recurring poll"},
+		{"resume", "Resume a paused recurring poll"},
+		{"remove", "Remove a recurring poll"},
+	} {
+		sub := model.NewAutocompleteData(cmd.trigger, `"schedule_id"`, cmd.helpText)
+		sub.AddTextArgument("ID of the schedule", `"schedule_id"`, "")
+		schedule.AddCommand(sub)
+	}
+
+	return schedule
+}
+
+// addListArguments добавляет команде флаги фильтрации и разбиения на страницы списка опросов.
+func addListArguments(data *model.AutocompleteData) {
+	data.AddNamedStaticListArgument("mine", "Only your own polls from all channels", false, boolListItems())
+	data.AddNamedStaticListArgument("channel", "Only polls of this channel (with --mine)", false, boolListItems())
+	data.AddNamedStaticListArgument("open", "Only open polls", false, boolListItems())
+	data.AddNamedStaticListArgument("closed", "Only closed polls", false, boolListItems())
+	data.AddNamedTextArgument("page", "Page number", "N", `^\d+$`, false)
+}
+
+// addResultsArguments добавляет команде флаги публикации и выгрузки результатов опроса.
+func addResultsArguments(data *model.AutocompleteData) {
+	data.AddNamedStaticListArgument("format", "Export results as a file", false, []model.AutocompleteListItem{
+		{Item: "csv", HelpText: "CSV file"},
+		{Item: "json", HelpText: "JSON file"},
+	})
+	data.AddNamedStaticListArgument("chart", "Post results to the channel with a chart", false, boolListItems())
+	data.AddNamedStaticListArgument("share", "Show results to everyone in the channel", false, boolListItems())
+}
+
+// boolListItems возвращает варианты значения логического флага для автодополнения.
+func boolListItems() []model.AutocompleteListItem {
+	return []model.AutocompleteListItem{
+		{Item: "true", HelpText: "Enable"},
+		{Item: "false", HelpText: "Disable"},
+	}
+}
+
+// addPollIdArgument добавляет команде аргумент с идентификатором опроса.
+// Mattermost запрашивает динамические списки подсказок только у плагинов, поэтому идентификатор вводится вручную.
+func addPollIdArgument(data *model.AutocompleteData) {
+	data.AddTextArgument("ID of the poll", `"poll_id"`, "")
+}
@@ -1,6 +1,12 @@
 package services
 
-import "github.com/mattermost/mattermost-server/v6/model"
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
 
 // BotInterface определяет интерфейс для взаимодействия с ботом Mattermost.
 type BotInterface interface {
@@ -9,4 +15,30 @@ type BotInterface interface {
 	ListCommands(teamId string, customOnly bool) ([]*model.Command, *model.Response, error)
 	CreateCommand(cmd *model.Command) (*model.Command, *model.Response, error)
 	CreatePost(post *model.Post) (*model.Post, *model.Response, error)
+	PatchPost(postId string, patch *model.PostPatch) (*model.Post, *model.Response, error)
+	OpenInteractiveDialog(request model.OpenDialogRequest) (*model.Response, error)
+	GetUsersByIds(userIds []string) ([]*model.User, *model.Response, error)
+	GetChannelMember(channelId, userId, etag string) (*model.ChannelMember, *model.Response, error)
+	GetUser(userId, etag string) (*model.User, *model.Response, error)
+	GetTeamMember(teamId, userId, etag string) (*model.TeamMember, *model.Response, error)
+	GetMe(etag string) (*model.User, *model.Response, error)
+	CreateDirectChannel(userId1, userId2 string) (*model.Channel, *model.Response, error)
+	UploadFile(data []byte, channelId string, filename string) (*model.FileUploadResponse, *model.Response, error)
+	GetUserByUsername(userName, etag string) (*model.User, *model.Response, error)
+	GetGroups(opts model.GroupSearchOpts) ([]*model.Group, *model.Response, error)
+	GetUsersInGroup(groupID string, page int, perPage int, etag string) ([]*model.User, *model.Response, error)
+	GetChannelStats(channelId string, etag string) (*model.ChannelStats, *model.Response, error)
+}
+
+// checkResponse возвращает ошибку, если ответ API Mattermost отсутствует или его код не входит в codes.
+func checkResponse(resp *model.Response, codes ...int) error {
+	if resp == nil {
+		return errors.New("empty response")
+	}
+
+	if !slices.Contains(codes, resp.StatusCode) {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
 }
@@ -0,0 +1,83 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"matterpoll-bot/internal/services"
+	"matterpoll-bot/internal/services/service_mocks"
+	"matterpoll-bot/internal/storage/store_mocks"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserLocale проверяет определение языка сообщений по локали пользователя в Mattermost.
+func TestUserLocale(t *testing.T) {
+	ok := &model.Response{StatusCode: 200}
+
+	t.Run("cached locale", func(t *testing.T) {
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, store_mocks.NewStoreInterface(t), testConfig)
+
+		mockBot.On("GetUser", "user1", "").Return(&model.User{Id: "user1", Locale: "ru-RU"}, ok, nil).Once()
+
+		require.Equal(t, "ru", pollService.UserLocale("user1"))
+		require.Equal(t, "ru", pollService.UserLocale("user1"))
+	})
+
+	t.Run("full cache", func(t *testing.T) {
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, store_mocks.NewStoreInterface(t), testConfig)
+
+		mockBot.On("GetUser", mock.Anything, "").Return(&model.User{Locale: "ru"}, ok, nil)
+
+		for i := 0; i <= services.LocaleCacheSize; i++ {
+			require.Equal(t, "ru", pollService.UserLocale(fmt.Sprintf("user%d", i)))
+		}
+		mockBot.AssertNumberOfCalls(t, "GetUser", services.LocaleCacheSize+1)
+
+		last := fmt.Sprintf("user%d", services.LocaleCacheSize)
+		require.Equal(t, "ru", pollService.UserLocale(last))
+		mockBot.AssertNumberOfCalls(t, "GetUser", services.LocaleCacheSize+1)
+
+		require.Equal(t, "ru", pollService.UserLocale("user0"))
+		mockBot.AssertNumberOfCalls(t, "GetUser", services.LocaleCacheSize+2)
+	})
+
+	t.Run("unsupported locale", func(t *testing.T) {
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, store_mocks.NewStoreInterface(t), testConfig)
+
+		mockBot.On("GetUser", "user1", "").Return(&model.User{Id: "user1", Locale: "pt-BR"}, ok, nil).Once()
+
+		require.Equal(t, "en", pollService.UserLocale("user1"))
+	})
+
+	t.Run("failed to get user", func(t *testing.T) {
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, store_mocks.NewStoreInterface(t), testConfig)
+
+		mockBot.On("GetUser", "user1", "").Return(nil, &model.Response{StatusCode: 500}, errors.New("internal error")).Twice()
+
+		require.Equal(t, "en", pollService.UserLocale("user1"))
+		require.Equal(t, "en", pollService.UserLocale("user1"))
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, store_mocks.NewStoreInterface(t), testConfig)
+
+		mockBot.On("GetUser", "user1", "").Return(nil, nil, nil).Once()
+
+		require.Equal(t, "en", pollService.UserLocale("user1"))
+	})
+
+	t.Run("empty user", func(t *testing.T) {
+		pollService := services.NewPollService(service_mocks.NewBotInterface(t), store_mocks.NewStoreInterface(t), testConfig)
+
+		require.Equal(t, "en", pollService.UserLocale(""))
+	})
+}
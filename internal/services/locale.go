@@ -0,0 +1,94 @@
+package services
+
+import (
+	"log"
+	"matterpoll-bot/internal/i18n"
+	"sync"
+	"time"
+)
+
+// localeCacheTTL - время, в течение которого язык пользователя берется из кэша без запроса к Mattermost.
+const localeCacheTTL = 10 * time.Minute
+
+// LocaleCacheSize - максимальное количество пользователей, языки которых хранятся в кэше.
+const LocaleCacheSize = 10000
+
+// cachedLocale - язык пользователя и время, до которого он считается актуальным.
+type cachedLocale struct {
+	locale    string
+	expiresAt time.Time
+}
+
+// localeCache хранит языки пользователей, полученные через API Mattermost.
+type localeCache struct {
+	mu      sync.Mutex
+	locales map[string]cachedLocale
+}
+
+// newLocaleCache возвращает пустой кэш языков пользователей.
+func newLocaleCache() *localeCache {
+	return &localeCache{locales: map[string]cachedLocale{}}
+}
+
+// get возвращает язык пользователя userId, если он есть в кэше и актуален на момент now.
+func (c *localeCache) get(userId string, now time.Time) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.locales[userId]
+	if !ok || !now.Before(cached.expiresAt) {
+		return "", false
+	}
+
+	return cached.locale, true
+}
+
+// put сохраняет язык пользователя userId на localeCacheTTL от момента now.
+// Если кэш заполнен, из него удаляются устаревшие записи, а если их нет - все записи.
+func (c *localeCache) put(userId, locale string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.locales[userId]; !ok && len(c.locales) >= LocaleCacheSize {
+		for id, cached := range c.locales {
+			if !now.Before(cached.expiresAt) {
+				delete(c.locales, id)
+			}
+		}
+		if len(c.locales) >= LocaleCacheSize {
+			clear(c.locales)
+		}
+	}
+
+	c.locales[userId] = cachedLocale{locale: locale, expiresAt: now.Add(localeCacheTTL)}
+}
+
+// UserLocale возвращает язык сообщений для пользователя userId по его локали в Mattermost.
+// Язык кэшируется на localeCacheTTL, в кэше хранится не больше LocaleCacheSize пользователей. Если пользователь не указан или его не удалось получить,
+// возвращается язык по умолчанию.
+func (ps *PollService) UserLocale(userId string) string {
+	if userId == "" {
+		return i18n.DefaultLocale
+	}
+
+	now := time.Now()
+	if locale, ok := ps.locales.get(userId, now); ok {
+		return locale
+	}
+
+	user, resp, err := ps.Bot.GetUser(userId, "")
+	if err != nil {
+		log.Printf("failed to get locale of user '%s': %v\n", userId, err)
+		return i18n.DefaultLocale
+	}
+
+	if err := checkResponse(resp, 200); err != nil {
+		log.Printf("failed to get locale of user '%s': %v\n", userId, err)
+		return i18n.DefaultLocale
+	}
+
+	locale := i18n.Language(user.Locale)
+	ps.locales.put(userId, locale, now)
+
+	return locale
+}
@@ -0,0 +1,125 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/services"
+	"matterpoll-bot/internal/services/service_mocks"
+	"matterpoll-bot/internal/storage/store_mocks"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestModeratePoll проверяет закрытие, повторное открытие и удаление чужих опросов модераторами.
+func TestModeratePoll(t *testing.T) {
+	poll := &entities.Poll{
+		PollId:    "poll1",
+		Question:  "What is your favorite color?",
+		Options:   map[string]int32{"Red": 0, "Blue": 0},
+		Voters:    map[string][]string{},
+		MaxVotes:  1,
+		Creator:   "user1",
+		ChannelId: "channel1",
+		TeamId:    "team1",
+		PostId:    "post1",
+	}
+
+	notFound := &model.Response{StatusCode: 404}
+	ok := &model.Response{StatusCode: 200}
+
+	// expectNotification ожидает личное сообщение создателю опроса на языке его локали locale.
+	expectNotification := func(mockBot *service_mocks.BotInterface, locale, msg string) {
+		mockBot.On("GetUser", "user1", "").Return(&model.User{Id: "user1", Locale: locale}, ok, nil).Once()
+		mockBot.On("GetMe", "").Return(&model.User{Id: "bot"}, ok, nil).Once()
+		mockBot.On("CreateDirectChannel", "bot", "user1").Return(&model.Channel{Id: "dm"}, &model.Response{StatusCode: 201}, nil).Once()
+		mockBot.On("CreatePost", &model.Post{ChannelId: "dm", Message: msg}).Return(&model.Post{}, &model.Response{StatusCode: 201}, nil).Once()
+	}
+
+	t.Run("system admin closes poll", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+		mockStore.On("GetPoll", "poll1").Return(poll, nil)
+		mockBot.On("GetUser", "admin", "").Return(&model.User{Id: "admin", Roles: "system_user system_admin"}, ok, nil).Once()
+		mockStore.On("ClosePoll", "poll1", "admin", true, int32(0)).Return(entities.NewMessage("poll.closed", "poll1"), nil).Once()
+		mockBot.On("PatchPost", "post1", mock.Anything).Return(&model.Post{}, ok, nil).Once()
+		mockBot.On("UploadFile", mock.Anything, "channel1", "poll_poll1_chart.svg").Return(&model.FileUploadResponse{FileInfos: []*model.FileInfo{{Id: "chart1"}}}, &model.Response{StatusCode: 201}, nil).Once()
+		mockBot.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool { return post.ChannelId == "channel1" })).Return(&model.Post{}, &model.Response{StatusCode: 201}, nil).Once()
+		expectNotification(mockBot, "en", "*Poll*: `poll1` (What is your favorite color?) **has been closed by a system admin!**")
+
+		msg, err := pollService.ClosePoll("poll1", "admin")
+		require.NoError(t, err)
+		require.Equal(t, "*Poll*: `poll1` **has been successfully closed!**", msg.String())
+	})
+
+	t.Run("team admin reopens poll", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+		mockStore.On("GetPoll", "poll1").Return(poll, nil)
+		mockBot.On("GetUser", "admin", "").Return(&model.User{Id: "admin", Roles: "system_user"}, ok, nil).Once()
+		mockBot.On("GetTeamMember", "team1", "admin", "").Return(&model.TeamMember{Roles: "team_user team_admin"}, ok, nil).Once()
+		mockStore.On("ReopenPoll", "poll1", "admin", true).Return(entities.NewMessage("poll.reopened", "poll1"), nil).Once()
+		mockBot.On("PatchPost", "post1", mock.Anything).Return(&model.Post{}, ok, nil).Once()
+		expectNotification(mockBot, "ru", "*Опрос*: `poll1` (What is your favorite color?) **открыт повторно администратором команды!**")
+
+		msg, err := pollService.ReopenPoll("poll1", "admin")
+		require.NoError(t, err)
+		require.Equal(t, "*Poll*: `poll1` **has been successfully reopened!**", msg.String())
+	})
+
+	t.Run("channel admin deletes poll", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+		mockStore.On("GetPoll", "poll1").Return(poll, nil)
+		mockBot.On("GetUser", "admin", "").Return(&model.User{Id: "admin", Roles: "system_user"}, ok, nil).Once()
+		mockBot.On("GetTeamMember", "team1", "admin", "").Return(&model.TeamMember{Roles: "team_user"}, ok, nil).Once()
+		mockBot.On("GetChannelMember", "channel1", "admin", "").Return(&model.ChannelMember{SchemeAdmin: true}, ok, nil).Once()
+		mockStore.On("DeletePoll", "poll1", "admin", true).Return(entities.NewMessage("poll.deleted", "poll1"), nil).Once()
+		mockBot.On("PatchPost", "post1", mock.Anything).Return(&model.Post{}, ok, nil).Once()
+		expectNotification(mockBot, "", "*Poll*: `poll1` (What is your favorite color?) **has been deleted by a channel admin!**")
+
+		msg, err := pollService.DeletePoll("poll1", "admin")
+		require.NoError(t, err)
+		require.Equal(t, "*Poll*: `poll1` **has been successfully deleted!**", msg.String())
+	})
+
+	t.Run("regular user", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+		mockStore.On("GetPoll", "poll1").Return(poll, nil)
+		mockBot.On("GetUser", "user2", "").Return(&model.User{Id: "user2", Roles: "system_user"}, ok, nil).Once()
+		mockBot.On("GetTeamMember", "team1", "user2", "").Return(nil, notFound, errors.New("not found")).Once()
+		mockBot.On("GetChannelMember", "channel1", "user2", "").Return(&model.ChannelMember{Roles: "channel_user"}, ok, nil).Once()
+		mockStore.On("ClosePoll", "poll1", "user2", false, int32(0)).Return(nil, entities.NewUserError("poll.close_forbidden")).Once()
+
+		msg, err := pollService.ClosePoll("poll1", "user2")
+		require.Empty(t, msg)
+		require.EqualError(t, err, "**You don't have the permission to close a vote!**")
+		mockBot.AssertNotCalled(t, "GetMe", mock.Anything)
+	})
+
+	t.Run("failed to get user", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+		mockStore.On("GetPoll", "poll1").Return(poll, nil)
+		mockBot.On("GetUser", "user2", "").Return(nil, &model.Response{StatusCode: 500}, errors.New("internal error")).Once()
+
+		msg, err := pollService.DeletePoll("poll1", "user2")
+		require.Empty(t, msg)
+		require.EqualError(t, err, "failed to get user: internal error")
+		mockStore.AssertNotCalled(t, "DeletePoll", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// Роли пользователей, которым разрешено модерировать чужие опросы.
+const (
+	systemAdminRole  = "system admin"
+	teamAdminRole    = "team admin"
+	channelAdminRole = "channel admin"
+)
+
+// roleKeys - ключи каталога сообщений с названиями ролей модераторов для уведомлений.
+var roleKeys = map[string]string{
+	systemAdminRole:  "moderation.by_system_admin",
+	teamAdminRole:    "moderation.by_team_admin",
+	channelAdminRole: "moderation.by_channel_admin",
+}
+
+// actorRole возвращает роль модератора, с которой пользователь userId действует над опросом poll.
+// Для создателя опроса и пользователей без прав модератора возвращается пустая строка:
+// права таких пользователей проверяет хранилище.
+func (ps *PollService) actorRole(poll *entities.Poll, userId string) (string, error) {
+	if poll.Creator == userId {
+		return "", nil
+	}
+
+	return ps.moderatorRole(poll, userId)
+}
+
+// moderatorRole определяет через API Mattermost, может ли пользователь userId модерировать опрос poll:
+// администраторы системы модерируют любые опросы, администраторы команды - опросы своей команды,
+// а администраторы канала - опросы своего канала. Возвращает пустую строку, если прав модератора нет.
+func (ps *PollService) moderatorRole(poll *entities.Poll, userId string) (string, error) {
+	user, resp, err := ps.Bot.GetUser(userId, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := checkResponse(resp, 200); err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.IsSystemAdmin() {
+		return systemAdminRole, nil
+	}
+
+	if poll.TeamId != "" {
+		isAdmin, err := ps.isTeamAdmin(poll.TeamId, userId)
+		if err != nil {
+			return "", err
+		}
+
+		if isAdmin {
+			return teamAdminRole, nil
+		}
+	}
+
+	if poll.ChannelId != "" {
+		isAdmin, err := ps.isChannelAdmin(poll.ChannelId, userId)
+		if err != nil {
+			return "", err
+		}
+
+		if isAdmin {
+			return channelAdminRole, nil
+		}
+	}
+
+	return "", nil
+}
+
+// isTeamAdmin проверяет, является ли пользователь userId администратором команды teamId.
+// Пользователь, не состоящий в команде, администратором не считается.
+func (ps *PollService) isTeamAdmin(teamId, userId string) (bool, error) {
+	member, resp, err := ps.Bot.GetTeamMember(teamId, userId, "")
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to get team member: %w", err)
+	}
+
+	if err := checkResponse(resp, 200); err != nil {
+		return false, fmt.Errorf("failed to get team member: %w", err)
+	}
+
+	return member.SchemeAdmin || model.IsInRole(member.Roles, model.TeamAdminRoleId), nil
+}
+
+// isChannelAdmin проверяет, является ли пользователь userId администратором канала channelId.
+// Пользователь, не состоящий в канале, администратором не считается.
+func (ps *PollService) isChannelAdmin(channelId, userId string) (bool, error) {
+	member, resp, err := ps.Bot.GetChannelMember(channelId, userId, "")
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to get channel member: %w", err)
+	}
+
+	if err := checkResponse(resp, 200); err != nil {
+		return false, fmt.Errorf("failed to get channel member: %w", err)
+	}
+
+	return member.SchemeAdmin || model.IsInRole(member.Roles, model.ChannelAdminRoleId), nil
+}
+
+// recordModeration записывает в журнал действие action, выполненное модератором userId с ролью role
+// над опросом poll, и уведомляет создателя опроса личным сообщением на его языке.
+// action - "closed", "reopened" или "deleted". Для действий создателя (пустая роль) ничего не выполняется.
+// Ошибки уведомления только логируются.
+func (ps *PollService) recordModeration(poll *entities.Poll, userId, role, action string) {
+	if role == "" {
+		return
+	}
+
+	log.Printf("Poll '%s' of user '%s' has been %s by %s '%s'\n", poll.PollId, poll.Creator, action, role, userId)
+
+	locale := ps.UserLocale(poll.Creator)
+	msg := i18n.T(locale, "moderation."+action, poll.PollId, poll.Question, i18n.T(locale, roleKeys[role]))
+	if err := ps.notifyUser(poll.Creator, msg); err != nil {
+		log.Printf("failed to notify creator of poll '%s': %v\n", poll.PollId, err)
+	}
+}
+
+// notifyUser отправляет пользователю userId личное сообщение от имени бота.
+func (ps *PollService) notifyUser(userId, message string) error {
+	bot, resp, err := ps.Bot.GetMe("")
+	if err != nil {
+		return fmt.Errorf("failed to get bot user: %w", err)
+	}
+
+	if err := checkResponse(resp, 200); err != nil {
+		return fmt.Errorf("failed to get bot user: %w", err)
+	}
+
+	channel, resp, err := ps.Bot.CreateDirectChannel(bot.Id, userId)
+	if err != nil {
+		return fmt.Errorf("failed to create direct channel: %w", err)
+	}
+
+	if err := checkResponse(resp, 200, 201); err != nil {
+		return fmt.Errorf("failed to create direct channel: %w", err)
+	}
+
+	_, resp, err = ps.Bot.CreatePost(&model.Post{ChannelId: channel.Id, Message: message})
+	if err != nil {
+		return fmt.Errorf("failed to create post: %w", err)
+	}
+
+	if err := checkResponse(resp, 201); err != nil {
+		return fmt.Errorf("failed to create post: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/services"
+	"matterpoll-bot/internal/services/service_mocks"
+	"matterpoll-bot/internal/storage/store_mocks"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpenCreatePollDialog проверяет открытие диалога создания опроса.
+func TestOpenCreatePollDialog(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	t.Run("success opened dialog", func(t *testing.T) {
+		mockBot.On("OpenInteractiveDialog", mock.Anything).Return(&model.Response{StatusCode: 200}, nil)
+
+		err := pollService.OpenCreatePollDialog("trigger1", "user1", "en")
+		require.NoError(t, err)
+		mockBot.AssertCalled(t, "OpenInteractiveDialog", mock.MatchedBy(func(req model.OpenDialogRequest) bool {
+			return req.TriggerId == "trigger1" &&
+				req.URL == "http://localhost:8080"+entities.DialogPath &&
+				req.Dialog.CallbackId == services.CreatePollDialogId &&
+				pollService.VerifyAction(services.DialogDomain, "user1", req.Dialog.State)
+		}))
+	})
+
+	t.Run("dialog in user locale", func(t *testing.T) {
+		mockBot.ExpectedCalls = nil
+		mockBot.On("OpenInteractiveDialog", mock.Anything).Return(&model.Response{StatusCode: 200}, nil)
+
+		err := pollService.OpenCreatePollDialog("trigger1", "user1", "ru")
+		require.NoError(t, err)
+		mockBot.AssertCalled(t, "OpenInteractiveDialog", mock.MatchedBy(func(req model.OpenDialogRequest) bool {
+			return req.Dialog.Title == "Создание опроса" && req.Dialog.Elements[0].DisplayName == "Вопрос" &&
+				req.Dialog.Elements[2].Options[1].Text == "До 2 вариантов"
+		}))
+	})
+
+	t.Run("failed to open dialog", func(t *testing.T) {
+		testErr := errors.New("error text")
+
+		mockBot.ExpectedCalls = nil
+		mockBot.On("OpenInteractiveDialog", mock.Anything).Return(&model.Response{StatusCode: 400}, testErr)
+
+		err := pollService.OpenCreatePollDialog("trigger1", "user1", "en")
+		require.Error(t, err)
+		require.Equal(t, "failed to open dialog: error text", err.Error())
+
+		mockBot.ExpectedCalls = nil
+		mockBot.On("OpenInteractiveDialog", mock.Anything).Return(&model.Response{StatusCode: 400}, nil)
+
+		err = pollService.OpenCreatePollDialog("trigger1", "user1", "en")
+		require.Error(t, err)
+		require.Equal(t, "failed to open dialog: unexpected status code 400", err.Error())
+	})
+}
+
+// TestOpenRankPollDialog проверяет открытие диалога ранжирования вариантов рейтингового опроса.
+func TestOpenRankPollDialog(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Options:  map[string]int32{"Red": 0, "Blue": 0, "Green": 0},
+		Voters:   map[string][]string{},
+		MaxVotes: 1,
+		Ranked:   true,
+	}
+
+	t.Run("success opened dialog", func(t *testing.T) {
+		mockStore.On("GetPoll", "poll1").Return(poll, nil).Once()
+		mockBot.On("OpenInteractiveDialog", mock.Anything).Return(&model.Response{StatusCode: 200}, nil).Once()
+
+		err := pollService.OpenRankPollDialog("trigger1", "user1", "poll1", "en")
+		require.NoError(t, err)
+		mockBot.AssertCalled(t, "OpenInteractiveDialog", mock.MatchedBy(func(req model.OpenDialogRequest) bool {
+			elements := req.Dialog.Elements
+			return req.Dialog.CallbackId == services.RankPollDialogId+":poll1" &&
+				pollService.VerifyAction(services.DialogDomain, "user1", req.Dialog.State) &&
+				len(elements) == 3 && !elements[0].Optional && elements[2].Optional &&
+				elements[0].Name == "choice_1" && elements[0].Options[0].Value == "Blue"
+		}))
+	})
+
+	t.Run("dialog in user locale", func(t *testing.T) {
+		mockStore.On("GetPoll", "poll1").Return(poll, nil).Once()
+		mockBot.ExpectedCalls = nil
+		mockBot.On("OpenInteractiveDialog", mock.Anything).Return(&model.Response{StatusCode: 200}, nil).Once()
+
+		err := pollService.OpenRankPollDialog("trigger1", "user1", "poll1", "ru")
+		require.NoError(t, err)
+		mockBot.AssertCalled(t, "OpenInteractiveDialog", mock.MatchedBy(func(req model.OpenDialogRequest) bool {
+			return req.Dialog.Title == "Ранжирование вариантов" && req.Dialog.SubmitLabel == "Проголосовать" &&
+				req.Dialog.Elements[1].DisplayName == "Место №2"
+		}))
+	})
+
+	t.Run("not ranked poll", func(t *testing.T) {
+		notRanked := *poll
+		notRanked.Ranked = false
+		mockStore.On("GetPoll", "poll1").Return(&notRanked, nil).Once()
+
+		err := pollService.OpenRankPollDialog("trigger1", "user1", "poll1", "en")
+		require.Error(t, err)
+		require.Equal(t, "**This poll is not ranked, choose one option!**", err.Error())
+	})
+
+	t.Run("closed poll", func(t *testing.T) {
+		closed := *poll
+		closed.Closed = true
+		mockStore.On("GetPoll", "poll1").Return(&closed, nil).Once()
+
+		err := pollService.OpenRankPollDialog("trigger1", "user1", "poll1", "en")
+		require.Error(t, err)
+		require.Equal(t, "*Poll*: `poll1` **is already closed!**", err.Error())
+	})
+}
+
+// TestOpenAddOptionDialog проверяет открытие диалога добавления варианта в опрос.
+func TestOpenAddOptionDialog(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	poll := &entities.Poll{
+		PollId:      "poll1",
+		Question:    "What is your favorite color?",
+		Options:     map[string]int32{"Red": 0, "Blue": 0},
+		Voters:      map[string][]string{},
+		MaxVotes:    1,
+		OpenOptions: true,
+	}
+
+	t.Run("success opened dialog", func(t *testing.T) {
+		mockStore.On("GetPoll", "poll1").Return(poll, nil).Once()
+		mockBot.On("OpenInteractiveDialog", mock.Anything).Return(&model.Response{StatusCode: 200}, nil).Once()
+
+		err := pollService.OpenAddOptionDialog("trigger1", "user1", "poll1", "en")
+		require.NoError(t, err)
+		mockBot.AssertCalled(t, "OpenInteractiveDialog", mock.MatchedBy(func(req model.OpenDialogRequest) bool {
+			elements := req.Dialog.Elements
+			return req.Dialog.CallbackId == services.AddOptionDialogId+":poll1" &&
+				pollService.VerifyAction(services.DialogDomain, "user1", req.Dialog.State) &&
+				len(elements) == 1 && elements[0].Name == "option" && elements[0].HelpText == poll.Question
+		}))
+	})
+
+	t.Run("fixed options", func(t *testing.T) {
+		fixed := *poll
+		fixed.OpenOptions = false
+		mockStore.On("GetPoll", "poll1").Return(&fixed, nil).Once()
+
+		err := pollService.OpenAddOptionDialog("trigger1", "user1", "poll1", "en")
+		require.Error(t, err)
+		require.Equal(t, "**This poll doesn't allow adding options!**", err.Error())
+	})
+}
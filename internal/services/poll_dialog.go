@@ -0,0 +1,246 @@
+package services
+
+import (
+	"fmt"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
+	"matterpoll-bot/internal/storage"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+const (
+	// CreatePollDialogId - идентификатор диалога создания опроса, возвращаемый Mattermost при его отправке.
+	CreatePollDialogId = "create_poll"
+	// RankPollDialogId - префикс идентификатора диалога ранжирования вариантов рейтингового опроса.
+	// Полный идентификатор имеет вид `rank_poll:Poll_ID`.
+	RankPollDialogId = "rank_poll"
+	// AddOptionDialogId - префикс идентификатора диалога добавления варианта в опрос с открытыми вариантами.
+	// Полный идентификатор имеет вид `add_option:Poll_ID`.
+	AddOptionDialogId = "add_option"
+)
+
+// NewCreatePollDialog формирует интерактивный диалог для создания опроса пользователем userId на языке locale.
+// В поле State диалога передается подпись идентификатора пользователя для проверки отправки.
+func (ps *PollService) NewCreatePollDialog(userId, locale string) model.Dialog {
+	return model.Dialog{
+		CallbackId:  CreatePollDialogId,
+		Title:       i18n.T(locale, "dialog.create_title"),
+		SubmitLabel: i18n.T(locale, "dialog.create_submit"),
+		State:       ps.SignAction(DialogDomain, userId),
+		Elements: []model.DialogElement{
+			{
+				DisplayName: i18n.T(locale, "dialog.question"),
+				Name:        "question",
+				Type:        "text",
+				MaxLength:   300,
+			},
+			{
+				DisplayName: i18n.T(locale, "dialog.options"),
+				Name:        "options",
+				Type:        "textarea",
+				HelpText:    i18n.T(locale, "dialog.options_help"),
+				MaxLength:   3000,
+			},
+			{
+				DisplayName: i18n.T(locale, "dialog.max_votes"),
+				Name:        "max_votes",
+				Type:        "select",
+				Default:     "1",
+				Options: []*model.PostActionOptions{
+					{Text: i18n.T(locale, "dialog.max_votes_single"), Value: "1"},
+					{Text: i18n.T(locale, "dialog.max_votes_up_to", 2), Value: "2"},
+					{Text: i18n.T(locale, "dialog.max_votes_up_to", 3), Value: "3"},
+					{Text: i18n.T(locale, "dialog.max_votes_up_to", 5), Value: "5"},
+					{Text: i18n.T(locale, "dialog.max_votes_unlimited"), Value: "0"},
+				},
+			},
+			{
+				DisplayName: i18n.T(locale, "dialog.public"),
+				Name:        "public",
+				Type:        "bool",
+				Placeholder: i18n.T(locale, "dialog.public_help"),
+				Optional:    true,
+			},
+			{
+				DisplayName: i18n.T(locale, "dialog.ranked"),
+				Name:        "ranked",
+				Type:        "bool",
+				Placeholder: i18n.T(locale, "dialog.ranked_help"),
+				Optional:    true,
+			},
+			{
+				DisplayName: i18n.T(locale, "dialog.open_options"),
+				Name:        "open_options",
+				Type:        "bool",
+				Placeholder: i18n.T(locale, "dialog.open_options_help"),
+				Optional:    true,
+			},
+			{
+				DisplayName: i18n.T(locale, "dialog.hide_results"),
+				Name:        "hide_results",
+				Type:        "bool",
+				Placeholder: i18n.T(locale, "dialog.hide_results_help"),
+				Optional:    true,
+			},
+			{
+				DisplayName: i18n.T(locale, "dialog.weights"),
+				Name:        "weights",
+				Type:        "text",
+				HelpText:    i18n.T(locale, "dialog.weights_help"),
+				Optional:    true,
+			},
+			{
+				DisplayName: i18n.T(locale, "dialog.quorum"),
+				Name:        "quorum",
+				Type:        "text",
+				HelpText:    i18n.T(locale, "dialog.quorum_help"),
+				Optional:    true,
+			},
+			{
+				DisplayName: i18n.T(locale, "dialog.threshold"),
+				Name:        "threshold",
+				Type:        "text",
+				HelpText:    i18n.T(locale, "dialog.threshold_help"),
+				Optional:    true,
+			},
+			{
+				DisplayName: i18n.T(locale, "dialog.ends"),
+				Name:        "ends",
+				Type:        "text",
+				HelpText:    i18n.T(locale, "dialog.ends_help"),
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// OpenCreatePollDialog открывает диалог создания опроса для пользователя userId на языке locale.
+// triggerId передается Mattermost вместе с командой и действителен ограниченное время.
+func (ps *PollService) OpenCreatePollDialog(triggerId, userId, locale string) error {
+	resp, err := ps.Bot.OpenInteractiveDialog(model.OpenDialogRequest{
+		TriggerId: triggerId,
+		URL:       ps.botURL(entities.DialogPath),
+		Dialog:    ps.NewCreatePollDialog(userId, locale),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open dialog: %w", err)
+	}
+
+	if err := checkResponse(resp, 200); err != nil {
+		return fmt.Errorf("failed to open dialog: %w", err)
+	}
+
+	return nil
+}
+
+// NewRankPollDialog формирует диалог ранжирования вариантов рейтингового опроса poll для пользователя userId
+// на языке locale. Диалог содержит по одному полю выбора на каждое место в бюллетене, обязательно только первое из них.
+func (ps *PollService) NewRankPollDialog(poll *entities.Poll, userId, locale string) model.Dialog {
+	options := storage.SortedOptions(poll)
+	choices := make([]*model.PostActionOptions, 0, len(options))
+	for _, option := range options {
+		choices = append(choices, &model.PostActionOptions{Text: option, Value: option})
+	}
+
+	elements := make([]model.DialogElement, 0, len(options))
+	for i := range options {
+		elements = append(elements, model.DialogElement{
+			DisplayName: i18n.T(locale, "dialog.choice", i+1),
+			Name:        fmt.Sprintf("choice_%d", i+1),
+			Type:        "select",
+			Options:     choices,
+			Optional:    i > 0,
+		})
+	}
+
+	return model.Dialog{
+		CallbackId:  RankPollDialogId + ":" + poll.PollId,
+		Title:       i18n.T(locale, "dialog.rank_title"),
+		SubmitLabel: i18n.T(locale, "dialog.rank_submit"),
+		State:       ps.SignAction(DialogDomain, userId),
+		Elements:    elements,
+	}
+}
+
+// OpenRankPollDialog открывает пользователю userId диалог ранжирования вариантов рейтингового опроса pollId
+// на языке locale.
+func (ps *PollService) OpenRankPollDialog(triggerId, userId, pollId, locale string) error {
+	poll, err := ps.store.GetPoll(pollId)
+	if err != nil {
+		return err
+	}
+
+	if !poll.Ranked {
+		return entities.NewUserError("vote.not_ranked")
+	}
+
+	if poll.Closed {
+		return entities.NewUserError("poll.already_closed", pollId)
+	}
+
+	resp, err := ps.Bot.OpenInteractiveDialog(model.OpenDialogRequest{
+		TriggerId: triggerId,
+		URL:       ps.botURL(entities.DialogPath),
+		Dialog:    ps.NewRankPollDialog(poll, userId, locale),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open dialog: %w", err)
+	}
+
+	if err := checkResponse(resp, 200); err != nil {
+		return fmt.Errorf("failed to open dialog: %w", err)
+	}
+
+	return nil
+}
+
+// NewAddOptionDialog формирует диалог добавления варианта в опрос poll для пользователя userId на языке locale.
+func (ps *PollService) NewAddOptionDialog(poll *entities.Poll, userId, locale string) model.Dialog {
+	return model.Dialog{
+		CallbackId:  AddOptionDialogId + ":" + poll.PollId,
+		Title:       i18n.T(locale, "dialog.add_option_title"),
+		SubmitLabel: i18n.T(locale, "dialog.add_option_submit"),
+		State:       ps.SignAction(DialogDomain, userId),
+		Elements: []model.DialogElement{
+			{
+				DisplayName: i18n.T(locale, "dialog.option"),
+				Name:        "option",
+				Type:        "text",
+				HelpText:    poll.Question,
+				MaxLength:   storage.MaxOptionLength,
+			},
+		},
+	}
+}
+
+// OpenAddOptionDialog открывает пользователю userId диалог добавления варианта в опрос pollId на языке locale.
+func (ps *PollService) OpenAddOptionDialog(triggerId, userId, pollId, locale string) error {
+	poll, err := ps.store.GetPoll(pollId)
+	if err != nil {
+		return err
+	}
+
+	if !poll.OpenOptions {
+		return entities.NewUserError("option.not_allowed")
+	}
+
+	if poll.Closed {
+		return entities.NewUserError("poll.already_closed", pollId)
+	}
+
+	resp, err := ps.Bot.OpenInteractiveDialog(model.OpenDialogRequest{
+		TriggerId: triggerId,
+		URL:       ps.botURL(entities.DialogPath),
+		Dialog:    ps.NewAddOptionDialog(poll, userId, locale),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open dialog: %w", err)
+	}
+
+	if err := checkResponse(resp, 200); err != nil {
+		return fmt.Errorf("failed to open dialog: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/services"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewPollPost проверяет формирование сообщения с опросом.
+func TestNewPollPost(t *testing.T) {
+	pollService := services.NewPollService(nil, nil, testConfig)
+
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Question: "What is your favorite color?",
+		Options:  map[string]int32{"Red": 1, "Blue": 0},
+		Voters:   map[string][]string{"user2": {"Red"}},
+		MaxVotes: 1,
+		Creator:  "user1",
+	}
+
+	t.Run("open poll", func(t *testing.T) {
+		post := pollService.NewPollPost(poll, "channel1", nil)
+		require.Equal(t, "channel1", post.ChannelId)
+		require.Contains(t, post.Message, "`poll1`")
+		require.Contains(t, post.Message, "| `Red` | `1` | `100.0％` |")
+
+		attachments := post.Attachments()
+		require.Len(t, attachments, 1)
+		require.Len(t, attachments[0].Actions, 3)
+
+		for i, option := range []string{"Blue", "Red"} {
+			action := attachments[0].Actions[i]
+			require.Equal(t, model.PostActionTypeButton, action.Type)
+			require.Equal(t, option, action.Name)
+			require.Equal(t, "http://localhost:8080"+entities.ActionPath, action.Integration.URL)
+			require.Equal(t, "vote", action.Integration.Context["action"])
+			require.Equal(t, option, action.Integration.Context["option"])
+			require.True(t, pollService.VerifyAction(services.ActionDomain, poll.PollId, action.Integration.Context["token"].(string)))
+		}
+
+		retract := attachments[0].Actions[2]
+		require.Equal(t, "Retract vote", retract.Name)
+		require.Equal(t, "retract", retract.Integration.Context["action"])
+		require.True(t, pollService.VerifyAction(services.ActionDomain, poll.PollId, retract.Integration.Context["token"].(string)))
+	})
+
+	t.Run("closed poll", func(t *testing.T) {
+		closedPoll := *poll
+		closedPoll.Closed = true
+
+		post := pollService.NewPollPost(&closedPoll, "channel1", nil)
+		require.Contains(t, post.Message, "(Completed)")
+		require.Empty(t, post.Attachments())
+	})
+
+	t.Run("public poll", func(t *testing.T) {
+		publicPoll := *poll
+		publicPoll.Public = true
+
+		post := pollService.NewPollPost(&publicPoll, "channel1", map[string]string{"user2": "alice"})
+		require.Contains(t, post.Message, "| `Red` | `1` | `100.0％` | @alice |")
+		require.Contains(t, post.Attachments()[0].Text, "*This poll is public")
+	})
+
+	t.Run("ranked poll", func(t *testing.T) {
+		rankedPoll := *poll
+		rankedPoll.Ranked = true
+
+		post := pollService.NewPollPost(&rankedPoll, "channel1", nil)
+		attachments := post.Attachments()
+		require.Len(t, attachments[0].Actions, 2)
+		require.Equal(t, "rank", attachments[0].Actions[0].Integration.Context["action"])
+		require.Equal(t, "retract", attachments[0].Actions[1].Integration.Context["action"])
+		require.Contains(t, attachments[0].Text, "*Ranked-choice poll")
+	})
+
+	t.Run("hidden results poll", func(t *testing.T) {
+		hiddenPoll := *poll
+		hiddenPoll.HideResults = true
+
+		post := pollService.NewPollPost(&hiddenPoll, "channel1", nil)
+		require.NotContains(t, post.Message, "100.0％")
+		require.Contains(t, post.Message, "| `Red` |\n")
+		require.Contains(t, post.Message, "| *Voters*: `1` |")
+		require.Contains(t, post.Attachments()[0].Text, "*Results are hidden until the poll is closed.*")
+
+		hiddenPoll.Closed = true
+		post = pollService.NewPollPost(&hiddenPoll, "channel1", nil)
+		require.Contains(t, post.Message, "| `Red` | `1` | `100.0％` |")
+	})
+
+	t.Run("open options poll", func(t *testing.T) {
+		openPoll := *poll
+		openPoll.OpenOptions = true
+
+		post := pollService.NewPollPost(&openPoll, "channel1", nil)
+		attachments := post.Attachments()
+		require.Len(t, attachments[0].Actions, 4)
+		require.Equal(t, "Add option", attachments[0].Actions[2].Name)
+		require.Equal(t, "add_option", attachments[0].Actions[2].Integration.Context["action"])
+		require.Equal(t, "retract", attachments[0].Actions[3].Integration.Context["action"])
+		require.Contains(t, attachments[0].Text, "*Participants can add their own options.*")
+	})
+}
+
+// TestNewDeletedPollPostPatch проверяет формирование изменений для сообщения с удаленным опросом.
+func TestNewDeletedPollPostPatch(t *testing.T) {
+	patch := services.NewDeletedPollPostPatch("poll1")
+	require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been deleted!**", "poll1"), *patch.Message)
+	require.NotNil(t, patch.Props)
+	require.Empty(t, *patch.Props)
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
+	"matterpoll-bot/internal/storage"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// NewPollPost формирует сообщение с опросом для канала channelId.
+// Сообщение содержит таблицу с текущими результатами опроса, а пока опрос открыт,
+// к нему прикрепляются кнопки для голосования, по одной на каждый вариант ответа, и кнопка отзыва голоса.
+// Для рейтингового опроса вместо кнопок вариантов прикрепляется кнопка, открывающая диалог ранжирования,
+// а для опроса с открытыми вариантами - кнопка, открывающая диалог добавления варианта.
+// voterNames используется для отображения проголосовавших в публичном опросе.
+// Если результаты опроса скрыты до его закрытия, таблица содержит только варианты и число проголосовавших,
+// а под таблицей закрытого опроса с кворумом или порогом выводится итог решения.
+// Сообщение видят все участники канала, поэтому оно выводится на языке по умолчанию.
+func (ps *PollService) NewPollPost(poll *entities.Poll, channelId string, voterNames map[string]string) *model.Post {
+	table := storage.PrintTable(poll, voterNames, i18n.DefaultLocale)
+	if resultsHidden(poll, "") {
+		table = storage.PrintHiddenTable(poll, i18n.DefaultLocale)
+	}
+	if decision := storage.PrintDecision(poll, i18n.DefaultLocale); decision != "" {
+		table += "\n\n" + decision
+	}
+
+	post := &model.Post{
+		ChannelId: channelId,
+		Message:   i18n.T(i18n.DefaultLocale, "post.poll", poll.PollId, table),
+		Props:     model.StringInterface{},
+	}
+
+	if poll.Closed {
+		return post
+	}
+
+	var actions []*model.PostAction
+	if poll.Ranked {
+		actions = append(actions, ps.newPostAction(i18n.T(i18n.DefaultLocale, "post.rank"), map[string]interface{}{
+			"action":  "rank",
+			"poll_id": poll.PollId,
+		}))
+	} else {
+		for _, option := range storage.SortedOptions(poll) {
+			actions = append(actions, ps.newPostAction(option, map[string]interface{}{
+				"action":  "vote",
+				"poll_id": poll.PollId,
+				"option":  option,
+			}))
+		}
+	}
+
+	var notes []string
+	switch {
+	case poll.Ranked:
+		notes = append(notes, i18n.T(i18n.DefaultLocale, "post.ranked_note"))
+	case poll.MaxVotes == 0:
+		notes = append(notes, i18n.T(i18n.DefaultLocale, "post.any_number_note"))
+	case poll.MaxVotes > 1:
+		notes = append(notes, i18n.T(i18n.DefaultLocale, "post.up_to_note", poll.MaxVotes))
+	}
+	if poll.Public {
+		notes = append(notes, i18n.T(i18n.DefaultLocale, "post.public_note"))
+	}
+	if rules := storage.PrintDecisionRules(poll, i18n.DefaultLocale); rules != "" {
+		notes = append(notes, rules)
+	}
+	if poll.HideResults {
+		notes = append(notes, i18n.T(i18n.DefaultLocale, "results.hidden_note"))
+	}
+	if poll.OpenOptions {
+		notes = append(notes, i18n.T(i18n.DefaultLocale, "post.open_options_note"))
+		actions = append(actions, ps.newPostAction(i18n.T(i18n.DefaultLocale, "post.add_option"), map[string]interface{}{
+			"action":  "add_option",
+			"poll_id": poll.PollId,
+		}))
+	}
+	if poll.EndsAt > 0 {
+		notes = append(notes, i18n.T(i18n.DefaultLocale, "post.closes_at_note", time.Unix(poll.EndsAt, 0).UTC().Format("2006-01-02 15:04 MST")))
+	}
+	text := strings.Join(notes, "\n")
+
+	actions = append(actions, ps.newPostAction(i18n.T(i18n.DefaultLocale, "post.retract"), map[string]interface{}{
+		"action":  "retract",
+		"poll_id": poll.PollId,
+	}))
+
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{{Text: text, Actions: actions}})
+
+	return post
+}
+
+// NewPollPostPatch формирует изменения для сообщения с опросом в соответствии с его текущим состоянием.
+func (ps *PollService) NewPollPostPatch(poll *entities.Poll, voterNames map[string]string) *model.PostPatch {
+	post := ps.NewPollPost(poll, "", voterNames)
+
+	return &model.PostPatch{Message: &post.Message, Props: &post.Props}
+}
+
+// NewDeletedPollPostPatch формирует изменения для сообщения с удаленным опросом на языке по умолчанию.
+func NewDeletedPollPostPatch(pollId string) *model.PostPatch {
+	message := i18n.T(i18n.DefaultLocale, "post.deleted", pollId)
+	props := model.StringInterface{}
+
+	return &model.PostPatch{Message: &message, Props: &props}
+}
+
+// newPostAction создает кнопку, нажатие на которую обрабатывается ботом по пути entities.ActionPath.
+// В контекст кнопки добавляется подпись идентификатора опроса для проверки запроса.
+func (ps *PollService) newPostAction(name string, context map[string]interface{}) *model.PostAction {
+	if pollId, ok := context["poll_id"].(string); ok {
+		context["token"] = ps.SignAction(ActionDomain, pollId)
+	}
+
+	return &model.PostAction{
+		Type: model.PostActionTypeButton,
+		Name: name,
+		Integration: &model.PostActionIntegration{
+			URL:     ps.botURL(entities.ActionPath),
+			Context: context,
+		},
+	}
+}
+
+// botURL возвращает полный адрес обработчика бота для указанного пути.
+func (ps *PollService) botURL(path string) string {
+	return fmt.Sprintf("http://%s%s%s", ps.cfg.BotHostname, ps.cfg.BotSocket, path)
+}
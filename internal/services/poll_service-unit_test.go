@@ -3,6 +3,7 @@ package services_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"matterpoll-bot/config"
@@ -16,10 +17,19 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// testConfig - конфигурация бота, с которой тесты создают сервис голосований.
+var testConfig = &config.Config{
+	Mode:        config.ModeDatabase,
+	BotToken:    "bot_token",
+	BotHostname: "localhost",
+	BotSocket:   ":8080",
+	TeamName:    "test_team",
+}
+
 // TestCreatePoll проверяет функциональность создания опроса.
 func TestCreatePoll(t *testing.T) {
 	mockStore := store_mocks.NewStoreInterface(t)
-	pollService := services.NewPollService(nil, mockStore)
+	pollService := services.NewPollService(nil, mockStore, testConfig)
 
 	poll := &entities.Poll{
 		PollId:   "poll1",
@@ -46,30 +56,66 @@ func TestCreatePoll(t *testing.T) {
 		require.Equal(t, "failed to create poll", err.Error())
 		mockStore.AssertCalled(t, "CreatePoll", poll)
 	})
+	t.Run("invalid poll settings", func(t *testing.T) {
+		mockStore.ExpectedCalls = nil
+		invalidPoll := *poll
+		invalidPoll.MaxVotes = 3
+
+		err := pollService.CreatePoll(&invalidPoll)
+		require.Error(t, err)
+		require.IsType(t, &entities.UserError{}, err)
+		mockStore.AssertNotCalled(t, "CreatePoll", &invalidPoll)
+	})
 }
 
 // TestVote проверяет функциональность голосования в опросе.
 func TestVote(t *testing.T) {
 	mockStore := store_mocks.NewStoreInterface(t)
-	pollService := services.NewPollService(nil, mockStore)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
 
 	voice := &entities.Voice{
 		PollId: "poll1",
 		UserId: "user1",
 		Option: "Red",
 	}
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Question: "What is your favorite color?",
+		Options:  map[string]int32{"Red": 1, "Blue": 0},
+		Voters:   map[string][]string{"user1": {"Red"}},
+		MaxVotes: 1,
+		Creator:  "user1",
+		PostId:   "post1",
+	}
 
 	t.Run("success Vote", func(t *testing.T) {
-		mockStore.On("Vote", mock.Anything).Return("**Voice recorded!**", nil)
+		mockStore.On("Vote", mock.Anything).Return(entities.NewMessage("vote.recorded"), nil)
+		mockStore.On("GetPoll", voice.PollId).Return(poll, nil)
+		mockBot.On("PatchPost", poll.PostId, mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 200}, nil)
+
+		msg, err := pollService.Vote(voice)
+		require.NoError(t, err)
+		require.Equal(t, "**Voice recorded!**", msg.String())
+		mockBot.AssertCalled(t, "PatchPost", poll.PostId, mock.MatchedBy(func(patch *model.PostPatch) bool {
+			return strings.Contains(*patch.Message, "| `Red` | `1` | `100.0％` |")
+		}))
+	})
+
+	t.Run("failed to update post", func(t *testing.T) {
+		// Ошибка обновления сообщения не должна влиять на результат голосования
+		mockBot.ExpectedCalls = nil
+		mockBot.On("PatchPost", poll.PostId, mock.Anything).Return(nil, &model.Response{StatusCode: 500}, errors.New("failed to patch post"))
 
 		msg, err := pollService.Vote(voice)
 		require.NoError(t, err)
-		require.Equal(t, "**Voice recorded!**", msg)
+		require.Equal(t, "**Voice recorded!**", msg.String())
 	})
 
 	t.Run("failed Vote", func(t *testing.T) {
 		mockStore.ExpectedCalls = nil
-		mockStore.On("Vote", mock.Anything).Return("", errors.New("**Invalid Poll_ID or not exists!**"))
+		mockStore.On("GetPoll", voice.PollId).Return(poll, nil)
+		mockStore.On("Vote", mock.Anything).Return(nil, errors.New("**Invalid Poll_ID or not exists!**"))
 
 		msg, err := pollService.Vote(voice)
 		require.Empty(t, msg)
@@ -79,90 +125,637 @@ func TestVote(t *testing.T) {
 	})
 }
 
+// TestAddOption проверяет добавление варианта в опрос с открытыми вариантами.
+func TestAddOption(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	poll := &entities.Poll{
+		PollId:      "poll1",
+		Question:    "What is your favorite color?",
+		Options:     map[string]int32{"Red": 0, "Blue": 0, "Green": 0},
+		Voters:      map[string][]string{},
+		MaxVotes:    1,
+		Creator:     "user1",
+		PostId:      "post1",
+		OpenOptions: true,
+	}
+
+	t.Run("success AddOption", func(t *testing.T) {
+		mockStore.On("GetPoll", "poll1").Return(poll, nil).Twice()
+		mockStore.On("AddOption", "poll1", "Green").Return(entities.NewMessage("option.added"), nil).Once()
+		mockBot.On("PatchPost", "post1", mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 200}, nil).Once()
+
+		msg, err := pollService.AddOption("poll1", "user2", "Green")
+		require.NoError(t, err)
+		require.Equal(t, "**Option added!**", msg.String())
+		mockBot.AssertCalled(t, "PatchPost", "post1", mock.MatchedBy(func(patch *model.PostPatch) bool {
+			return strings.Contains(*patch.Message, "| `Green` | `0` |")
+		}))
+	})
+
+	t.Run("failed AddOption", func(t *testing.T) {
+		mockStore.On("GetPoll", "poll1").Return(poll, nil).Once()
+		mockStore.On("AddOption", "poll1", "Red").Return(nil, entities.NewUserError("option.exists", "Red")).Once()
+
+		msg, err := pollService.AddOption("poll1", "user2", "Red")
+		require.Empty(t, msg)
+		require.EqualError(t, err, "**Option `Red` already exists!**")
+	})
+}
+
+// TestVoteChannelMembership проверяет, что голосовать могут только участники канала опроса.
+func TestVoteChannelMembership(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	voice := &entities.Voice{PollId: "poll1", UserId: "user2", Option: "Red"}
+	poll := &entities.Poll{
+		PollId:    "poll1",
+		Question:  "What is your favorite color?",
+		Options:   map[string]int32{"Red": 0, "Blue": 0},
+		Voters:    map[string][]string{},
+		MaxVotes:  1,
+		Creator:   "user1",
+		ChannelId: "channel1",
+	}
+
+	t.Run("member", func(t *testing.T) {
+		mockStore.On("GetPoll", voice.PollId).Return(poll, nil).Twice()
+		mockBot.On("GetChannelMember", "channel1", "user2", "").Return(&model.ChannelMember{}, &model.Response{StatusCode: 200}, nil).Once()
+		mockStore.On("Vote", voice).Return(entities.NewMessage("vote.recorded"), nil).Once()
+
+		msg, err := pollService.Vote(voice)
+		require.NoError(t, err)
+		require.Equal(t, "**Voice recorded!**", msg.String())
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		mockStore.On("GetPoll", voice.PollId).Return(poll, nil).Once()
+		mockBot.On("GetChannelMember", "channel1", "user2", "").Return(nil, &model.Response{StatusCode: 404}, errors.New("not found")).Once()
+
+		msg, err := pollService.ChangeVote(voice)
+		require.Empty(t, msg)
+		require.IsType(t, &entities.UserError{}, err)
+		require.Equal(t, "**Only members of the poll's channel can vote!**", err.Error())
+	})
+
+	t.Run("failed to get member", func(t *testing.T) {
+		mockStore.On("GetPoll", voice.PollId).Return(poll, nil).Once()
+		mockBot.On("GetChannelMember", "channel1", "user2", "").Return(nil, &model.Response{StatusCode: 500}, errors.New("internal error")).Once()
+
+		msg, err := pollService.Vote(voice)
+		require.Empty(t, msg)
+		require.EqualError(t, err, "failed to get channel member: internal error")
+	})
+}
+
+// TestResultsChannelMembership проверяет, что получить результаты опроса могут только участники его канала.
+func TestResultsChannelMembership(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	poll := &entities.Poll{
+		PollId:    "poll1",
+		Question:  "What is your favorite color?",
+		Options:   map[string]int32{"Red": 1, "Blue": 0},
+		Voters:    map[string][]string{"user1": {"Red"}},
+		MaxVotes:  1,
+		Creator:   "user1",
+		ChannelId: "channel1",
+	}
+	mockStore.On("GetPoll", "poll1").Return(poll, nil)
+
+	t.Run("member", func(t *testing.T) {
+		mockBot.On("GetChannelMember", "channel1", "user2", "").Return(&model.ChannelMember{}, &model.Response{StatusCode: 200}, nil).Once()
+
+		result, err := pollService.GetPollResult("poll1", "user2", "en", false)
+		require.NoError(t, err)
+		require.Contains(t, result, "| `Red` | `1` | `100.0％` |")
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		mockBot.On("GetChannelMember", "channel1", "user2", "").Return(nil, &model.Response{StatusCode: 404}, errors.New("not found")).Times(4)
+
+		result, err := pollService.GetPollResult("poll1", "user2", "en", false)
+		require.Empty(t, result)
+		require.EqualError(t, err, "**Only members of the poll's channel can see its results!**")
+
+		result, err = pollService.GetPollResult("poll1", "user2", "en", true)
+		require.Empty(t, result)
+		require.EqualError(t, err, "**Only members of the poll's channel can see its results!**")
+
+		msg, err := pollService.PostPollResult("poll1", "user2", "channel2")
+		require.Empty(t, msg)
+		require.EqualError(t, err, "**Only members of the poll's channel can see its results!**")
+
+		msg, err = pollService.ExportPollResult("poll1", "user2", "channel2", "csv")
+		require.Empty(t, msg)
+		require.EqualError(t, err, "**Only members of the poll's channel can see its results!**")
+		mockBot.AssertNotCalled(t, "UploadFile", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed to get member", func(t *testing.T) {
+		mockBot.On("GetChannelMember", "channel1", "user2", "").Return(nil, &model.Response{StatusCode: 500}, errors.New("internal error")).Once()
+
+		msg, err := pollService.PostPollResult("poll1", "user2", "channel1")
+		require.Empty(t, msg)
+		require.EqualError(t, err, "failed to get channel member: internal error")
+	})
+}
+
 // TestClosePoll проверяет функциональность закрытия опроса.
+func TestRetractVote(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	voice := &entities.Voice{
+		PollId: "poll1",
+		UserId: "user1",
+	}
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Question: "What is your favorite color?",
+		Options:  map[string]int32{"Red": 0, "Blue": 0},
+		Voters:   map[string][]string{},
+		MaxVotes: 1,
+		Creator:  "user1",
+		PostId:   "post1",
+	}
+
+	t.Run("success RetractVote", func(t *testing.T) {
+		mockStore.On("RetractVote", voice).Return(entities.NewMessage("vote.retracted"), nil)
+		mockStore.On("GetPoll", voice.PollId).Return(poll, nil)
+		mockBot.On("PatchPost", poll.PostId, mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 200}, nil)
+
+		msg, err := pollService.RetractVote(voice)
+		require.NoError(t, err)
+		require.Equal(t, "**Voice retracted!**", msg.String())
+	})
+
+	t.Run("failed RetractVote", func(t *testing.T) {
+		mockStore.ExpectedCalls = nil
+		mockStore.On("RetractVote", voice).Return(nil, errors.New("**You haven't voted in this poll!**"))
+
+		msg, err := pollService.RetractVote(voice)
+		require.Empty(t, msg)
+		require.Error(t, err)
+		require.Equal(t, "**You haven't voted in this poll!**", err.Error())
+	})
+}
+
+func TestChangeVote(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	voice := &entities.Voice{
+		PollId: "poll1",
+		UserId: "user1",
+		Option: "Blue",
+	}
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Question: "What is your favorite color?",
+		Options:  map[string]int32{"Red": 0, "Blue": 1},
+		Voters:   map[string][]string{"user1": {"Blue"}},
+		MaxVotes: 1,
+		Creator:  "user1",
+		PostId:   "post1",
+	}
+
+	t.Run("success ChangeVote", func(t *testing.T) {
+		mockStore.On("ChangeVote", voice).Return(entities.NewMessage("vote.changed"), nil)
+		mockStore.On("GetPoll", voice.PollId).Return(poll, nil)
+		mockBot.On("PatchPost", poll.PostId, mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 200}, nil)
+
+		msg, err := pollService.ChangeVote(voice)
+		require.NoError(t, err)
+		require.Equal(t, "**Voice changed!**", msg.String())
+		mockBot.AssertCalled(t, "PatchPost", poll.PostId, mock.MatchedBy(func(patch *model.PostPatch) bool {
+			return strings.Contains(*patch.Message, "| `Blue` | `1` | `100.0％` |")
+		}))
+	})
+
+	t.Run("failed ChangeVote", func(t *testing.T) {
+		mockStore.ExpectedCalls = nil
+		mockStore.On("GetPoll", voice.PollId).Return(poll, nil)
+		mockStore.On("ChangeVote", voice).Return(nil, errors.New("**Invalid option!**"))
+
+		msg, err := pollService.ChangeVote(voice)
+		require.Empty(t, msg)
+		require.Error(t, err)
+		require.Equal(t, "**Invalid option!**", err.Error())
+	})
+}
+
 func TestClosePoll(t *testing.T) {
 	mockStore := store_mocks.NewStoreInterface(t)
-	pollService := services.NewPollService(nil, mockStore)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
 
 	pollId := "poll1"
 	userId := "user1"
 
 	t.Run("success closed Poll", func(t *testing.T) {
-		mockStore.On("ClosePoll", mock.Anything, mock.Anything).Return(fmt.Sprintf("*Poll*: `%s` **has been successfully closed!**", pollId), nil)
+		closedPoll := &entities.Poll{PollId: pollId, Options: map[string]int32{"Red": 0}, Voters: map[string][]string{}, Creator: userId, Closed: true, PostId: "post1", ChannelId: "channel1"}
+
+		mockStore.On("ClosePoll", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(entities.NewMessage("poll.closed", pollId), nil)
+		mockStore.On("GetPoll", pollId).Return(closedPoll, nil)
+		mockBot.On("PatchPost", closedPoll.PostId, mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 200}, nil)
+		mockBot.On("UploadFile", mock.Anything, "channel1", "poll_poll1_chart.svg").Return(&model.FileUploadResponse{FileInfos: []*model.FileInfo{{Id: "chart1"}}}, &model.Response{StatusCode: 201}, nil)
+		mockBot.On("CreatePost", mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 201}, nil)
 
 		msg, err := pollService.ClosePoll(pollId, userId)
 		require.NoError(t, err)
-		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully closed!**", pollId), msg)
-		mockStore.AssertCalled(t, "ClosePoll", pollId, userId)
+		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully closed!**", pollId), msg.String())
+		mockStore.AssertCalled(t, "ClosePoll", pollId, userId, false, int32(0))
+		mockBot.AssertCalled(t, "PatchPost", closedPoll.PostId, mock.MatchedBy(func(patch *model.PostPatch) bool {
+			_, hasAttachments := (*patch.Props)["attachments"]
+			return !hasAttachments && strings.Contains(*patch.Message, "(Completed)")
+		}))
+		mockBot.AssertCalled(t, "CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+			return post.ChannelId == "channel1" &&
+				strings.HasPrefix(post.Message, "*Poll*: `poll1` **has been closed!**") &&
+				len(post.FileIds) == 1 && post.FileIds[0] == "chart1"
+		}))
 	})
 
 	t.Run("failed closed Poll", func(t *testing.T) {
 		mockStore.ExpectedCalls = nil
-		mockStore.On("ClosePoll", mock.Anything, mock.Anything).Return("", fmt.Errorf("*Poll*: `%s` **has already been closed!**", pollId))
+		mockStore.On("GetPoll", pollId).Return(&entities.Poll{PollId: pollId, Creator: userId, Closed: true}, nil)
+		mockStore.On("ClosePoll", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("*Poll*: `%s` **has already been closed!**", pollId))
 
 		msg, err := pollService.ClosePoll(pollId, userId)
 		require.Error(t, err)
 		require.Empty(t, msg)
 		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has already been closed!**", pollId), err.Error())
-		mockStore.AssertCalled(t, "ClosePoll", pollId, userId)
+		mockStore.AssertCalled(t, "ClosePoll", pollId, userId, false, int32(0))
+	})
+}
+
+// TestCloseDecisionPoll проверяет сохранение кворума при закрытии опроса для принятия решения.
+func TestCloseDecisionPoll(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	poll := &entities.Poll{PollId: "poll1", Options: map[string]int32{"Yes": 0}, Voters: map[string][]string{}, Creator: "user1", ChannelId: "channel1", Quorum: 3, QuorumPercent: 50}
+
+	mockBot.On("UploadFile", mock.Anything, poll.ChannelId, "poll_poll1_chart.svg").Return(&model.FileUploadResponse{FileInfos: []*model.FileInfo{{Id: "chart1"}}}, &model.Response{StatusCode: 201}, nil)
+	mockBot.On("CreatePost", mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 201}, nil)
+
+	t.Run("success closed Poll", func(t *testing.T) {
+		mockStore.On("GetPoll", poll.PollId).Return(poll, nil)
+		mockBot.On("GetChannelStats", poll.ChannelId, "").Return(&model.ChannelStats{MemberCount: 9}, &model.Response{StatusCode: 200}, nil).Once()
+		mockStore.On("ClosePoll", poll.PollId, "user1", false, int32(5)).Return(entities.NewMessage("poll.closed", poll.PollId), nil).Once()
+
+		msg, err := pollService.ClosePoll(poll.PollId, "user1")
+		require.NoError(t, err)
+		require.Equal(t, "*Poll*: `poll1` **has been successfully closed!**", msg.String())
+	})
+
+	t.Run("failed to get channel stats", func(t *testing.T) {
+		mockBot.On("GetChannelStats", poll.ChannelId, "").Return(nil, &model.Response{StatusCode: 500}, fmt.Errorf("internal error")).Once()
+		mockStore.On("ClosePoll", poll.PollId, "user1", false, entities.QuorumUnknown).Return(entities.NewMessage("poll.closed", poll.PollId), nil).Once()
+
+		msg, err := pollService.ClosePoll(poll.PollId, "user1")
+		require.NoError(t, err)
+		require.Equal(t, "*Poll*: `poll1` **has been successfully closed!**", msg.String())
+		mockStore.AssertNumberOfCalls(t, "ClosePoll", 2)
+	})
+
+	t.Run("empty channel stats response", func(t *testing.T) {
+		mockBot.On("GetChannelStats", poll.ChannelId, "").Return(nil, nil, nil).Once()
+		mockStore.On("ClosePoll", poll.PollId, "user1", false, entities.QuorumUnknown).Return(entities.NewMessage("poll.closed", poll.PollId), nil).Once()
+
+		_, err := pollService.ClosePoll(poll.PollId, "user1")
+		require.NoError(t, err)
+	})
+
+	t.Run("percent quorum without channel", func(t *testing.T) {
+		noChannelPoll := &entities.Poll{PollId: "poll2", Options: map[string]int32{"Yes": 0}, Voters: map[string][]string{}, Creator: "user1", Quorum: 3, QuorumPercent: 50}
+		mockStore.On("GetPoll", noChannelPoll.PollId).Return(noChannelPoll, nil)
+		mockStore.On("ClosePoll", noChannelPoll.PollId, "user1", false, entities.QuorumUnknown).Return(entities.NewMessage("poll.closed", noChannelPoll.PollId), nil).Once()
+
+		msg, err := pollService.ClosePoll(noChannelPoll.PollId, "user1")
+		require.NoError(t, err)
+		require.Equal(t, "*Poll*: `poll2` **has been successfully closed!**", msg.String())
+		mockBot.AssertNumberOfCalls(t, "GetChannelStats", 3)
 	})
 }
 
 // TestDeletePoll проверяет функциональность удаления опроса.
 func TestDeletePoll(t *testing.T) {
 	mockStore := store_mocks.NewStoreInterface(t)
-	pollService := services.NewPollService(nil, mockStore)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
 
 	pollId := "poll1"
 	userId := "user1"
+	poll := &entities.Poll{PollId: pollId, Creator: userId, PostId: "post1"}
 
 	t.Run("success deleted Poll", func(t *testing.T) {
-		mockStore.On("DeletePoll", mock.Anything, mock.Anything).Return(fmt.Sprintf("*Poll*: `%s` **has been successfully deleted!**", pollId), nil)
+		mockStore.On("GetPoll", pollId).Return(poll, nil)
+		mockStore.On("DeletePoll", mock.Anything, mock.Anything, mock.Anything).Return(entities.NewMessage("poll.deleted", pollId), nil)
+		mockBot.On("PatchPost", poll.PostId, mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 200}, nil)
 
 		msg, err := pollService.DeletePoll(pollId, userId)
 		require.NoError(t, err)
-		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully deleted!**", pollId), msg)
-		mockStore.AssertCalled(t, "DeletePoll", pollId, userId)
-
+		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully deleted!**", pollId), msg.String())
+		mockStore.AssertCalled(t, "DeletePoll", pollId, userId, false)
+		mockBot.AssertCalled(t, "PatchPost", poll.PostId, mock.MatchedBy(func(patch *model.PostPatch) bool {
+			return *patch.Message == fmt.Sprintf("*Poll*: `%s` **has been deleted!**", pollId)
+		}))
 	})
 
 	t.Run("failed closed Poll", func(t *testing.T) {
 		mockStore.ExpectedCalls = nil
-		mockStore.On("DeletePoll", mock.Anything, mock.Anything).Return("", fmt.Errorf("**Invalid Poll_ID or not exists!**"))
+		mockStore.On("GetPoll", pollId).Return(poll, nil)
+		mockStore.On("DeletePoll", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("**Invalid Poll_ID or not exists!**"))
 
 		msg, err := pollService.DeletePoll(pollId, userId)
 		require.Error(t, err)
 		require.Empty(t, msg)
 		require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
-		mockStore.AssertCalled(t, "DeletePoll", pollId, userId)
+		mockStore.AssertCalled(t, "DeletePoll", pollId, userId, false)
+	})
+}
+
+// TestPostPoll проверяет функциональность публикации опроса в канале.
+func TestPostPoll(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	poll := &entities.Poll{
+		PollId:    "poll1",
+		Question:  "What is your favorite color?",
+		Options:   map[string]int32{"Red": 0, "Blue": 0},
+		Voters:    map[string][]string{},
+		MaxVotes:  1,
+		Creator:   "user1",
+		ChannelId: "channel1",
+	}
+
+	t.Run("success posted Poll", func(t *testing.T) {
+		mockBot.On("CreatePost", mock.Anything).Return(&model.Post{Id: "post1"}, &model.Response{StatusCode: 201}, nil)
+		mockStore.On("SetPollPost", poll.PollId, "post1").Return(nil)
+
+		err := pollService.PostPoll(poll)
+		require.NoError(t, err)
+		mockStore.AssertCalled(t, "SetPollPost", poll.PollId, "post1")
+		mockBot.AssertCalled(t, "CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+			return post.ChannelId == "channel1"
+		}))
+	})
+
+	t.Run("failed to create post", func(t *testing.T) {
+		mockBot.ExpectedCalls = nil
+		mockBot.On("CreatePost", mock.Anything).Return(nil, &model.Response{StatusCode: 403}, nil)
+
+		err := pollService.PostPoll(poll)
+		require.Error(t, err)
+		require.Equal(t, "failed to create post: unexpected status code 403", err.Error())
+	})
+
+	t.Run("failed to set poll post", func(t *testing.T) {
+		mockBot.ExpectedCalls = nil
+		mockStore.ExpectedCalls = nil
+		mockBot.On("CreatePost", mock.Anything).Return(&model.Post{Id: "post1"}, &model.Response{StatusCode: 201}, nil).Once()
+		mockStore.On("SetPollPost", poll.PollId, "post1").Return(errors.New("connection refused")).Once()
+		mockBot.On("PatchPost", "post1", mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 200}, nil).Once()
+
+		err := pollService.PostPoll(poll)
+		require.EqualError(t, err, "failed to set poll post: connection refused")
+		mockBot.AssertCalled(t, "PatchPost", "post1", mock.MatchedBy(func(patch *model.PostPatch) bool {
+			return *patch.Message == "*Poll*: `poll1` **has been deleted!**"
+		}))
 	})
 }
 
+// TestDiscardPoll проверяет удаление опроса, который не удалось опубликовать.
+func TestDiscardPoll(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	pollService := services.NewPollService(nil, mockStore, testConfig)
+
+	poll := &entities.Poll{PollId: "poll1", Creator: "user1"}
+
+	mockStore.On("DeletePoll", "poll1", "user1", false).Return(entities.NewMessage("poll.deleted", "poll1"), nil).Once()
+	pollService.DiscardPoll(poll)
+
+	mockStore.On("DeletePoll", "poll1", "user1", false).Return(nil, errors.New("connection refused")).Once()
+	pollService.DiscardPoll(poll)
+
+	mockStore.AssertNumberOfCalls(t, "DeletePoll", 2)
+}
+
 // TestGetPollResult проверяет функциональность получения результатов опроса.
 func TestGetPollResult(t *testing.T) {
 	mockStore := store_mocks.NewStoreInterface(t)
-	pollService := services.NewPollService(nil, mockStore)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
 
 	pollId := "poll1"
+	poll := &entities.Poll{
+		PollId:   pollId,
+		Question: "What is your favorite color?",
+		Options:  map[string]int32{"Red": 1, "Blue": 1},
+		Voters:   map[string][]string{"user1": {"Red"}, "user2": {"Blue"}},
+		MaxVotes: 1,
+		Creator:  "user1",
+	}
 
 	t.Run("success got Poll results", func(t *testing.T) {
-		mockStore.On("GetPollResult", mock.Anything).Return("**Poll Results:** Red: 5, Blue: 3", nil)
+		mockStore.On("GetPoll", pollId).Return(poll, nil).Once()
+
+		result, err := pollService.GetPollResult(pollId, "user1", "en", false)
+		require.NoError(t, err)
+		require.Contains(t, result, "| `Red` | `1` | `50.0％` |\n")
+		mockBot.AssertNotCalled(t, "GetUsersByIds", mock.Anything)
+	})
+
+	t.Run("success got public Poll results", func(t *testing.T) {
+		publicPoll := *poll
+		publicPoll.Public = true
+		mockStore.On("GetPoll", pollId).Return(&publicPoll, nil).Once()
+		mockBot.On("GetUsersByIds", mock.Anything).Return([]*model.User{{Id: "user1", Username: "alice"}}, &model.Response{StatusCode: 200}, nil).Once()
 
-		result, err := pollService.GetPollResult(pollId)
+		result, err := pollService.GetPollResult(pollId, "user1", "en", false)
 		require.NoError(t, err)
-		require.Equal(t, "**Poll Results:** Red: 5, Blue: 3", result)
-		mockStore.AssertCalled(t, "GetPollResult", pollId)
+		require.Contains(t, result, "| `Red` | `1` | `50.0％` | @alice |")
+		require.Contains(t, result, "| `Blue` | `1` | `50.0％` | user2 |")
+	})
+
+	t.Run("failed to get voters", func(t *testing.T) {
+		publicPoll := *poll
+		publicPoll.Public = true
+		mockStore.On("GetPoll", pollId).Return(&publicPoll, nil).Once()
+		mockBot.On("GetUsersByIds", mock.Anything).Return(nil, &model.Response{StatusCode: 500}, errors.New("failed to get users")).Once()
+
+		result, err := pollService.GetPollResult(pollId, "user1", "en", false)
+		require.NoError(t, err)
+		require.Contains(t, result, "| `Red` | `1` | `50.0％` | user1 |")
+	})
+
+	t.Run("success got ranked Poll results", func(t *testing.T) {
+		rankedPoll := *poll
+		rankedPoll.Ranked = true
+		rankedPoll.Voters = map[string][]string{"user1": {"Red", "Blue"}, "user2": {"Blue"}, "user3": {"Red"}}
+		rankedPoll.Options = map[string]int32{"Red": 2, "Blue": 1}
+		mockStore.On("GetPoll", pollId).Return(&rankedPoll, nil).Once()
+
+		result, err := pollService.GetPollResult(pollId, "user1", "en", false)
+		require.NoError(t, err)
+		require.Contains(t, result, "| `Red` | `2` | `66.7％` |\n")
+		require.Contains(t, result, "*Round 1*: `Red` 2, `Blue` 1\n**Winner**: `Red`")
+	})
+
+	t.Run("hidden Poll results", func(t *testing.T) {
+		hiddenPoll := *poll
+		hiddenPoll.HideResults = true
+		mockStore.On("GetPoll", pollId).Return(&hiddenPoll, nil).Once()
+
+		result, err := pollService.GetPollResult(pollId, "user2", "en", false)
+		require.NoError(t, err)
+		require.Contains(t, result, "| *Voters*: `2` |")
+		require.Contains(t, result, "*Results are hidden until the poll is closed.*")
+		require.NotContains(t, result, "50.0％")
+
+		mockStore.On("GetPoll", pollId).Return(&hiddenPoll, nil).Once()
+
+		result, err = pollService.GetPollResult(pollId, hiddenPoll.Creator, "en", false)
+		require.NoError(t, err)
+		require.Contains(t, result, "| `Red` | `1` | `50.0％` |\n")
+
+		mockStore.On("GetPoll", pollId).Return(&hiddenPoll, nil).Once()
+
+		result, err = pollService.GetPollResult(pollId, hiddenPoll.Creator, "en", true)
+		require.NoError(t, err)
+		require.Contains(t, result, "*Results are hidden until the poll is closed.*")
+
+		hiddenPoll.Closed = true
+		mockStore.On("GetPoll", pollId).Return(&hiddenPoll, nil).Once()
+
+		result, err = pollService.GetPollResult(pollId, "user2", "en", false)
+		require.NoError(t, err)
+		require.Contains(t, result, "| `Red` | `1` | `50.0％` |\n")
 	})
 
 	t.Run("failed got Poll results", func(t *testing.T) {
-		mockStore.ExpectedCalls = nil
-		mockStore.On("GetPollResult", mock.Anything).Return("", fmt.Errorf("**Invalid Poll_ID or not exists!**"))
+		mockStore.On("GetPoll", pollId).Return(nil, fmt.Errorf("**Invalid Poll_ID or not exists!**")).Once()
 
-		result, err := pollService.GetPollResult(pollId)
+		result, err := pollService.GetPollResult(pollId, "user1", "en", false)
 		require.Error(t, err)
 		require.Empty(t, result)
 		require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
-		mockStore.AssertCalled(t, "GetPollResult", pollId)
+	})
+}
+
+// TestPostPollResult проверяет публикацию результатов опроса с диаграммой.
+func TestPostPollResult(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Question: "What is your favorite color?",
+		Options:  map[string]int32{"Red": 1, "Blue": 1},
+		Voters:   map[string][]string{"user1": {"Red"}, "user2": {"Blue"}},
+		MaxVotes: 1,
+	}
+
+	t.Run("success posted results with chart", func(t *testing.T) {
+		mockStore.On("GetPoll", "poll1").Return(poll, nil).Once()
+		mockBot.On("UploadFile", mock.Anything, "channel1", "poll_poll1_chart.svg").Return(&model.FileUploadResponse{FileInfos: []*model.FileInfo{{Id: "chart1"}}}, &model.Response{StatusCode: 201}, nil).Once()
+		mockBot.On("CreatePost", mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 201}, nil).Once()
+
+		msg, err := pollService.PostPollResult("poll1", "user1", "channel1")
+		require.NoError(t, err)
+		require.Equal(t, "**Results posted!**", msg.String())
+		mockBot.AssertCalled(t, "UploadFile", mock.MatchedBy(func(data []byte) bool {
+			return strings.HasPrefix(string(data), "<svg")
+		}), "channel1", "poll_poll1_chart.svg")
+		mockBot.AssertCalled(t, "CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+			return strings.Contains(post.Message, "| `Red` | `1` | `50.0％` |") && len(post.FileIds) == 1 && post.FileIds[0] == "chart1"
+		}))
+	})
+
+	t.Run("hidden results", func(t *testing.T) {
+		hiddenPoll := *poll
+		hiddenPoll.HideResults = true
+		mockStore.On("GetPoll", "poll1").Return(&hiddenPoll, nil).Once()
+
+		msg, err := pollService.PostPollResult("poll1", "user1", "channel1")
+		require.Empty(t, msg)
+		require.EqualError(t, err, "**Results are hidden until the poll is closed!**")
+	})
+
+	t.Run("posted results without chart", func(t *testing.T) {
+		mockStore.On("GetPoll", "poll1").Return(poll, nil).Once()
+		mockBot.On("UploadFile", mock.Anything, "channel1", "poll_poll1_chart.svg").Return(nil, &model.Response{StatusCode: 413}, errors.New("file too large")).Once()
+		mockBot.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+			return len(post.FileIds) == 0
+		})).Return(&model.Post{}, &model.Response{StatusCode: 201}, nil).Once()
+
+		msg, err := pollService.PostPollResult("poll1", "user1", "channel1")
+		require.NoError(t, err)
+		require.Equal(t, "**Results posted!**", msg.String())
+	})
+}
+
+// TestExportPollResult проверяет выгрузку результатов опроса в файл.
+func TestExportPollResult(t *testing.T) {
+	mockStore := store_mocks.NewStoreInterface(t)
+	mockBot := service_mocks.NewBotInterface(t)
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Question: "What is your favorite color?",
+		Options:  map[string]int32{"Red": 1, "Blue": 1},
+		Voters:   map[string][]string{"user1": {"Red"}, "user2": {"Blue"}},
+		MaxVotes: 1,
+		Public:   true,
+	}
+
+	t.Run("success exported CSV", func(t *testing.T) {
+		mockStore.On("GetPoll", "poll1").Return(poll, nil).Once()
+		mockBot.On("GetUsersByIds", mock.Anything).Return([]*model.User{{Id: "user1", Username: "alice"}}, &model.Response{StatusCode: 200}, nil).Once()
+		mockBot.On("UploadFile", mock.Anything, "channel1", "poll_poll1.csv").Return(&model.FileUploadResponse{FileInfos: []*model.FileInfo{{Id: "file1"}}}, &model.Response{StatusCode: 201}, nil).Once()
+		mockBot.On("CreatePost", mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 201}, nil).Once()
+
+		msg, err := pollService.ExportPollResult("poll1", "user1", "channel1", "csv")
+		require.NoError(t, err)
+		require.Equal(t, "**Results exported!**", msg.String())
+		mockBot.AssertCalled(t, "UploadFile", mock.MatchedBy(func(data []byte) bool {
+			return strings.Contains(string(data), "Red,1,50.00\n") && strings.Contains(string(data), "@alice,Red,\n")
+		}), "channel1", "poll_poll1.csv")
+		mockBot.AssertCalled(t, "CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+			return post.ChannelId == "channel1" && len(post.FileIds) == 1 && post.FileIds[0] == "file1"
+		}))
+	})
+
+	t.Run("failed to upload file", func(t *testing.T) {
+		anonymousPoll := *poll
+		anonymousPoll.Public = false
+		mockStore.On("GetPoll", "poll1").Return(&anonymousPoll, nil).Once()
+		mockBot.On("UploadFile", mock.Anything, "channel1", "poll_poll1.json").Return(nil, &model.Response{StatusCode: 403}, errors.New("forbidden")).Once()
+
+		msg, err := pollService.ExportPollResult("poll1", "user1", "channel1", "json")
+		require.Empty(t, msg)
+		require.EqualError(t, err, "failed to upload file: forbidden")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		msg, err := pollService.ExportPollResult("poll1", "user1", "channel1", "xml")
+		require.Empty(t, msg)
+		require.EqualError(t, err, "**Unsupported format** `xml`! *Expected*: `csv` or `json`")
 	})
 }
 
@@ -170,11 +763,7 @@ func TestGetPollResult(t *testing.T) {
 func TestRegisterCommands(t *testing.T) {
 	mockStore := store_mocks.NewStoreInterface(t)
 	mockBot := service_mocks.NewBotInterface(t)
-	pollService := services.NewPollService(mockBot, mockStore)
-
-	config.TeamName = "test_team"
-	config.BotHostname = "localhost"
-	config.BotSocket = ":8080"
+	pollService := services.NewPollService(mockBot, mockStore, testConfig)
 
 	team := &model.Team{Id: "team_id"}
 	existingCommands := []*model.Command{
@@ -193,7 +782,7 @@ func TestRegisterCommands(t *testing.T) {
 		getStatusCode := 200
 		createStatusCode := 201
 
-		mockBot.On("GetTeamByName", config.TeamName, "").Return(team, &model.Response{StatusCode: getStatusCode}, nil)
+		mockBot.On("GetTeamByName", testConfig.TeamName, "").Return(team, &model.Response{StatusCode: getStatusCode}, nil)
 		mockBot.On("ListCommands", team.Id, false).Return(existingCommands, &model.Response{StatusCode: getStatusCode}, nil)
 		mockBot.On("CreateCommand", mock.Anything).Return(&model.Command{Token: "new_token"}, &model.Response{StatusCode: createStatusCode}, nil)
 		mockStore.On("AddCmdToken", newCommand.URLPath, "new_token").Return(nil)
@@ -201,7 +790,7 @@ func TestRegisterCommands(t *testing.T) {
 		err := pollService.RegisterCommands()
 		require.NoError(t, err)
 
-		mockBot.AssertCalled(t, "GetTeamByName", config.TeamName, "")
+		mockBot.AssertCalled(t, "GetTeamByName", testConfig.TeamName, "")
 		mockBot.AssertCalled(t, "ListCommands", team.Id, false)
 		mockBot.AssertCalled(t, "CreateCommand", mock.MatchedBy(func(cmd *model.Command) bool {
 			return cmd.Trigger == newCommand.Trigger
@@ -216,7 +805,7 @@ func TestRegisterCommands(t *testing.T) {
 
 		mockBot.ExpectedCalls = nil
 
-		mockBot.On("GetTeamByName", config.TeamName, "").Return(nil, &model.Response{StatusCode: getStatusCode}, testErr)
+		mockBot.On("GetTeamByName", testConfig.TeamName, "").Return(nil, &model.Response{StatusCode: getStatusCode}, testErr)
 
 		err := pollService.RegisterCommands()
 		require.Error(t, err)
@@ -227,13 +816,13 @@ func TestRegisterCommands(t *testing.T) {
 
 		mockBot.ExpectedCalls = nil
 
-		mockBot.On("GetTeamByName", config.TeamName, "").Return(nil, &model.Response{StatusCode: getStatusCode}, nil)
+		mockBot.On("GetTeamByName", testConfig.TeamName, "").Return(nil, &model.Response{StatusCode: getStatusCode}, nil)
 
 		err = pollService.RegisterCommands()
 		require.Error(t, err)
 		require.Equal(t, fmt.Sprintf("failed to get team: unexpected status code %d", getStatusCode), err.Error())
 
-		mockBot.AssertCalled(t, "GetTeamByName", config.TeamName, "")
+		mockBot.AssertCalled(t, "GetTeamByName", testConfig.TeamName, "")
 	})
 
 	t.Run("failed to get commands list", func(t *testing.T) {
@@ -242,7 +831,7 @@ func TestRegisterCommands(t *testing.T) {
 
 		getStatusCode := 200
 
-		mockBot.On("GetTeamByName", config.TeamName, "").Return(team, &model.Response{StatusCode: getStatusCode}, nil)
+		mockBot.On("GetTeamByName", testConfig.TeamName, "").Return(team, &model.Response{StatusCode: getStatusCode}, nil)
 
 		testErr := errors.New("error text")
 		mockBot.On("ListCommands", team.Id, false).Return(existingCommands, &model.Response{StatusCode: getStatusCode}, testErr)
@@ -254,7 +843,7 @@ func TestRegisterCommands(t *testing.T) {
 		// Проверяем обработку 500 статуса ответа при получении списка команд
 		mockBot.ExpectedCalls = nil
 
-		mockBot.On("GetTeamByName", config.TeamName, "").Return(team, &model.Response{StatusCode: getStatusCode}, nil)
+		mockBot.On("GetTeamByName", testConfig.TeamName, "").Return(team, &model.Response{StatusCode: getStatusCode}, nil)
 
 		getStatusCode = 500
 		mockBot.On("ListCommands", team.Id, false).Return(existingCommands, &model.Response{StatusCode: getStatusCode}, nil)
@@ -263,7 +852,7 @@ func TestRegisterCommands(t *testing.T) {
 		require.Error(t, err)
 		require.Equal(t, fmt.Sprintf("failed to get commands list: unexpected status code %d", getStatusCode), err.Error())
 
-		mockBot.AssertCalled(t, "GetTeamByName", config.TeamName, "")
+		mockBot.AssertCalled(t, "GetTeamByName", testConfig.TeamName, "")
 		mockBot.AssertCalled(t, "ListCommands", team.Id, false)
 	})
 
@@ -275,7 +864,7 @@ func TestRegisterCommands(t *testing.T) {
 		createStatusCode := 500
 		testErr := errors.New("error text")
 
-		mockBot.On("GetTeamByName", config.TeamName, "").Return(team, &model.Response{StatusCode: getStatusCode}, nil)
+		mockBot.On("GetTeamByName", testConfig.TeamName, "").Return(team, &model.Response{StatusCode: getStatusCode}, nil)
 		mockBot.On("ListCommands", team.Id, false).Return(existingCommands, &model.Response{StatusCode: getStatusCode}, nil)
 		mockCreateCommand := mockBot.On("CreateCommand", mock.Anything).Return(&model.Command{Token: "new_token"}, &model.Response{StatusCode: createStatusCode}, testErr)
 
@@ -292,7 +881,7 @@ func TestRegisterCommands(t *testing.T) {
 		require.Error(t, err)
 		require.Equal(t, fmt.Sprintf("failed to create command: unexpected status code %d", createStatusCode), err.Error())
 
-		mockBot.AssertCalled(t, "GetTeamByName", config.TeamName, "")
+		mockBot.AssertCalled(t, "GetTeamByName", testConfig.TeamName, "")
 		mockBot.AssertCalled(t, "ListCommands", team.Id, false)
 		mockBot.AssertCalled(t, "CreateCommand", mock.MatchedBy(func(cmd *model.Command) bool {
 			return cmd.Trigger == newCommand.Trigger
@@ -307,7 +896,7 @@ func TestRegisterCommands(t *testing.T) {
 		getStatusCode := 200
 		createStatusCode := 201
 
-		mockBot.On("GetTeamByName", config.TeamName, "").Return(team, &model.Response{StatusCode: getStatusCode}, nil)
+		mockBot.On("GetTeamByName", testConfig.TeamName, "").Return(team, &model.Response{StatusCode: getStatusCode}, nil)
 		mockBot.On("ListCommands", team.Id, false).Return(existingCommands, &model.Response{StatusCode: getStatusCode}, nil)
 		mockBot.On("CreateCommand", mock.Anything).Return(&model.Command{Token: "new_token"}, &model.Response{StatusCode: createStatusCode}, nil)
 
@@ -318,7 +907,7 @@ func TestRegisterCommands(t *testing.T) {
 		require.Error(t, err)
 		require.Equal(t, fmt.Sprintf("failed to add cmd token : %v", testErr), err.Error())
 
-		mockBot.AssertCalled(t, "GetTeamByName", config.TeamName, "")
+		mockBot.AssertCalled(t, "GetTeamByName", testConfig.TeamName, "")
 		mockBot.AssertCalled(t, "ListCommands", team.Id, false)
 		mockBot.AssertCalled(t, "CreateCommand", mock.MatchedBy(func(cmd *model.Command) bool {
 			return cmd.Trigger == newCommand.Trigger
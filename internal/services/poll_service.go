@@ -5,73 +5,525 @@ import (
 	"log"
 	"matterpoll-bot/config"
 	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
 	"matterpoll-bot/internal/storage"
+	"net/http"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v6/model"
 )
 
 type PollService struct {
-	Bot   BotInterface
-	store storage.StoreInterface
+	Bot     BotInterface
+	store   storage.StoreInterface
+	locales *localeCache
+	cfg     *config.Config
 }
 
 // NewPollService возвращает структуру сервиса голосований.
-func NewPollService(bot BotInterface, s storage.StoreInterface) *PollService {
-	return &PollService{Bot: bot, store: s}
+// Из cfg сервис берет адрес бота, токен для подписи запросов и имя команды для регистрации слеш-команд.
+func NewPollService(bot BotInterface, s storage.StoreInterface, cfg *config.Config) *PollService {
+	return &PollService{Bot: bot, store: s, locales: newLocaleCache(), cfg: cfg}
 }
 
-// CreatePoll создает новый опрос и сохраняет его в хранилище.
-// Возвращает строку с результатом и ошибку, если операция завершилась неудачно.
+// NewPoll возвращает новый опрос с одиночным выбором, созданный пользователем creator в текущий момент.
+func NewPoll(question string, options []string, creator string) *entities.Poll {
+	voices := make(map[string]int32, len(options))
+	for _, option := range options {
+		voices[option] = 0
+	}
+
+	return &entities.Poll{
+		PollId:    model.NewId(),
+		Question:  question,
+		Options:   voices,
+		Voters:    map[string][]string{},
+		Creator:   creator,
+		Closed:    false,
+		MaxVotes:  1,
+		CreatedAt: time.Now().Unix(),
+	}
+}
+
+// CreatePoll проверяет настройки опроса, создает новый опрос и сохраняет его в хранилище.
+// Возвращает ошибку, если операция завершилась неудачно.
 func (ps *PollService) CreatePoll(poll *entities.Poll) error {
+	if err := storage.ValidatePoll(poll); err != nil {
+		return err
+	}
+
 	err := ps.store.CreatePoll(poll)
 
 	return err
 }
 
+// PostPoll публикует опрос в его канале и сохраняет идентификатор созданного сообщения,
+// чтобы обновлять его после каждого изменения опроса. Если идентификатор сохранить не удалось,
+// сообщение помечается как относящееся к удаленному опросу.
+func (ps *PollService) PostPoll(poll *entities.Poll) error {
+	post, resp, err := ps.Bot.CreatePost(ps.NewPollPost(poll, poll.ChannelId, nil))
+	if err != nil {
+		return fmt.Errorf("failed to create post: %w", err)
+	}
+
+	if err := checkResponse(resp, 201); err != nil {
+		return fmt.Errorf("failed to create post: %w", err)
+	}
+
+	if err := ps.store.SetPollPost(poll.PollId, post.Id); err != nil {
+		ps.patchPost(post.Id, NewDeletedPollPostPatch(poll.PollId))
+		return fmt.Errorf("failed to set poll post: %w", err)
+	}
+
+	return nil
+}
+
+// DiscardPoll удаляет из хранилища опрос poll, который не удалось опубликовать,
+// чтобы в нем не оставались опросы без сообщения в канале.
+// Ошибки удаления только логируются, так как вызывающему уже возвращается ошибка публикации.
+func (ps *PollService) DiscardPoll(poll *entities.Poll) {
+	if _, err := ps.store.DeletePoll(poll.PollId, poll.Creator, false); err != nil {
+		log.Printf("failed to discard poll '%s': %v\n", poll.PollId, err)
+	}
+}
+
 // Vote регистрирует голос пользователя в опросе,
-// в соответствии с выбранным вариантом.
-// Возвращает строку с результатом и ошибку, если операция завершилась неудачно.
-func (ps *PollService) Vote(voice *entities.Voice) (string, error) {
+// в соответствии с выбранным вариантом. Голосовать могут только участники канала опроса.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) Vote(voice *entities.Voice) (*entities.Message, error) {
+	if err := ps.checkMembership(voice); err != nil {
+		return nil, err
+	}
+
 	res, err := ps.store.Vote(voice)
+	if err != nil {
+		return nil, err
+	}
+	ps.updatePollPost(voice.PollId)
 
-	return res, err
+	return res, nil
 }
 
-// GetPollResult получает результат опроса по его идентификатору.
+// RetractVote отзывает голос пользователя в опросе.
+// Если вариант в голосе не указан, отзываются все варианты, выбранные пользователем.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) RetractVote(voice *entities.Voice) (*entities.Message, error) {
+	res, err := ps.store.RetractVote(voice)
+	if err != nil {
+		return nil, err
+	}
+	ps.updatePollPost(voice.PollId)
+
+	return res, nil
+}
+
+// ChangeVote заменяет выбор пользователя в опросе на вариант, указанный в голосе.
+// Изменять голос могут только участники канала опроса.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) ChangeVote(voice *entities.Voice) (*entities.Message, error) {
+	if err := ps.checkMembership(voice); err != nil {
+		return nil, err
+	}
+
+	res, err := ps.store.ChangeVote(voice)
+	if err != nil {
+		return nil, err
+	}
+	ps.updatePollPost(voice.PollId)
+
+	return res, nil
+}
+
+// AddOption добавляет вариант option в опрос pollId с открытыми вариантами от имени пользователя userId.
+// Добавлять варианты могут только участники канала опроса.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) AddOption(pollId, userId, option string) (*entities.Message, error) {
+	if err := ps.checkMembership(&entities.Voice{PollId: pollId, UserId: userId}); err != nil {
+		return nil, err
+	}
+
+	res, err := ps.store.AddOption(pollId, option)
+	if err != nil {
+		return nil, err
+	}
+	ps.updatePollPost(pollId)
+
+	return res, nil
+}
+
+// checkMembership проверяет, что автор голоса состоит в канале, в котором опубликован опрос,
+// чтобы по идентификатору нельзя было проголосовать из другого канала.
+func (ps *PollService) checkMembership(voice *entities.Voice) error {
+	poll, err := ps.store.GetPoll(voice.PollId)
+	if err != nil {
+		return err
+	}
+
+	return ps.checkChannelAccess(poll, voice.UserId, "vote.not_member")
+}
+
+// checkChannelAccess проверяет, что пользователь userId состоит в канале опроса poll,
+// и возвращает пользовательскую ошибку с сообщением key, если это не так.
+// Для опросов без сохраненного канала проверка не выполняется.
+func (ps *PollService) checkChannelAccess(poll *entities.Poll, userId, key string) error {
+	if poll.ChannelId == "" {
+		return nil
+	}
+
+	isMember, err := ps.isChannelMember(poll.ChannelId, userId)
+	if err != nil {
+		return err
+	}
+
+	if !isMember {
+		return entities.NewUserError(key)
+	}
+
+	return nil
+}
+
+// isChannelMember проверяет через API Mattermost, состоит ли пользователь userId в канале channelId.
+func (ps *PollService) isChannelMember(channelId, userId string) (bool, error) {
+	_, resp, err := ps.Bot.GetChannelMember(channelId, userId, "")
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to get channel member: %w", err)
+	}
+
+	if err := checkResponse(resp, 200); err != nil {
+		return false, fmt.Errorf("failed to get channel member: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetPollResult получает результат опроса по его идентификатору для пользователя userId на языке locale.
+// Для публичного опроса в результат добавляются имена проголосовавших пользователей,
+// а для рейтингового — потуровые результаты подсчета и победитель.
+// Если результаты опроса скрыты до его закрытия, всем, кроме создателя, возвращается только число проголосовавших.
+// Результаты доступны только участникам канала опроса.
+// Если share равен true, результаты готовятся для публикации в канале и скрытые результаты не раскрываются даже создателю.
 // Возвращает строку с результатом и ошибку, если операция завершилась неудачно.
-func (ps *PollService) GetPollResult(pollId string) (string, error) {
-	res, err := ps.store.GetPollResult(pollId)
+func (ps *PollService) GetPollResult(pollId, userId, locale string, share bool) (string, error) {
+	poll, err := ps.store.GetPoll(pollId)
+	if err != nil {
+		return "", err
+	}
+
+	if err := ps.checkChannelAccess(poll, userId, "results.not_member"); err != nil {
+		return "", err
+	}
+
+	viewer := userId
+	if share {
+		viewer = ""
+	}
+
+	return ps.resultText(poll, viewer, locale), nil
+}
+
+// resultsHidden сообщает, скрыты ли результаты опроса poll от пользователя userId.
+// Результаты скрываются до закрытия опроса от всех, кроме его создателя;
+// пустой userId означает публикацию в канале, где результаты скрыты от всех.
+func resultsHidden(poll *entities.Poll, userId string) bool {
+	return poll.HideResults && !poll.Closed && (userId == "" || userId != poll.Creator)
+}
+
+// resultText возвращает таблицу с результатами опроса для пользователя userId на языке locale,
+// для рейтингового опроса — и потуровые результаты подсчета, а для закрытого опроса с кворумом или порогом — итог решения.
+func (ps *PollService) resultText(poll *entities.Poll, userId, locale string) string {
+	if resultsHidden(poll, userId) {
+		return storage.PrintHiddenTable(poll, locale) + "\n\n" + i18n.T(locale, "results.hidden_note")
+	}
+
+	res := storage.PrintTable(poll, ps.voterNames(poll), locale)
+	if poll.Ranked {
+		res += "\n\n" + storage.PrintRunoff(poll, locale)
+	}
+	if decision := storage.PrintDecision(poll, locale); decision != "" {
+		res += "\n\n" + decision
+	}
+
+	return res
+}
+
+// PostPollResult публикует по запросу пользователя userId результаты опроса pollId в канале channelId
+// вместе с диаграммой результатов во вложении.
+// Результаты, скрытые до закрытия опроса, не публикуются, а опубликовать их может только участник канала опроса.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) PostPollResult(pollId, userId, channelId string) (*entities.Message, error) {
+	poll, err := ps.store.GetPoll(pollId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.checkChannelAccess(poll, userId, "results.not_member"); err != nil {
+		return nil, err
+	}
+
+	if resultsHidden(poll, "") {
+		return nil, entities.NewUserError("results.hidden")
+	}
+
+	message := i18n.T(i18n.DefaultLocale, "post.poll", poll.PollId, ps.resultText(poll, "", i18n.DefaultLocale))
+	if err := ps.createResultsPost(poll, channelId, message); err != nil {
+		return nil, err
+	}
+
+	return entities.NewMessage("results.posted"), nil
+}
+
+// ExportPollResult выгружает по запросу пользователя userId результаты опроса pollId в файл формата format
+// ("csv" или "json") и публикует его в канале channelId.
+// Для публичного опроса в файл добавляется выбор каждого проголосовавшего пользователя.
+// Результаты, скрытые до закрытия опроса, не выгружаются, а выгрузить их может только участник канала опроса.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) ExportPollResult(pollId, userId, channelId, format string) (*entities.Message, error) {
+	var export func(*entities.Poll, map[string]string) ([]byte, error)
+	switch format {
+	case "csv":
+		export = storage.ExportCSV
+	case "json":
+		export = storage.ExportJSON
+	default:
+		return nil, entities.NewUserError("results.unsupported_format", format)
+	}
+
+	poll, err := ps.store.GetPoll(pollId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.checkChannelAccess(poll, userId, "results.not_member"); err != nil {
+		return nil, err
+	}
+
+	if resultsHidden(poll, "") {
+		return nil, entities.NewUserError("results.hidden")
+	}
+
+	data, err := export(poll, ps.voterNames(poll))
+	if err != nil {
+		return nil, err
+	}
 
-	return res, err
+	fileIds, err := ps.uploadFile(data, channelId, fmt.Sprintf("poll_%s.%s", poll.PollId, format))
+	if err != nil {
+		return nil, err
+	}
+
+	post := &model.Post{
+		ChannelId: channelId,
+		Message:   i18n.T(i18n.DefaultLocale, "post.export", poll.PollId, format),
+		FileIds:   fileIds,
+	}
+	_, resp, err := ps.Bot.CreatePost(post)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create post: %w", err)
+	}
+
+	if err := checkResponse(resp, 201); err != nil {
+		return nil, fmt.Errorf("failed to create post: %w", err)
+	}
+
+	return entities.NewMessage("results.exported"), nil
+}
+
+// createResultsPost публикует в канале channelId сообщение message с результатами опроса
+// и прикрепляет к нему диаграмму результатов. Если диаграмму загрузить не удалось,
+// сообщение публикуется без нее.
+func (ps *PollService) createResultsPost(poll *entities.Poll, channelId, message string) error {
+	post := &model.Post{ChannelId: channelId, Message: message}
+
+	fileIds, err := ps.uploadFile(storage.RenderChart(poll, i18n.DefaultLocale), channelId, fmt.Sprintf("poll_%s_chart.svg", poll.PollId))
+	if err != nil {
+		log.Printf("failed to attach chart of poll '%s': %v\n", poll.PollId, err)
+	} else {
+		post.FileIds = fileIds
+	}
+
+	_, resp, err := ps.Bot.CreatePost(post)
+	if err != nil {
+		return fmt.Errorf("failed to create post: %w", err)
+	}
+
+	if err := checkResponse(resp, 201); err != nil {
+		return fmt.Errorf("failed to create post: %w", err)
+	}
+
+	return nil
+}
+
+// uploadFile загружает файл filename с содержимым data в канал channelId
+// и возвращает идентификаторы загруженных файлов для прикрепления к сообщению.
+func (ps *PollService) uploadFile(data []byte, channelId, filename string) (model.StringArray, error) {
+	upload, resp, err := ps.Bot.UploadFile(data, channelId, filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	if err := checkResponse(resp, 201); err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	fileIds := make(model.StringArray, 0, len(upload.FileInfos))
+	for _, info := range upload.FileInfos {
+		fileIds = append(fileIds, info.Id)
+	}
+
+	return fileIds, nil
+}
+
+// PollListPageSize - количество опросов на одной странице списка опросов.
+const PollListPageSize = 10
+
+// ListPolls возвращает таблицу опросов, удовлетворяющих условиям filter, на языке locale.
+// Если опросы не помещаются на одну страницу (filter.Limit), под таблицей указывается номер страницы.
+func (ps *PollService) ListPolls(filter *entities.PollFilter, locale string) (string, error) {
+	polls, total, err := ps.store.ListPolls(filter)
+	if err != nil {
+		return "", err
+	}
+
+	res := storage.PrintList(polls, locale)
+	if filter.Limit > 0 && total > filter.Limit && len(polls) != 0 {
+		pages := (total + filter.Limit - 1) / filter.Limit
+		res += "\n\n" + i18n.T(locale, "list.page", filter.Offset/filter.Limit+1, pages, total)
+	}
+
+	return res, nil
 }
 
 // ClosePoll завершает опрос с указанным pollId от имени пользователя userId.
-// Возвращает строку с результатом и ошибку, если операция завершилась неудачно.
-func (ps *PollService) ClosePoll(pollId, userId string) (string, error) {
-	res, err := ps.store.ClosePoll(pollId, userId)
+// Закрыть опрос может его создатель или модератор (администратор системы, команды или канала опроса).
+// Для опроса с кворумом вместе с закрытием сохраняется количество проголосовавших, необходимое для кворума.
+// Если количество участников канала получить не удалось или у опроса нет канала, опрос все равно закрывается,
+// а кворум сохраняется неизвестным (entities.QuorumUnknown).
+// После закрытия итоговые результаты публикуются в канале опроса вместе с диаграммой.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) ClosePoll(pollId, userId string) (*entities.Message, error) {
+	return ps.closePoll(pollId, userId, "post.closed")
+}
 
-	return res, err
+// closePoll закрывает опрос pollId от имени пользователя userId и публикует итоговые результаты
+// с заголовком из сообщения resultsKey.
+func (ps *PollService) closePoll(pollId, userId, resultsKey string) (*entities.Message, error) {
+	poll, err := ps.store.GetPoll(pollId)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := ps.actorRole(poll, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var requiredVoters int32
+	if storage.IsDecision(poll) {
+		if requiredVoters, err = ps.requiredVoters(poll); err != nil {
+			log.Printf("failed to compute quorum of poll '%s': %v\n", pollId, err)
+			requiredVoters = entities.QuorumUnknown
+		}
+	}
+
+	res, err := ps.store.ClosePoll(pollId, userId, role != "", requiredVoters)
+	if err != nil {
+		return nil, err
+	}
+	ps.updatePollPost(pollId)
+	ps.recordModeration(poll, userId, role, "closed")
+	if err := ps.postFinalResults(pollId, poll.ChannelId, resultsKey); err != nil {
+		log.Println(err)
+	}
+
+	return res, nil
 }
 
-// DeletePoll удаляет опрос с указанным pollId, если userId имеет необходимые права.
-// Возвращает строку с результатом и ошибку, если операция завершилась неудачно.
-func (ps *PollService) DeletePoll(pollId, userId string) (string, error) {
-	res, err := ps.store.DeletePoll(pollId, userId)
+// requiredVoters возвращает количество проголосовавших, необходимое для кворума опроса.
+// Если кворум задан долей участников канала, их количество запрашивается у Mattermost,
+// а для опроса без канала кворум считается неизвестным (entities.QuorumUnknown).
+func (ps *PollService) requiredVoters(poll *entities.Poll) (int32, error) {
+	if poll.QuorumPercent == 0 {
+		return storage.RequiredVoters(poll, 0), nil
+	}
 
-	return res, err
+	if poll.ChannelId == "" {
+		return entities.QuorumUnknown, nil
+	}
+
+	stats, resp, err := ps.Bot.GetChannelStats(poll.ChannelId, "")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get channel stats: %w", err)
+	}
+
+	if err := checkResponse(resp, 200); err != nil {
+		return 0, fmt.Errorf("failed to get channel stats: %w", err)
+	}
+
+	return storage.RequiredVoters(poll, stats.MemberCount), nil
+}
+
+// ReopenPoll снова открывает закрытый опрос с указанным pollId от имени пользователя userId.
+// Открыть опрос может его создатель или модератор; срок автоматического закрытия при этом снимается.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) ReopenPoll(pollId, userId string) (*entities.Message, error) {
+	poll, err := ps.store.GetPoll(pollId)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := ps.actorRole(poll, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ps.store.ReopenPoll(pollId, userId, role != "")
+	if err != nil {
+		return nil, err
+	}
+	ps.updatePollPost(pollId)
+	ps.recordModeration(poll, userId, role, "reopened")
+
+	return res, nil
+}
+
+// DeletePoll удаляет опрос с указанным pollId, если userId является его создателем или модератором.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) DeletePoll(pollId, userId string) (*entities.Message, error) {
+	poll, err := ps.store.GetPoll(pollId)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := ps.actorRole(poll, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ps.store.DeletePoll(pollId, userId, role != "")
+	if err != nil {
+		return nil, err
+	}
+	ps.patchPost(poll.PostId, NewDeletedPollPostPatch(pollId))
+	ps.recordModeration(poll, userId, role, "deleted")
+
+	return res, nil
 }
 
 // RegisterCommands регистрирует команды Mattermost для бота.
 // Сначала проверяется наличие команды в списке существующих команд,
 // затем создаются новые команды, если они еще не зарегистрированы.
 func (ps *PollService) RegisterCommands() error {
-	team, resp, err := ps.Bot.GetTeamByName(config.TeamName, "")
+	team, resp, err := ps.Bot.GetTeamByName(ps.cfg.TeamName, "")
 	if err != nil {
 		return fmt.Errorf("failed to get team: %w", err)
 	}
 
-	if resp == nil || resp.StatusCode != 200 {
-		return fmt.Errorf("failed to get team: unexpected status code %d", resp.StatusCode)
+	if err := checkResponse(resp, 200); err != nil {
+		return fmt.Errorf("failed to get team: %w", err)
 	}
 
 	existingCommands, resp, err := ps.Bot.ListCommands(team.Id, false)
@@ -79,8 +531,8 @@ func (ps *PollService) RegisterCommands() error {
 		return fmt.Errorf("failed to get commands list: %w", err)
 	}
 
-	if resp == nil || resp.StatusCode != 200 {
-		return fmt.Errorf("failed to get commands list: unexpected status code %d", resp.StatusCode)
+	if err := checkResponse(resp, 200); err != nil {
+		return fmt.Errorf("failed to get commands list: %w", err)
 	}
 
 	registeredCommands := make(map[string]bool)
@@ -97,12 +549,13 @@ func (ps *PollService) RegisterCommands() error {
 			TeamId:           team.Id,
 			Trigger:          cmd.Trigger,
 			Method:           "P",
-			URL:              fmt.Sprintf("http://%s%s%s", config.BotHostname, config.BotSocket, cmd.URLPath),
+			URL:              ps.botURL(cmd.URLPath),
 			DisplayName:      cmd.DisplayName,
 			Description:      cmd.Description,
 			AutoComplete:     true,
 			AutoCompleteDesc: cmd.Description,
 			AutoCompleteHint: cmd.Hint,
+			AutocompleteData: NewCommandAutocompleteData(cmd),
 		}
 
 		createdCommand, resp, err := ps.Bot.CreateCommand(newCommand)
@@ -110,8 +563,8 @@ func (ps *PollService) RegisterCommands() error {
 			return fmt.Errorf("failed to create command '%s': %w", cmd.URLPath, err)
 		}
 
-		if resp == nil || resp.StatusCode != 201 {
-			return fmt.Errorf("failed to create command: unexpected status code %d", resp.StatusCode)
+		if err := checkResponse(resp, 201); err != nil {
+			return fmt.Errorf("failed to create command: %w", err)
 		}
 
 		if err := ps.store.AddCmdToken(cmd.URLPath, createdCommand.Token); err != nil {
@@ -123,3 +576,58 @@ func (ps *PollService) RegisterCommands() error {
 
 	return nil
 }
+
+// updatePollPost обновляет сообщение с опросом в соответствии с его текущим состоянием.
+// Ошибки обновления только логируются, так как не влияют на результат основной операции.
+func (ps *PollService) updatePollPost(pollId string) {
+	poll, err := ps.store.GetPoll(pollId)
+	if err != nil {
+		log.Printf("failed to get poll '%s' for post update: %v\n", pollId, err)
+		return
+	}
+
+	ps.patchPost(poll.PostId, ps.NewPollPostPatch(poll, ps.voterNames(poll)))
+}
+
+// voterNames возвращает имена пользователей, проголосовавших в публичном опросе, по их идентификаторам.
+// Для анонимного опроса возвращает nil. Ошибки получения пользователей только логируются,
+// в этом случае в результатах отображаются идентификаторы пользователей.
+func (ps *PollService) voterNames(poll *entities.Poll) map[string]string {
+	if !poll.Public || len(poll.Voters) == 0 {
+		return nil
+	}
+
+	userIds := make([]string, 0, len(poll.Voters))
+	for userId := range poll.Voters {
+		userIds = append(userIds, userId)
+	}
+
+	users, resp, err := ps.Bot.GetUsersByIds(userIds)
+	if err != nil {
+		log.Printf("failed to get voters of poll '%s': %v\n", poll.PollId, err)
+		return nil
+	}
+
+	if err := checkResponse(resp, 200); err != nil {
+		log.Printf("failed to get voters of poll '%s': %v\n", poll.PollId, err)
+		return nil
+	}
+
+	names := make(map[string]string, len(users))
+	for _, user := range users {
+		names[user.Id] = user.Username
+	}
+
+	return names
+}
+
+// patchPost применяет изменения к сообщению postId, если опрос был опубликован.
+func (ps *PollService) patchPost(postId string, patch *model.PostPatch) {
+	if postId == "" {
+		return
+	}
+
+	if _, _, err := ps.Bot.PatchPost(postId, patch); err != nil {
+		log.Printf("failed to update post '%s': %v\n", postId, err)
+	}
+}
@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/services"
+	"matterpoll-bot/internal/services/service_mocks"
+	"matterpoll-bot/internal/storage/store_mocks"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestCloseDuePolls проверяет автоматическое закрытие опросов с истекшим сроком.
+func TestCloseDuePolls(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	poll := &entities.Poll{
+		PollId:    "poll1",
+		Question:  "What is your favorite color?",
+		Options:   map[string]int32{"Red": 1, "Blue": 0},
+		Voters:    map[string][]string{"user2": {"Red"}},
+		MaxVotes:  1,
+		Creator:   "user1",
+		ChannelId: "channel1",
+		EndsAt:    now.Unix() - 60,
+	}
+	closedPoll := *poll
+	closedPoll.Closed = true
+
+	t.Run("success closed due polls", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+		mockStore.On("ListDuePolls", now.Unix()).Return([]*entities.Poll{poll}, nil)
+		mockStore.On("ClosePoll", poll.PollId, poll.Creator, false, int32(0)).Return(entities.NewMessage("poll.closed", "poll1"), nil)
+		mockStore.On("GetPoll", poll.PollId).Return(&closedPoll, nil)
+		mockBot.On("UploadFile", mock.Anything, "channel1", "poll_poll1_chart.svg").Return(&model.FileUploadResponse{FileInfos: []*model.FileInfo{{Id: "chart1"}}}, &model.Response{StatusCode: 201}, nil)
+		mockBot.On("CreatePost", mock.Anything).Return(&model.Post{}, &model.Response{StatusCode: 201}, nil)
+
+		pollService.CloseDuePolls(now)
+		mockStore.AssertCalled(t, "ClosePoll", poll.PollId, poll.Creator, false, int32(0))
+		mockBot.AssertCalled(t, "CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+			return post.ChannelId == "channel1" &&
+				strings.Contains(post.Message, "**has been closed at the deadline!**") &&
+				strings.Contains(post.Message, "| `Red` | `1` | `100.0％` |") &&
+				len(post.FileIds) == 1 && post.FileIds[0] == "chart1"
+		}))
+	})
+
+	t.Run("failed to close poll", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+		mockStore.On("ListDuePolls", now.Unix()).Return([]*entities.Poll{poll}, nil)
+		mockStore.On("GetPoll", poll.PollId).Return(poll, nil)
+		mockStore.On("ClosePoll", poll.PollId, poll.Creator, false, int32(0)).Return(nil, errors.New("failed to execute update request"))
+
+		pollService.CloseDuePolls(now)
+		mockBot.AssertNotCalled(t, "CreatePost", mock.Anything)
+	})
+
+	t.Run("failed to list due polls", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		pollService := services.NewPollService(nil, mockStore, testConfig)
+
+		mockStore.On("ListDuePolls", now.Unix()).Return(nil, errors.New("failed to execute select request"))
+
+		pollService.CloseDuePolls(now)
+		mockStore.AssertNotCalled(t, "ClosePoll", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"matterpoll-bot/internal/i18n"
+	"time"
+)
+
+// RunScheduler периодически, с интервалом interval, закрывает опросы с истекшим сроком
+// и создает опросы по расписаниям до отмены ctx.
+// Первая проверка выполняется сразу при запуске, поэтому опросы, срок которых истек,
+// пока бот был остановлен, закрываются после перезапуска.
+func (ps *PollService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		ps.CloseDuePolls(now)
+		ps.RunDueSchedules(now)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CloseDuePolls закрывает от имени создателей открытые опросы, срок которых истек к моменту now,
+// обновляет сообщения с ними и публикует итоговые результаты в каналах опросов.
+// Ошибки только логируются, чтобы не прерывать обработку остальных опросов.
+func (ps *PollService) CloseDuePolls(now time.Time) {
+	polls, err := ps.store.ListDuePolls(now.Unix())
+	if err != nil {
+		log.Printf("failed to list due polls: %v\n", err)
+		return
+	}
+
+	for _, poll := range polls {
+		if _, err := ps.closePoll(poll.PollId, poll.Creator, "post.closed_at_deadline"); err != nil {
+			log.Printf("failed to close poll '%s' at deadline: %v\n", poll.PollId, err)
+		}
+	}
+}
+
+// postFinalResults публикует итоговые результаты закрытого опроса в канале channelId
+// с заголовком из сообщения key вместе с диаграммой результатов во вложении.
+func (ps *PollService) postFinalResults(pollId, channelId, key string) error {
+	if channelId == "" {
+		return nil
+	}
+
+	poll, err := ps.store.GetPoll(pollId)
+	if err != nil {
+		return fmt.Errorf("failed to get results of poll '%s': %w", pollId, err)
+	}
+
+	message := i18n.T(i18n.DefaultLocale, key, pollId, ps.resultText(poll, "", i18n.DefaultLocale))
+	if err := ps.createResultsPost(poll, channelId, message); err != nil {
+		return fmt.Errorf("failed to post results of poll '%s': %w", pollId, err)
+	}
+
+	return nil
+}
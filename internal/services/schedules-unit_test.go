@@ -0,0 +1,163 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/services"
+	"matterpoll-bot/internal/services/service_mocks"
+	"matterpoll-bot/internal/storage/store_mocks"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateSchedule проверяет создание расписания повторяющегося опроса.
+func TestCreateSchedule(t *testing.T) {
+	// 2025-01-01 10:30 UTC - среда.
+	now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	t.Run("success created schedule", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		pollService := services.NewPollService(service_mocks.NewBotInterface(t), mockStore, testConfig)
+
+		schedule := &entities.Schedule{Cron: "0 12 * * 5", Question: "Where do we lunch?", Options: []string{"Pizza", "Sushi"}, MaxVotes: 1, Creator: "user1", ChannelId: "channel1"}
+		mockStore.On("CreateSchedule", mock.MatchedBy(func(s *entities.Schedule) bool {
+			return s.ScheduleId != "" && s.NextRunAt == time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC).Unix() && s.CreatedAt == now.Unix()
+		})).Return(nil).Once()
+
+		msg, err := pollService.CreateSchedule(schedule, now)
+		require.NoError(t, err)
+		require.Equal(t, "*Schedule*: `"+schedule.ScheduleId+"` **has been created!** *Next poll*: 2025-01-03 12:00 UTC", msg.String())
+	})
+
+	tests := []struct {
+		name     string
+		schedule *entities.Schedule
+		err      string
+	}{
+		{"invalid cron", &entities.Schedule{Cron: "0 25 * * *", Options: []string{"A", "B"}, MaxVotes: 1},
+			"**Invalid schedule!** hour out of range 0-23: `25`. *Expected*: five cron fields in UTC (e.g. `0 12 * * 5`)"},
+		{"never runs", &entities.Schedule{Cron: "0 0 30 2 *", Options: []string{"A", "B"}, MaxVotes: 1},
+			"**Invalid schedule!** `0 0 30 2 *` never runs"},
+		{"invalid poll", &entities.Schedule{Cron: "@daily", Options: []string{"A", "B"}, MaxVotes: 3},
+			"**Invalid number of votes per user!** *Expected*: from `0` (unlimited) to `2`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pollService := services.NewPollService(service_mocks.NewBotInterface(t), store_mocks.NewStoreInterface(t), testConfig)
+
+			msg, err := pollService.CreateSchedule(tt.schedule, now)
+			require.Error(t, err)
+			require.Empty(t, msg)
+			require.Equal(t, tt.err, err.Error())
+		})
+	}
+}
+
+// TestResumeSchedule проверяет пересчет времени следующего запуска при возобновлении расписания.
+func TestResumeSchedule(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+	schedule := &entities.Schedule{ScheduleId: "schedule1", Cron: "0 12 * * *", Creator: "user1", Paused: true, NextRunAt: 1}
+
+	t.Run("success resumed schedule", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		pollService := services.NewPollService(service_mocks.NewBotInterface(t), mockStore, testConfig)
+
+		next := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).Unix()
+		mockStore.On("GetSchedule", "schedule1").Return(schedule, nil).Once()
+		mockStore.On("ResumeSchedule", "schedule1", "user1", next).Return(entities.NewMessage("schedule.resumed", "schedule1"), nil).Once()
+
+		msg, err := pollService.ResumeSchedule("schedule1", "user1", now)
+		require.NoError(t, err)
+		require.Equal(t, "*Schedule*: `schedule1` **has been resumed!** *Next poll*: 2025-01-01 12:00 UTC", msg.String())
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		pollService := services.NewPollService(service_mocks.NewBotInterface(t), mockStore, testConfig)
+
+		mockStore.On("GetSchedule", "schedule1").Return(schedule, nil).Once()
+		mockStore.On("ResumeSchedule", "schedule1", "user2", mock.Anything).Return(nil, entities.NewUserError("schedule.resume_forbidden")).Once()
+
+		_, err := pollService.ResumeSchedule("schedule1", "user2", now)
+		require.EqualError(t, err, "**You don't have the permission to resume a schedule!**")
+	})
+}
+
+// TestRunDueSchedules проверяет создание опросов по расписаниям, время запуска которых наступило.
+func TestRunDueSchedules(t *testing.T) {
+	now := time.Date(2025, 1, 3, 12, 0, 30, 0, time.UTC)
+	schedule := &entities.Schedule{
+		ScheduleId: "schedule1",
+		Cron:       "0 12 * * 5",
+		Question:   "Where do we lunch?",
+		Options:    []string{"Pizza", "Sushi"},
+		Creator:    "user1",
+		ChannelId:  "channel1",
+		TeamId:     "team1",
+		MaxVotes:   1,
+		Duration:   7200,
+		NextRunAt:  now.Unix() - 30,
+	}
+	next := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC).Unix()
+
+	t.Run("success created poll", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+		mockStore.On("ListDueSchedules", now.Unix()).Return([]*entities.Schedule{schedule}, nil)
+		mockStore.On("SetScheduleNextRun", "schedule1", next).Return(nil).Once()
+		mockStore.On("CreatePoll", mock.MatchedBy(func(poll *entities.Poll) bool {
+			return poll.Question == "Where do we lunch?" && len(poll.Options) == 2 && poll.Creator == "user1" &&
+				poll.ChannelId == "channel1" && poll.TeamId == "team1" && poll.EndsAt == now.Unix()+7200
+		})).Return(nil).Once()
+		mockBot.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+			return post.ChannelId == "channel1"
+		})).Return(&model.Post{Id: "post1"}, &model.Response{StatusCode: 201}, nil).Once()
+		mockStore.On("SetPollPost", mock.Anything, "post1").Return(nil).Once()
+
+		pollService.RunDueSchedules(now)
+	})
+
+	t.Run("failed to post poll", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, mockStore, testConfig)
+
+		mockStore.On("ListDueSchedules", now.Unix()).Return([]*entities.Schedule{schedule}, nil)
+		mockStore.On("SetScheduleNextRun", "schedule1", next).Return(nil).Once()
+		mockStore.On("CreatePoll", mock.Anything).Return(nil).Once()
+		mockBot.On("CreatePost", mock.Anything).Return(nil, &model.Response{StatusCode: 403}, errors.New("forbidden")).Once()
+		mockStore.On("DeletePoll", mock.Anything, "user1", false).Return(entities.NewMessage("poll.deleted", "poll1"), nil).Once()
+
+		pollService.RunDueSchedules(now)
+		mockStore.AssertCalled(t, "DeletePoll", mock.Anything, "user1", false)
+	})
+
+	t.Run("failed to set next run", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		pollService := services.NewPollService(service_mocks.NewBotInterface(t), mockStore, testConfig)
+
+		mockStore.On("ListDueSchedules", now.Unix()).Return([]*entities.Schedule{schedule}, nil)
+		mockStore.On("SetScheduleNextRun", "schedule1", next).Return(errors.New("connection refused")).Once()
+
+		pollService.RunDueSchedules(now)
+		mockStore.AssertNotCalled(t, "CreatePoll", mock.Anything)
+	})
+
+	t.Run("failed to list due schedules", func(t *testing.T) {
+		mockStore := store_mocks.NewStoreInterface(t)
+		pollService := services.NewPollService(service_mocks.NewBotInterface(t), mockStore, testConfig)
+
+		mockStore.On("ListDueSchedules", now.Unix()).Return(nil, errors.New("connection refused"))
+
+		pollService.RunDueSchedules(now)
+		mockStore.AssertNotCalled(t, "SetScheduleNextRun", mock.Anything, mock.Anything)
+	})
+}
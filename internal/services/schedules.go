@@ -0,0 +1,148 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/parser"
+	"matterpoll-bot/internal/storage"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// CreateSchedule проверяет расписание повторяющегося опроса и настройки создаваемых по нему опросов,
+// вычисляет время первого запуска после now и сохраняет расписание в хранилище.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) CreateSchedule(schedule *entities.Schedule, now time.Time) (*entities.Message, error) {
+	cron, err := parser.ParseCron(schedule.Cron)
+	if err != nil {
+		return nil, entities.NewUserError("schedule.invalid_cron", err)
+	}
+
+	if err := storage.ValidatePoll(newScheduledPoll(schedule, now)); err != nil {
+		return nil, err
+	}
+
+	next := cron.Next(now)
+	if next.IsZero() {
+		return nil, entities.NewUserError("schedule.never_runs", schedule.Cron)
+	}
+
+	schedule.ScheduleId = model.NewId()
+	schedule.NextRunAt = next.Unix()
+	schedule.CreatedAt = now.Unix()
+	if err := ps.store.CreateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	return entities.NewMessage("schedule.created", schedule.ScheduleId, storage.PrintScheduleTime(schedule.NextRunAt)), nil
+}
+
+// ListSchedules возвращает таблицу расписаний повторяющихся опросов канала channelId на языке locale.
+func (ps *PollService) ListSchedules(channelId, locale string) (string, error) {
+	schedules, err := ps.store.ListSchedules(channelId)
+	if err != nil {
+		return "", err
+	}
+
+	return storage.PrintSchedules(schedules, locale), nil
+}
+
+// PauseSchedule приостанавливает расписание scheduleId от имени пользователя userId.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) PauseSchedule(scheduleId, userId string) (*entities.Message, error) {
+	return ps.store.PauseSchedule(scheduleId, userId)
+}
+
+// ResumeSchedule возобновляет расписание scheduleId от имени пользователя userId.
+// Время следующего запуска отсчитывается от now и сохраняется тем же изменением, что и снятие паузы,
+// поэтому запуски, пропущенные во время паузы, не выполняются.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) ResumeSchedule(scheduleId, userId string, now time.Time) (*entities.Message, error) {
+	schedule, err := ps.store.GetSchedule(scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	cron, err := parser.ParseCron(schedule.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schedule '%s': %w", scheduleId, err)
+	}
+
+	next := scheduleTime(cron.Next(now))
+	if _, err := ps.store.ResumeSchedule(scheduleId, userId, next); err != nil {
+		return nil, err
+	}
+
+	return entities.NewMessage("schedule.resumed_next_poll", scheduleId, storage.PrintScheduleTime(next)), nil
+}
+
+// DeleteSchedule удаляет расписание scheduleId от имени пользователя userId.
+// Опросы, уже созданные по расписанию, не изменяются.
+// Возвращает сообщение с результатом и ошибку, если операция завершилась неудачно.
+func (ps *PollService) DeleteSchedule(scheduleId, userId string) (*entities.Message, error) {
+	return ps.store.DeleteSchedule(scheduleId, userId)
+}
+
+// RunDueSchedules создает и публикует опросы по расписаниям, время запуска которых наступило к моменту now.
+// Время следующего запуска сохраняется до создания опроса, поэтому при ошибке опрос не создается повторно
+// при каждой проверке, а опрос, который не удалось опубликовать, удаляется.
+// Если бот был остановлен, пропущенные запуски заменяются одним опросом.
+// Ошибки только логируются, чтобы не прерывать обработку остальных расписаний.
+func (ps *PollService) RunDueSchedules(now time.Time) {
+	schedules, err := ps.store.ListDueSchedules(now.Unix())
+	if err != nil {
+		log.Printf("failed to list due schedules: %v\n", err)
+		return
+	}
+
+	for _, schedule := range schedules {
+		cron, err := parser.ParseCron(schedule.Cron)
+		if err != nil {
+			log.Printf("failed to parse schedule '%s': %v\n", schedule.ScheduleId, err)
+			continue
+		}
+
+		if err := ps.store.SetScheduleNextRun(schedule.ScheduleId, scheduleTime(cron.Next(now))); err != nil {
+			log.Printf("failed to set next run of schedule '%s': %v\n", schedule.ScheduleId, err)
+			continue
+		}
+
+		poll := newScheduledPoll(schedule, now)
+		if err := ps.CreatePoll(poll); err != nil {
+			log.Printf("failed to create poll by schedule '%s': %v\n", schedule.ScheduleId, err)
+			continue
+		}
+
+		if err := ps.PostPoll(poll); err != nil {
+			ps.DiscardPoll(poll)
+			log.Printf("failed to post poll by schedule '%s': %v\n", schedule.ScheduleId, err)
+		}
+	}
+}
+
+// newScheduledPoll возвращает новый опрос, созданный по расписанию schedule в момент now.
+func newScheduledPoll(schedule *entities.Schedule, now time.Time) *entities.Poll {
+	poll := NewPoll(schedule.Question, schedule.Options, schedule.Creator)
+	poll.MaxVotes = schedule.MaxVotes
+	poll.Public = schedule.Public
+	poll.Ranked = schedule.Ranked
+	poll.ChannelId = schedule.ChannelId
+	poll.TeamId = schedule.TeamId
+	poll.CreatedAt = now.Unix()
+	if schedule.Duration > 0 {
+		poll.EndsAt = now.Unix() + schedule.Duration
+	}
+
+	return poll
+}
+
+// scheduleTime возвращает время запуска в формате Unix или 0, если запусков больше не будет.
+func scheduleTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
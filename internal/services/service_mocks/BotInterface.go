@@ -51,6 +51,45 @@ func (_m *BotInterface) CreateCommand(cmd *model.Command) (*model.Command, *mode
 	return r0, r1, r2
 }
 
+// CreateDirectChannel provides a mock function with given fields: userId1, userId2
+func (_m *BotInterface) CreateDirectChannel(userId1 string, userId2 string) (*model.Channel, *model.Response, error) {
+	ret := _m.Called(userId1, userId2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDirectChannel")
+	}
+
+	var r0 *model.Channel
+	var r1 *model.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string) (*model.Channel, *model.Response, error)); ok {
+		return rf(userId1, userId2)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *model.Channel); ok {
+		r0 = rf(userId1, userId2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Channel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) *model.Response); ok {
+		r1 = rf(userId1, userId2)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(userId1, userId2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // CreatePost provides a mock function with given fields: post
 func (_m *BotInterface) CreatePost(post *model.Post) (*model.Post, *model.Response, error) {
 	ret := _m.Called(post)
@@ -90,6 +129,162 @@ func (_m *BotInterface) CreatePost(post *model.Post) (*model.Post, *model.Respon
 	return r0, r1, r2
 }
 
+// GetChannelMember provides a mock function with given fields: channelId, userId, etag
+func (_m *BotInterface) GetChannelMember(channelId string, userId string, etag string) (*model.ChannelMember, *model.Response, error) {
+	ret := _m.Called(channelId, userId, etag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelMember")
+	}
+
+	var r0 *model.ChannelMember
+	var r1 *model.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*model.ChannelMember, *model.Response, error)); ok {
+		return rf(channelId, userId, etag)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *model.ChannelMember); ok {
+		r0 = rf(channelId, userId, etag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) *model.Response); ok {
+		r1 = rf(channelId, userId, etag)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string, string) error); ok {
+		r2 = rf(channelId, userId, etag)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetChannelStats provides a mock function with given fields: channelId, etag
+func (_m *BotInterface) GetChannelStats(channelId string, etag string) (*model.ChannelStats, *model.Response, error) {
+	ret := _m.Called(channelId, etag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelStats")
+	}
+
+	var r0 *model.ChannelStats
+	var r1 *model.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string) (*model.ChannelStats, *model.Response, error)); ok {
+		return rf(channelId, etag)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *model.ChannelStats); ok {
+		r0 = rf(channelId, etag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) *model.Response); ok {
+		r1 = rf(channelId, etag)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(channelId, etag)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetGroups provides a mock function with given fields: opts
+func (_m *BotInterface) GetGroups(opts model.GroupSearchOpts) ([]*model.Group, *model.Response, error) {
+	ret := _m.Called(opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroups")
+	}
+
+	var r0 []*model.Group
+	var r1 *model.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(model.GroupSearchOpts) ([]*model.Group, *model.Response, error)); ok {
+		return rf(opts)
+	}
+	if rf, ok := ret.Get(0).(func(model.GroupSearchOpts) []*model.Group); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model.GroupSearchOpts) *model.Response); ok {
+		r1 = rf(opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(model.GroupSearchOpts) error); ok {
+		r2 = rf(opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMe provides a mock function with given fields: etag
+func (_m *BotInterface) GetMe(etag string) (*model.User, *model.Response, error) {
+	ret := _m.Called(etag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMe")
+	}
+
+	var r0 *model.User
+	var r1 *model.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (*model.User, *model.Response, error)); ok {
+		return rf(etag)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.User); ok {
+		r0 = rf(etag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) *model.Response); ok {
+		r1 = rf(etag)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(etag)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetTeamByName provides a mock function with given fields: teamName, etag
 func (_m *BotInterface) GetTeamByName(teamName string, etag string) (*model.Team, *model.Response, error) {
 	ret := _m.Called(teamName, etag)
@@ -129,6 +324,201 @@ func (_m *BotInterface) GetTeamByName(teamName string, etag string) (*model.Team
 	return r0, r1, r2
 }
 
+// GetTeamMember provides a mock function with given fields: teamId, userId, etag
+func (_m *BotInterface) GetTeamMember(teamId string, userId string, etag string) (*model.TeamMember, *model.Response, error) {
+	ret := _m.Called(teamId, userId, etag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamMember")
+	}
+
+	var r0 *model.TeamMember
+	var r1 *model.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*model.TeamMember, *model.Response, error)); ok {
+		return rf(teamId, userId, etag)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *model.TeamMember); ok {
+		r0 = rf(teamId, userId, etag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TeamMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) *model.Response); ok {
+		r1 = rf(teamId, userId, etag)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string, string) error); ok {
+		r2 = rf(teamId, userId, etag)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUser provides a mock function with given fields: userId, etag
+func (_m *BotInterface) GetUser(userId string, etag string) (*model.User, *model.Response, error) {
+	ret := _m.Called(userId, etag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *model.User
+	var r1 *model.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string) (*model.User, *model.Response, error)); ok {
+		return rf(userId, etag)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *model.User); ok {
+		r0 = rf(userId, etag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) *model.Response); ok {
+		r1 = rf(userId, etag)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(userId, etag)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUserByUsername provides a mock function with given fields: userName, etag
+func (_m *BotInterface) GetUserByUsername(userName string, etag string) (*model.User, *model.Response, error) {
+	ret := _m.Called(userName, etag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByUsername")
+	}
+
+	var r0 *model.User
+	var r1 *model.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string) (*model.User, *model.Response, error)); ok {
+		return rf(userName, etag)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *model.User); ok {
+		r0 = rf(userName, etag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) *model.Response); ok {
+		r1 = rf(userName, etag)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(userName, etag)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUsersByIds provides a mock function with given fields: userIds
+func (_m *BotInterface) GetUsersByIds(userIds []string) ([]*model.User, *model.Response, error) {
+	ret := _m.Called(userIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersByIds")
+	}
+
+	var r0 []*model.User
+	var r1 *model.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*model.User, *model.Response, error)); ok {
+		return rf(userIds)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*model.User); ok {
+		r0 = rf(userIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) *model.Response); ok {
+		r1 = rf(userIds)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func([]string) error); ok {
+		r2 = rf(userIds)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUsersInGroup provides a mock function with given fields: groupID, page, perPage, etag
+func (_m *BotInterface) GetUsersInGroup(groupID string, page int, perPage int, etag string) ([]*model.User, *model.Response, error) {
+	ret := _m.Called(groupID, page, perPage, etag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersInGroup")
+	}
+
+	var r0 []*model.User
+	var r1 *model.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, int, int, string) ([]*model.User, *model.Response, error)); ok {
+		return rf(groupID, page, perPage, etag)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int, string) []*model.User); ok {
+		r0 = rf(groupID, page, perPage, etag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int, string) *model.Response); ok {
+		r1 = rf(groupID, page, perPage, etag)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, int, int, string) error); ok {
+		r2 = rf(groupID, page, perPage, etag)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListCommands provides a mock function with given fields: teamId, customOnly
 func (_m *BotInterface) ListCommands(teamId string, customOnly bool) ([]*model.Command, *model.Response, error) {
 	ret := _m.Called(teamId, customOnly)
@@ -168,11 +558,119 @@ func (_m *BotInterface) ListCommands(teamId string, customOnly bool) ([]*model.C
 	return r0, r1, r2
 }
 
+// OpenInteractiveDialog provides a mock function with given fields: request
+func (_m *BotInterface) OpenInteractiveDialog(request model.OpenDialogRequest) (*model.Response, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenInteractiveDialog")
+	}
+
+	var r0 *model.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.OpenDialogRequest) (*model.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(model.OpenDialogRequest) *model.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model.OpenDialogRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchPost provides a mock function with given fields: postId, patch
+func (_m *BotInterface) PatchPost(postId string, patch *model.PostPatch) (*model.Post, *model.Response, error) {
+	ret := _m.Called(postId, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchPost")
+	}
+
+	var r0 *model.Post
+	var r1 *model.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, *model.PostPatch) (*model.Post, *model.Response, error)); ok {
+		return rf(postId, patch)
+	}
+	if rf, ok := ret.Get(0).(func(string, *model.PostPatch) *model.Post); ok {
+		r0 = rf(postId, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *model.PostPatch) *model.Response); ok {
+		r1 = rf(postId, patch)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, *model.PostPatch) error); ok {
+		r2 = rf(postId, patch)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // SetToken provides a mock function with given fields: token
 func (_m *BotInterface) SetToken(token string) {
 	_m.Called(token)
 }
 
+// UploadFile provides a mock function with given fields: data, channelId, filename
+func (_m *BotInterface) UploadFile(data []byte, channelId string, filename string) (*model.FileUploadResponse, *model.Response, error) {
+	ret := _m.Called(data, channelId, filename)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadFile")
+	}
+
+	var r0 *model.FileUploadResponse
+	var r1 *model.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func([]byte, string, string) (*model.FileUploadResponse, *model.Response, error)); ok {
+		return rf(data, channelId, filename)
+	}
+	if rf, ok := ret.Get(0).(func([]byte, string, string) *model.FileUploadResponse); ok {
+		r0 = rf(data, channelId, filename)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileUploadResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte, string, string) *model.Response); ok {
+		r1 = rf(data, channelId, filename)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func([]byte, string, string) error); ok {
+		r2 = rf(data, channelId, filename)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewBotInterface creates a new instance of BotInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewBotInterface(t interface {
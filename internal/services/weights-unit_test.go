@@ -0,0 +1,83 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"matterpoll-bot/internal/services"
+	"matterpoll-bot/internal/services/service_mocks"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResolveWeights проверяет преобразование весов пользователей и групп в веса по идентификаторам пользователей.
+func TestResolveWeights(t *testing.T) {
+	notFound := &model.Response{StatusCode: 404}
+	committee := "committee"
+
+	t.Run("users and groups", func(t *testing.T) {
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, nil, testConfig)
+
+		mockBot.On("GetUserByUsername", "alice", "").Return(&model.User{Id: "user1"}, &model.Response{StatusCode: 200}, nil).Once()
+		mockBot.On("GetUserByUsername", "committee", "").Return(nil, notFound, errors.New("not found")).Once()
+		mockBot.On("GetGroups", model.GroupSearchOpts{Q: "committee"}).Return([]*model.Group{
+			{Id: "group2", Name: new(string)},
+			{Id: "group1", Name: &committee},
+		}, &model.Response{StatusCode: 200}, nil).Once()
+		mockBot.On("GetUsersInGroup", "group1", 0, mock.Anything, "").Return([]*model.User{{Id: "user1"}, {Id: "user2"}}, &model.Response{StatusCode: 200}, nil).Once()
+
+		weights, err := pollService.ResolveWeights(map[string]int32{"alice": 2, "committee": 5})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int32{"user1": 2, "user2": 5}, weights)
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, nil, testConfig)
+
+		mockBot.On("GetUserByUsername", "bob", "").Return(nil, notFound, errors.New("not found")).Once()
+		mockBot.On("GetGroups", model.GroupSearchOpts{Q: "bob"}).Return([]*model.Group{}, &model.Response{StatusCode: 200}, nil).Once()
+
+		weights, err := pollService.ResolveWeights(map[string]int32{"bob": 2})
+		require.Nil(t, weights)
+		require.EqualError(t, err, "**User or group `@bob` not found!**")
+	})
+
+	t.Run("failed to get user", func(t *testing.T) {
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, nil, testConfig)
+
+		mockBot.On("GetUserByUsername", "alice", "").Return(nil, &model.Response{StatusCode: 500}, errors.New("internal error")).Once()
+
+		_, err := pollService.ResolveWeights(map[string]int32{"alice": 2})
+		require.EqualError(t, err, "failed to get user: internal error")
+	})
+
+	t.Run("empty responses", func(t *testing.T) {
+		mockBot := service_mocks.NewBotInterface(t)
+		pollService := services.NewPollService(mockBot, nil, testConfig)
+
+		mockBot.On("GetUserByUsername", "alice", "").Return(nil, nil, nil).Once()
+
+		_, err := pollService.ResolveWeights(map[string]int32{"alice": 2})
+		require.EqualError(t, err, "failed to get user: empty response")
+
+		mockBot.On("GetUserByUsername", "committee", "").Return(nil, notFound, errors.New("not found")).Once()
+		mockBot.On("GetGroups", model.GroupSearchOpts{Q: "committee"}).Return([]*model.Group{{Id: "group1", Name: &committee}}, &model.Response{StatusCode: 200}, nil).Once()
+		mockBot.On("GetUsersInGroup", "group1", 0, mock.Anything, "").Return(nil, nil, nil).Once()
+
+		_, err = pollService.ResolveWeights(map[string]int32{"committee": 2})
+		require.EqualError(t, err, "failed to get group members: empty response")
+	})
+
+	t.Run("without weights", func(t *testing.T) {
+		pollService := services.NewPollService(nil, nil, testConfig)
+
+		weights, err := pollService.ResolveWeights(nil)
+		require.NoError(t, err)
+		require.Nil(t, weights)
+	})
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+	"matterpoll-bot/internal/entities"
+	"net/http"
+	"sort"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// groupMembersPageSize - количество участников группы, запрашиваемых за один запрос.
+const groupMembersPageSize = 200
+
+// ResolveWeights преобразует таблицу весов, заданную по именам пользователей и групп Mattermost,
+// в веса голосов по идентификаторам пользователей.
+// Каждое имя сначала ищется среди пользователей, а затем среди групп; вес группы назначается всем ее участникам.
+// Если пользователь входит в несколько групп, ему назначается наибольший вес,
+// а вес, заданный пользователю по имени, имеет приоритет над весами групп.
+// Возвращает пользовательскую ошибку, если пользователь или группа с указанным именем не найдены.
+func (ps *PollService) ResolveWeights(names map[string]int32) (map[string]int32, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	userWeights := map[string]int32{}
+	weights := map[string]int32{}
+	for _, name := range sorted {
+		user, resp, err := ps.Bot.GetUserByUsername(name, "")
+		if err == nil && resp != nil && resp.StatusCode == 200 {
+			userWeights[user.Id] = names[name]
+			continue
+		}
+
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			if err == nil {
+				err = checkResponse(resp, http.StatusOK)
+			}
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+
+		members, err := ps.groupMembers(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, userId := range members {
+			weights[userId] = max(weights[userId], names[name])
+		}
+	}
+
+	for userId, weight := range userWeights {
+		weights[userId] = weight
+	}
+
+	return weights, nil
+}
+
+// groupMembers возвращает идентификаторы участников группы Mattermost с именем name.
+func (ps *PollService) groupMembers(name string) ([]string, error) {
+	groups, resp, err := ps.Bot.GetGroups(model.GroupSearchOpts{Q: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups: %w", err)
+	}
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get groups: %w", err)
+	}
+
+	var group *model.Group
+	for _, g := range groups {
+		if g.Name != nil && *g.Name == name {
+			group = g
+			break
+		}
+	}
+	if group == nil {
+		return nil, entities.NewUserError("poll.user_not_found", name)
+	}
+
+	var members []string
+	for page := 0; ; page++ {
+		users, resp, err := ps.Bot.GetUsersInGroup(group.Id, page, groupMembersPageSize, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get group members: %w", err)
+		}
+
+		if err := checkResponse(resp, http.StatusOK); err != nil {
+			return nil, fmt.Errorf("failed to get group members: %w", err)
+		}
+
+		for _, user := range users {
+			members = append(members, user.Id)
+		}
+		if len(users) < groupMembersPageSize {
+			return members, nil
+		}
+	}
+}
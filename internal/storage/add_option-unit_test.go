@@ -0,0 +1,73 @@
+package storage_test
+
+import (
+	"fmt"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/storage"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestAddOption тестирует добавление участником нового варианта в опрос.
+func TestAddOption(t *testing.T) {
+	newPoll := func() *entities.Poll {
+		return &entities.Poll{
+			PollId:      "poll1",
+			Options:     map[string]int32{"1": 1, "2": 0},
+			Voters:      map[string][]string{"user1": {"1"}},
+			MaxVotes:    1,
+			OpenOptions: true,
+		}
+	}
+
+	t.Run("Valid option", func(t *testing.T) {
+		poll := newPoll()
+
+		err := storage.AddOption(poll, "  3 ")
+		require.NoError(t, err)
+		require.Equal(t, map[string]int32{"1": 1, "2": 0, "3": 0}, poll.Options)
+	})
+
+	manyOptions := newPoll()
+	for i := len(manyOptions.Options); i < storage.MaxOptions; i++ {
+		manyOptions.Options[fmt.Sprint("option", i)] = 0
+	}
+	closedPoll := newPoll()
+	closedPoll.Closed = true
+	fixedPoll := newPoll()
+	fixedPoll.OpenOptions = false
+
+	tests := []struct {
+		name   string
+		poll   *entities.Poll
+		option string
+		err    string
+	}{
+		{"Closed poll", closedPoll, "3", "*Poll*: `poll1` **is already closed!**"},
+		{"Fixed options", fixedPoll, "3", "**This poll doesn't allow adding options!**"},
+		{"Empty option", newPoll(), "   ", "**Option can't be empty!**"},
+		{"Too long option", newPoll(), strings.Repeat("я", storage.MaxOptionLength+1), fmt.Sprintf("**Option is too long!** *Expected*: up to `%d` characters", storage.MaxOptionLength)},
+		{"Duplicated option", newPoll(), "2", "**Option `2` already exists!**"},
+		{"Too many options", manyOptions, "new", fmt.Sprintf("**The poll already has the maximum of %d options!**", storage.MaxOptions)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count := len(tt.poll.Options)
+
+			err := storage.AddOption(tt.poll, tt.option)
+			require.Error(t, err)
+			require.Equal(t, tt.err, err.Error())
+			require.Len(t, tt.poll.Options, count)
+		})
+	}
+
+	t.Run("Maximum length", func(t *testing.T) {
+		poll := newPoll()
+
+		err := storage.AddOption(poll, strings.Repeat("я", storage.MaxOptionLength))
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"matterpoll-bot/internal/entities"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MaxOptions      = 25  // MaxOptions - максимальное количество вариантов, до которого участники могут дополнять опрос.
+	MaxOptionLength = 100 // MaxOptionLength - максимальная длина варианта, добавляемого участником (в символах).
+)
+
+// AddOption добавляет в опрос с открытыми вариантами новый вариант option без голосов.
+// Вариант не должен быть пустым, длиннее MaxOptionLength или совпадать с существующим,
+// а общее количество вариантов не может превышать MaxOptions.
+func AddOption(poll *entities.Poll, option string) error {
+	if poll.Closed {
+		return entities.NewUserError("poll.already_closed", poll.PollId)
+	}
+	if !poll.OpenOptions {
+		return entities.NewUserError("option.not_allowed")
+	}
+
+	option = strings.TrimSpace(option)
+	if option == "" {
+		return entities.NewUserError("option.empty")
+	}
+	if utf8.RuneCountInString(option) > MaxOptionLength {
+		return entities.NewUserError("option.too_long", MaxOptionLength)
+	}
+	if _, exists := poll.Options[option]; exists {
+		return entities.NewUserError("option.exists", option)
+	}
+	if len(poll.Options) >= MaxOptions {
+		return entities.NewUserError("option.too_many", MaxOptions)
+	}
+
+	poll.Options[option] = 0
+
+	return nil
+}
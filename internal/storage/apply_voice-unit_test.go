@@ -0,0 +1,217 @@
+package storage_test
+
+import (
+	"fmt"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/storage"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestAddVoice тестирует функционал учета голоса в опросе.
+func TestAddVoice(t *testing.T) {
+	t.Run("Valid voice", func(t *testing.T) {
+		poll := &entities.Poll{
+			Options:  map[string]int32{"1": 0, "2": 0},
+			Voters:   map[string][]string{},
+			MaxVotes: 1,
+		}
+
+		err := storage.AddVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Option: "1"})
+		require.NoError(t, err)
+		require.Equal(t, int32(1), poll.Options["1"])
+		require.Equal(t, []string{"1"}, poll.Voters["user1"])
+	})
+
+	t.Run("Invalid voice", func(t *testing.T) {
+		poll := &entities.Poll{
+			Options:  map[string]int32{"1": 1, "2": 0},
+			Voters:   map[string][]string{"user1": {"1"}},
+			MaxVotes: 1,
+		}
+
+		err := storage.AddVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Option: "2"})
+		require.Error(t, err)
+		require.Equal(t, "**You can't vote again!**", err.Error())
+		require.Equal(t, int32(0), poll.Options["2"])
+	})
+}
+
+// TestRemoveVoice тестирует функционал отзыва голоса в опросе.
+func TestRemoveVoice(t *testing.T) {
+	newPoll := func() *entities.Poll {
+		return &entities.Poll{
+			Options:  map[string]int32{"1": 2, "2": 1, "3": 0},
+			Voters:   map[string][]string{"user1": {"1", "2"}, "user2": {"1"}},
+			MaxVotes: 2,
+		}
+	}
+
+	t.Run("Retract one option", func(t *testing.T) {
+		poll := newPoll()
+
+		err := storage.RemoveVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Option: "2"})
+		require.NoError(t, err)
+		require.Equal(t, int32(0), poll.Options["2"])
+		require.Equal(t, int32(2), poll.Options["1"])
+		require.Equal(t, []string{"1"}, poll.Voters["user1"])
+	})
+
+	t.Run("Retract all options", func(t *testing.T) {
+		poll := newPoll()
+
+		err := storage.RemoveVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1"})
+		require.NoError(t, err)
+		require.Equal(t, int32(1), poll.Options["1"])
+		require.Equal(t, int32(0), poll.Options["2"])
+		require.NotContains(t, poll.Voters, "user1")
+		require.Equal(t, []string{"1"}, poll.Voters["user2"])
+	})
+
+	t.Run("Not voted", func(t *testing.T) {
+		poll := newPoll()
+
+		err := storage.RemoveVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user3"})
+		require.Error(t, err)
+		require.Equal(t, "**You haven't voted in this poll!**", err.Error())
+	})
+
+	t.Run("Not voted for option", func(t *testing.T) {
+		poll := newPoll()
+
+		err := storage.RemoveVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user2", Option: "2"})
+		require.Error(t, err)
+		require.Equal(t, "**You haven't voted for this option!**", err.Error())
+		require.Equal(t, int32(1), poll.Options["2"])
+	})
+
+	t.Run("Closed poll", func(t *testing.T) {
+		poll := newPoll()
+		poll.Closed = true
+
+		err := storage.RemoveVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1"})
+		require.Error(t, err)
+		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **is already closed!**", "poll1"), err.Error())
+		require.Equal(t, int32(2), poll.Options["1"])
+	})
+
+	t.Run("Legacy vote", func(t *testing.T) {
+		poll := newPoll()
+		poll.Voters["user3"] = []string{entities.LegacyChoice}
+
+		err := storage.RemoveVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user3"})
+		require.Equal(t, entities.NewUserError("vote.legacy"), err)
+		require.Equal(t, map[string]int32{"1": 2, "2": 1, "3": 0}, poll.Options)
+
+		err = storage.ChangeVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user3", Option: "3"})
+		require.Equal(t, entities.NewUserError("vote.legacy"), err)
+		require.Equal(t, []string{entities.LegacyChoice}, poll.Voters["user3"])
+		require.Equal(t, map[string]int32{"1": 2, "2": 1, "3": 0}, poll.Options)
+	})
+}
+
+// TestChangeVoice тестирует функционал изменения голоса в опросе.
+func TestChangeVoice(t *testing.T) {
+	t.Run("Valid change", func(t *testing.T) {
+		poll := &entities.Poll{
+			Options:  map[string]int32{"1": 1, "2": 0},
+			Voters:   map[string][]string{"user1": {"1"}},
+			MaxVotes: 1,
+		}
+
+		err := storage.ChangeVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Option: "2"})
+		require.NoError(t, err)
+		require.Equal(t, int32(0), poll.Options["1"])
+		require.Equal(t, int32(1), poll.Options["2"])
+		require.Equal(t, []string{"2"}, poll.Voters["user1"])
+	})
+
+	t.Run("Invalid option", func(t *testing.T) {
+		poll := &entities.Poll{
+			Options:  map[string]int32{"1": 1, "2": 0},
+			Voters:   map[string][]string{"user1": {"1"}},
+			MaxVotes: 1,
+		}
+
+		err := storage.ChangeVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Option: "3"})
+		require.Error(t, err)
+		require.Equal(t, "**Invalid option!**", err.Error())
+		require.Equal(t, int32(1), poll.Options["1"])
+		require.Equal(t, []string{"1"}, poll.Voters["user1"])
+	})
+
+	t.Run("Not voted", func(t *testing.T) {
+		poll := &entities.Poll{
+			Options:  map[string]int32{"1": 0, "2": 0},
+			Voters:   map[string][]string{},
+			MaxVotes: 1,
+		}
+
+		err := storage.ChangeVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Option: "2"})
+		require.Error(t, err)
+		require.Equal(t, "**You haven't voted in this poll!**", err.Error())
+	})
+}
+
+// TestRankedVoice тестирует учет, изменение и отзыв бюллетеня в рейтинговом опросе.
+func TestRankedVoice(t *testing.T) {
+	poll := &entities.Poll{
+		Options:  map[string]int32{"1": 0, "2": 0, "3": 0},
+		Voters:   map[string][]string{},
+		MaxVotes: 1,
+		Ranked:   true,
+	}
+
+	err := storage.AddVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Ranking: []string{"2", "1"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"2", "1"}, poll.Voters["user1"])
+	require.Equal(t, map[string]int32{"1": 0, "2": 1, "3": 0}, poll.Options)
+
+	err = storage.ChangeVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Ranking: []string{"3", "2", "1"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"3", "2", "1"}, poll.Voters["user1"])
+	require.Equal(t, map[string]int32{"1": 0, "2": 0, "3": 1}, poll.Options)
+
+	err = storage.ChangeVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Ranking: []string{"3", "3"}})
+	require.Error(t, err)
+	require.Equal(t, []string{"3", "2", "1"}, poll.Voters["user1"])
+	require.Equal(t, map[string]int32{"1": 0, "2": 0, "3": 1}, poll.Options)
+
+	err = storage.RemoveVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Option: "2"})
+	require.Error(t, err)
+	require.Equal(t, "**Ranked ballots can only be retracted entirely!**", err.Error())
+
+	err = storage.RemoveVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1"})
+	require.NoError(t, err)
+	require.Empty(t, poll.Voters)
+	require.Equal(t, map[string]int32{"1": 0, "2": 0, "3": 0}, poll.Options)
+}
+
+// TestWeightedVoice тестирует учет и отзыв голосов с весами во взвешенном опросе.
+func TestWeightedVoice(t *testing.T) {
+	poll := &entities.Poll{
+		Options:  map[string]int32{"1": 0, "2": 0},
+		Voters:   map[string][]string{},
+		MaxVotes: 1,
+		Weights:  map[string]int32{"user1": 3},
+	}
+
+	err := storage.AddVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Option: "1"})
+	require.NoError(t, err)
+	err = storage.AddVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user2", Option: "1"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int32{"1": 4, "2": 0}, poll.Options)
+
+	err = storage.ChangeVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Option: "2"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int32{"1": 1, "2": 3}, poll.Options)
+
+	err = storage.ChangeVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Option: "3"})
+	require.Error(t, err)
+	require.Equal(t, map[string]int32{"1": 1, "2": 3}, poll.Options)
+
+	err = storage.RemoveVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int32{"1": 1, "2": 0}, poll.Options)
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"matterpoll-bot/internal/entities"
+	"slices"
+)
+
+// AddVoice проверяет голос пользователя и учитывает его в опросе:
+// увеличивает счетчик выбранного варианта на вес голоса пользователя и сохраняет выбор пользователя.
+// В рейтинговом опросе сохраняется весь бюллетень, а счетчик увеличивается у первого предпочтения.
+func AddVoice(poll *entities.Poll, voice *entities.Voice) error {
+	if err := ValidateVoice(poll, voice); err != nil {
+		return err
+	}
+
+	if poll.Ranked {
+		poll.Options[voice.Ranking[0]] += VoterWeight(poll, voice.UserId)
+		poll.Voters[voice.UserId] = append([]string(nil), voice.Ranking...)
+		return nil
+	}
+
+	poll.Options[voice.Option] += VoterWeight(poll, voice.UserId)
+	poll.Voters[voice.UserId] = append(poll.Voters[voice.UserId], voice.Option)
+
+	return nil
+}
+
+// RemoveVoice отзывает голос пользователя в опросе: уменьшает счетчики отозванных вариантов на вес голоса пользователя
+// и удаляет их из выбора пользователя. Если вариант в голосе не указан, отзываются все варианты пользователя.
+func RemoveVoice(poll *entities.Poll, voice *entities.Voice) error {
+	if poll.Closed {
+		return entities.NewUserError("poll.already_closed", voice.PollId)
+	}
+
+	choices, voted := poll.Voters[voice.UserId]
+	if !voted {
+		return entities.NewUserError("vote.not_voted")
+	}
+
+	// Неизвестно, из счетчика какого варианта вычитать голос старого формата, поэтому его нельзя отозвать
+	if slices.Contains(choices, entities.LegacyChoice) {
+		return entities.NewUserError("vote.legacy")
+	}
+
+	// Бюллетень рейтингового опроса отзывается только целиком
+	if poll.Ranked {
+		if voice.Option != "" {
+			return entities.NewUserError("vote.ranked_retract_whole")
+		}
+		poll.Options[choices[0]] -= VoterWeight(poll, voice.UserId)
+		delete(poll.Voters, voice.UserId)
+		return nil
+	}
+
+	remaining := make([]string, 0, len(choices))
+	for _, choice := range choices {
+		if voice.Option == "" || choice == voice.Option {
+			poll.Options[choice] -= VoterWeight(poll, voice.UserId)
+			continue
+		}
+		remaining = append(remaining, choice)
+	}
+
+	if len(remaining) == len(choices) {
+		return entities.NewUserError("vote.option_not_voted")
+	}
+
+	if len(remaining) == 0 {
+		delete(poll.Voters, voice.UserId)
+	} else {
+		poll.Voters[voice.UserId] = remaining
+	}
+
+	return nil
+}
+
+// ChangeVoice заменяет все варианты, выбранные пользователем, на вариант из голоса
+// (в рейтинговом опросе — бюллетень пользователя на новый).
+// Изменения применяются к опросу только при успешной проверке нового голоса.
+func ChangeVoice(poll *entities.Poll, voice *entities.Voice) error {
+	choices := poll.Voters[voice.UserId]
+	if err := RemoveVoice(poll, &entities.Voice{PollId: voice.PollId, UserId: voice.UserId}); err != nil {
+		return err
+	}
+
+	if err := AddVoice(poll, voice); err != nil {
+		// Возвращаем прежний выбор пользователя
+		counted := choices
+		if poll.Ranked {
+			counted = choices[:1]
+		}
+		for _, choice := range counted {
+			poll.Options[choice] += VoterWeight(poll, voice.UserId)
+		}
+		poll.Voters[voice.UserId] = choices
+
+		return err
+	}
+
+	return nil
+}
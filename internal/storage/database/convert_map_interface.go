@@ -1,9 +1,14 @@
 package database
 
-import "fmt"
+import (
+	"fmt"
+	"matterpoll-bot/internal/entities"
+)
 
-// convertMapInterfaceToStringInt преобразует карту с ключами и значениями 
+// convertMapInterfaceToStringInt преобразует карту с ключами и значениями
 // типа interface{} в карту с ключами типа string и значениями типа int32.
+// Значения принимаются в любом целочисленном типе: кортежи, перезаписанные из Lua,
+// хранят небольшие числа в компактном виде, и они декодируются как int8 или uint8.
 // Если тип ключа или значения не соответствует ожидаемому, возвращается ошибка.
 func convertMapInterfaceToStringInt(input map[interface{}]interface{}) (map[string]int32, error) {
 	result := make(map[string]int32)
@@ -16,23 +21,25 @@ func convertMapInterfaceToStringInt(input map[interface{}]interface{}) (map[stri
 		}
 
 		// Приведение значения к int
-		valueInt, ok := value.(int32)
+		valueInt, ok := convertToInt64(value)
 		if !ok {
 			return nil, fmt.Errorf("unexpected value type: %v", value)
 		}
 
 		// Добавление в результирующую карту
-		result[keyStr] = valueInt
+		result[keyStr] = int32(valueInt)
 	}
 
 	return result, nil
 }
 
-// convertMapInterfaceToStringBool преобразует карту с ключами и значениями 
-// типа interface{} в карту с ключами типа string и значениями типа bool.
-// Если тип ключа или значения не соответствует ожидаемому, возвращается ошибка.
-func convertMapInterfaceToStringBool(input map[interface{}]interface{}) (map[string]bool, error) {
-	result := make(map[string]bool)
+// convertMapInterfaceToStringSlice преобразует карту с ключами и значениями
+// типа interface{} в карту с ключами типа string и значениями типа []string.
+// Значение true исходного формата, в котором хранился только факт голосования,
+// преобразуется в выбор из entities.LegacyChoice.
+// Если тип ключа или элемента значения не соответствует ожидаемому, возвращается ошибка.
+func convertMapInterfaceToStringSlice(input map[interface{}]interface{}) (map[string][]string, error) {
+	result := make(map[string][]string)
 
 	for key, value := range input {
 		// Приведение ключа к строке
@@ -41,15 +48,88 @@ func convertMapInterfaceToStringBool(input map[interface{}]interface{}) (map[str
 			return nil, fmt.Errorf("unexpected key type: %v", key)
 		}
 
-		// Приведение значения к int
-		valueBool, ok := value.(bool)
+		// Голос, сохраненный до хранения выбранных вариантов
+		if voted, ok := value.(bool); ok {
+			if voted {
+				result[keyStr] = []string{entities.LegacyChoice}
+			}
+			continue
+		}
+
+		// Приведение значения к слайсу строк
+		valueSlice, ok := value.([]interface{})
 		if !ok {
-			return nil, fmt.Errorf("unexpected value type: %v", valueBool)
+			return nil, fmt.Errorf("unexpected value type: %v", value)
+		}
+
+		strs := make([]string, 0, len(valueSlice))
+		for _, elem := range valueSlice {
+			elemStr, ok := elem.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected element type: %v", elem)
+			}
+			strs = append(strs, elemStr)
 		}
 
 		// Добавление в результирующую карту
-		result[keyStr] = valueBool
+		result[keyStr] = strs
 	}
 
 	return result, nil
 }
+
+// convertToStringSlice преобразует слайс типа []interface{} в слайс строк.
+// Если тип элемента не соответствует ожидаемому, возвращается ошибка.
+func convertToStringSlice(input []interface{}) ([]string, error) {
+	result := make([]string, 0, len(input))
+	for _, elem := range input {
+		elemStr, ok := elem.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected element type: %v", elem)
+		}
+		result = append(result, elemStr)
+	}
+
+	return result, nil
+}
+
+// convertToInt64 приводит целое число любого размера, полученное из Tarantool, к типу int64.
+func convertToInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// convertToFloat64 преобразует числовое значение в float64.
+// Целые значения также допускаются, так как Tarantool может вернуть целое число для поля типа double.
+func convertToFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+
+	if i, ok := convertToInt64(value); ok {
+		return float64(i), true
+	}
+
+	return 0, false
+}
@@ -8,6 +8,8 @@ import (
 	"matterpoll-bot/internal/storage/database"
 	"os"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -21,12 +23,15 @@ var (
 	conn *tarantool.Connection
 	d    *database.Database
 	poll = &entities.Poll{
-		PollId:   "valid_id",
-		Question: "test_question",
-		Options:  map[string]int32{"opt1": 0, "opt2": 0},
-		Voters:   map[string]bool{},
-		Creator:  "creator_id",
-		Closed:   false,
+		PollId:    "valid_id",
+		Question:  "test_question",
+		Options:   map[string]int32{"opt1": 0, "opt2": 0},
+		Voters:    map[string][]string{},
+		MaxVotes:  1,
+		Creator:   "creator_id",
+		Closed:    false,
+		TeamId:    "team_id",
+		CreatedAt: 1735689600,
 	}
 )
 
@@ -94,6 +99,70 @@ func TestCreatePoll(t *testing.T) {
 	require.Equal(t, poll, actualPoll)
 }
 
+// TestGetPoll проверяет получение опроса из базы данных.
+func TestGetPoll(t *testing.T) {
+	t.Cleanup(func() { truncateTable("polls", t) })
+	createTestPoll(poll, t)
+
+	actualPoll, err := d.GetPoll(poll.PollId)
+	require.NoError(t, err)
+	require.Equal(t, poll, actualPoll)
+
+	publicPoll := *poll
+	publicPoll.PollId = "public_id"
+	publicPoll.Public = true
+	createTestPoll(&publicPoll, t)
+
+	actualPoll, err = d.GetPoll(publicPoll.PollId)
+	require.NoError(t, err)
+	require.True(t, actualPoll.Public)
+
+	hiddenPoll := *poll
+	hiddenPoll.PollId = "hidden_id"
+	hiddenPoll.HideResults = true
+	createTestPoll(&hiddenPoll, t)
+
+	actualPoll, err = d.GetPoll(hiddenPoll.PollId)
+	require.NoError(t, err)
+	require.True(t, actualPoll.HideResults)
+
+	weightedPoll := *poll
+	weightedPoll.PollId = "weighted_id"
+	weightedPoll.Weights = map[string]int32{"user1": 3}
+	createTestPoll(&weightedPoll, t)
+
+	actualPoll, err = d.GetPoll(weightedPoll.PollId)
+	require.NoError(t, err)
+	require.Equal(t, weightedPoll.Weights, actualPoll.Weights)
+
+	decisionPoll := *poll
+	decisionPoll.PollId = "decision_id"
+	decisionPoll.Quorum, decisionPoll.QuorumPercent, decisionPoll.Threshold = 10, 50, 2.0/3
+	createTestPoll(&decisionPoll, t)
+
+	actualPoll, err = d.GetPoll(decisionPoll.PollId)
+	require.NoError(t, err)
+	require.Equal(t, &decisionPoll, actualPoll)
+
+	actualPoll, err = d.GetPoll("invalid_id")
+	require.Error(t, err)
+	require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
+	require.Nil(t, actualPoll)
+}
+
+// TestSetPollPost проверяет сохранение идентификатора сообщения с опросом.
+func TestSetPollPost(t *testing.T) {
+	t.Cleanup(func() { truncateTable("polls", t) })
+	createTestPoll(poll, t)
+
+	err := d.SetPollPost(poll.PollId, "post_id")
+	require.NoError(t, err)
+
+	updatedPoll, err := getPoll(poll.PollId)
+	require.NoError(t, err)
+	require.Equal(t, "post_id", updatedPoll.PostId)
+}
+
 // TestVote проверяет различные сценарии голосования.
 func TestVote(t *testing.T) {
 	t.Run("successful vote", func(t *testing.T) {
@@ -108,12 +177,12 @@ func TestVote(t *testing.T) {
 
 		msg, err := d.Vote(voice)
 		require.NoError(t, err)
-		require.Equal(t, "**Voice recorded!**", msg)
+		require.Equal(t, "**Voice recorded!**", msg.String())
 
 		updatedPoll, err := getPoll(poll.PollId)
 		require.NoError(t, err)
 		require.Equal(t, int32(1), updatedPoll.Options["opt1"])
-		require.True(t, updatedPoll.Voters["user_id_1"])
+		require.Equal(t, []string{"opt1"}, updatedPoll.Voters["user_id_1"])
 	})
 
 	t.Run("invalid poll_id", func(t *testing.T) {
@@ -158,6 +227,42 @@ func TestVote(t *testing.T) {
 		require.Empty(t, msg)
 	})
 
+	t.Run("multiple choice", func(t *testing.T) {
+		multiPoll := *poll
+		multiPoll.MaxVotes = 2
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(&multiPoll, t)
+
+		for _, option := range []string{"opt1", "opt2"} {
+			msg, err := d.Vote(&entities.Voice{PollId: "valid_id", UserId: "user_id_5", Option: option})
+			require.NoError(t, err)
+			require.Equal(t, "**Voice recorded!**", msg.String())
+		}
+
+		updatedPoll, err := getPoll(poll.PollId)
+		require.NoError(t, err)
+		require.Equal(t, []string{"opt1", "opt2"}, updatedPoll.Voters["user_id_5"])
+		require.Equal(t, int32(2), updatedPoll.MaxVotes)
+	})
+
+	t.Run("ranked ballot", func(t *testing.T) {
+		rankedPoll := *poll
+		rankedPoll.Ranked = true
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(&rankedPoll, t)
+
+		msg, err := d.Vote(&entities.Voice{PollId: "valid_id", UserId: "user_id_6", Ranking: []string{"opt2", "opt1"}})
+		require.NoError(t, err)
+		require.Equal(t, "**Voice recorded!**", msg.String())
+
+		updatedPoll, err := getPoll(poll.PollId)
+		require.NoError(t, err)
+		require.True(t, updatedPoll.Ranked)
+		require.Equal(t, []string{"opt2", "opt1"}, updatedPoll.Voters["user_id_6"])
+		require.Equal(t, int32(1), updatedPoll.Options["opt2"])
+		require.Equal(t, int32(0), updatedPoll.Options["opt1"])
+	})
+
 	t.Run("invalid option", func(t *testing.T) {
 		t.Cleanup(func() { truncateTable("polls", t) })
 		createTestPoll(poll, t)
@@ -174,11 +279,43 @@ func TestVote(t *testing.T) {
 		require.Empty(t, msg)
 	})
 
+	t.Run("concurrent votes", func(t *testing.T) {
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(poll, t)
+
+		// Второй экземпляр хранилища не разделяет состояние с первым, как другой экземпляр бота
+		other := database.NewDatabaseStore(conn)
+
+		var wg sync.WaitGroup
+		var recorded atomic.Int32
+		for i := range 8 {
+			store := d
+			if i%2 == 1 {
+				store = other
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := store.Vote(&entities.Voice{PollId: "valid_id", UserId: fmt.Sprintf("user_id_%d", 10+i), Option: "opt1"}); err == nil {
+					recorded.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		// Ни один записанный голос не должен потеряться
+		updatedPoll, err := getPoll(poll.PollId)
+		require.NoError(t, err)
+		require.NotZero(t, recorded.Load())
+		require.Len(t, updatedPoll.Voters, int(recorded.Load()))
+		require.Equal(t, recorded.Load(), updatedPoll.Options["opt1"])
+	})
+
 	t.Run("poll is already closed", func(t *testing.T) {
 		t.Cleanup(func() { truncateTable("polls", t) })
 		createTestPoll(poll, t)
 
-		msg, err := d.ClosePoll(poll.PollId, poll.Creator)
+		msg, err := d.ClosePoll(poll.PollId, poll.Creator, false, 0)
 		require.NoError(t, err)
 		require.NotEmpty(t, msg)
 
@@ -195,6 +332,221 @@ func TestVote(t *testing.T) {
 	})
 }
 
+// TestRetractVote проверяет различные сценарии отзыва голоса.
+func TestRetractVote(t *testing.T) {
+	t.Run("successful retract", func(t *testing.T) {
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(poll, t)
+
+		voice := &entities.Voice{PollId: "valid_id", UserId: "user_id_1", Option: "opt1"}
+		_, err := d.Vote(voice)
+		require.NoError(t, err)
+
+		msg, err := d.RetractVote(&entities.Voice{PollId: "valid_id", UserId: "user_id_1"})
+		require.NoError(t, err)
+		require.Equal(t, "**Voice retracted!**", msg.String())
+
+		updatedPoll, err := getPoll(poll.PollId)
+		require.NoError(t, err)
+		require.Equal(t, int32(0), updatedPoll.Options["opt1"])
+		require.NotContains(t, updatedPoll.Voters, "user_id_1")
+	})
+
+	t.Run("not voted", func(t *testing.T) {
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(poll, t)
+
+		msg, err := d.RetractVote(&entities.Voice{PollId: "valid_id", UserId: "user_id_2"})
+		require.Error(t, err)
+		require.Equal(t, "**You haven't voted in this poll!**", err.Error())
+		require.Empty(t, msg)
+	})
+}
+
+// TestChangeVote проверяет различные сценарии изменения голоса.
+func TestChangeVote(t *testing.T) {
+	t.Run("successful change", func(t *testing.T) {
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(poll, t)
+
+		_, err := d.Vote(&entities.Voice{PollId: "valid_id", UserId: "user_id_1", Option: "opt1"})
+		require.NoError(t, err)
+
+		msg, err := d.ChangeVote(&entities.Voice{PollId: "valid_id", UserId: "user_id_1", Option: "opt2"})
+		require.NoError(t, err)
+		require.Equal(t, "**Voice changed!**", msg.String())
+
+		updatedPoll, err := getPoll(poll.PollId)
+		require.NoError(t, err)
+		require.Equal(t, int32(0), updatedPoll.Options["opt1"])
+		require.Equal(t, int32(1), updatedPoll.Options["opt2"])
+		require.Equal(t, []string{"opt2"}, updatedPoll.Voters["user_id_1"])
+	})
+
+	t.Run("invalid option", func(t *testing.T) {
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(poll, t)
+
+		_, err := d.Vote(&entities.Voice{PollId: "valid_id", UserId: "user_id_1", Option: "opt1"})
+		require.NoError(t, err)
+
+		msg, err := d.ChangeVote(&entities.Voice{PollId: "valid_id", UserId: "user_id_1", Option: "invalid_opt"})
+		require.Error(t, err)
+		require.Equal(t, "**Invalid option!**", err.Error())
+		require.Empty(t, msg)
+	})
+}
+
+// TestAddOption проверяет добавление варианта в опрос с открытыми вариантами.
+func TestAddOption(t *testing.T) {
+	t.Run("successful added", func(t *testing.T) {
+		openPoll := *poll
+		openPoll.Options = map[string]int32{"opt1": 0, "opt2": 0}
+		openPoll.OpenOptions = true
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(&openPoll, t)
+
+		msg, err := d.AddOption(poll.PollId, "opt3")
+		require.NoError(t, err)
+		require.Equal(t, "**Option added!**", msg.String())
+
+		updatedPoll, err := getPoll(poll.PollId)
+		require.NoError(t, err)
+		require.True(t, updatedPoll.OpenOptions)
+		require.Equal(t, map[string]int32{"opt1": 0, "opt2": 0, "opt3": 0}, updatedPoll.Options)
+	})
+
+	t.Run("fixed options", func(t *testing.T) {
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(poll, t)
+
+		msg, err := d.AddOption(poll.PollId, "opt3")
+		require.Error(t, err)
+		require.Equal(t, "**This poll doesn't allow adding options!**", err.Error())
+		require.Empty(t, msg)
+	})
+}
+
+// TestReopenPoll проверяет повторное открытие закрытого опроса.
+func TestReopenPoll(t *testing.T) {
+	t.Run("successful reopened", func(t *testing.T) {
+		closedPoll := *poll
+		closedPoll.Closed = true
+		closedPoll.EndsAt = 100
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(&closedPoll, t)
+
+		msg, err := d.ReopenPoll(poll.PollId, "creator_id", false)
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully reopened!**", poll.PollId), msg.String())
+
+		updatedPoll, err := getPoll(poll.PollId)
+		require.NoError(t, err)
+		require.False(t, updatedPoll.Closed)
+		require.Zero(t, updatedPoll.EndsAt)
+	})
+
+	t.Run("open poll", func(t *testing.T) {
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(poll, t)
+
+		msg, err := d.ReopenPoll(poll.PollId, "creator_id", false)
+		require.Error(t, err)
+		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **is not closed!**", poll.PollId), err.Error())
+		require.Empty(t, msg)
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		closedPoll := *poll
+		closedPoll.Closed = true
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(&closedPoll, t)
+
+		msg, err := d.ReopenPoll(poll.PollId, "other_user", false)
+		require.Error(t, err)
+		require.Equal(t, "**You don't have the permission to reopen a vote!**", err.Error())
+		require.Empty(t, msg)
+	})
+}
+
+// TestListPolls проверяет выборку опросов по каналу, создателю и статусу с разбиением на страницы.
+func TestListPolls(t *testing.T) {
+	t.Cleanup(func() { truncateTable("polls", t) })
+
+	for _, p := range []struct {
+		id, question, channelId, creator string
+		closed                           bool
+	}{
+		{"poll1", "B", "channel1", "user1", false},
+		{"poll2", "A", "channel1", "user2", false},
+		{"poll3", "C", "channel1", "user1", true},
+		{"poll4", "D", "channel2", "user1", false},
+	} {
+		testPoll := *poll
+		testPoll.PollId = p.id
+		testPoll.Question = p.question
+		testPoll.ChannelId = p.channelId
+		testPoll.Creator = p.creator
+		testPoll.Closed = p.closed
+		createTestPoll(&testPoll, t)
+	}
+
+	open, closed := false, true
+	tests := []struct {
+		name     string
+		filter   *entities.PollFilter
+		expected []string
+		total    int
+	}{
+		{"Channel", &entities.PollFilter{ChannelId: "channel1"}, []string{"poll2", "poll1", "poll3"}, 3},
+		{"Creator", &entities.PollFilter{Creator: "user1", Closed: &open}, []string{"poll1", "poll4"}, 2},
+		{"Channel and creator", &entities.PollFilter{ChannelId: "channel1", Creator: "user1"}, []string{"poll1", "poll3"}, 2},
+		{"Closed", &entities.PollFilter{Closed: &closed}, []string{"poll3"}, 1},
+		{"Page", &entities.PollFilter{Offset: 2, Limit: 2}, []string{"poll4", "poll3"}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls, total, err := d.ListPolls(tt.filter)
+			require.NoError(t, err)
+			require.Equal(t, tt.total, total)
+
+			ids := []string{}
+			for _, poll := range polls {
+				ids = append(ids, poll.PollId)
+			}
+			require.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+// TestListDuePolls проверяет выборку открытых опросов с истекшим сроком.
+func TestListDuePolls(t *testing.T) {
+	t.Cleanup(func() { truncateTable("polls", t) })
+
+	for _, p := range []struct {
+		id     string
+		endsAt int64
+		closed bool
+	}{
+		{"due", 100, false},
+		{"future", 300, false},
+		{"no_deadline", 0, false},
+		{"closed", 100, true},
+	} {
+		testPoll := *poll
+		testPoll.PollId = p.id
+		testPoll.EndsAt = p.endsAt
+		testPoll.Closed = p.closed
+		createTestPoll(&testPoll, t)
+	}
+
+	duePolls, err := d.ListDuePolls(200)
+	require.NoError(t, err)
+	require.Len(t, duePolls, 1)
+	require.Equal(t, "due", duePolls[0].PollId)
+}
+
 // TestClosePoll проверяет различные сценарии закрытия голосования.
 func TestClosePoll(t *testing.T) {
 	t.Run("successful closed", func(t *testing.T) {
@@ -204,13 +556,14 @@ func TestClosePoll(t *testing.T) {
 		pollId := "valid_id"
 		userId := "creator_id"
 
-		msg, err := d.ClosePoll(pollId, userId)
+		msg, err := d.ClosePoll(pollId, userId, false, 7)
 		require.NoError(t, err)
-		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully closed!**", pollId), msg)
+		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully closed!**", pollId), msg.String())
 
 		updatedPoll, err := getPoll(poll.PollId)
 		require.NoError(t, err)
 		require.True(t, updatedPoll.Closed)
+		require.Equal(t, int32(7), updatedPoll.RequiredVoters)
 	})
 
 	t.Run("invalid poll_id", func(t *testing.T) {
@@ -220,7 +573,7 @@ func TestClosePoll(t *testing.T) {
 		pollId := "invalid_id"
 		userId := "creator_id"
 
-		msg, err := d.ClosePoll(pollId, userId)
+		msg, err := d.ClosePoll(pollId, userId, false, 0)
 		require.Error(t, err)
 		require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
 		require.Empty(t, msg)
@@ -235,7 +588,7 @@ func TestClosePoll(t *testing.T) {
 		pollId := "valid_id"
 		userId := "creator_id"
 
-		msg, err := d.ClosePoll(pollId, userId)
+		msg, err := d.ClosePoll(pollId, userId, false, 0)
 		require.Error(t, err)
 		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **is already closed!**", pollId), err.Error())
 		require.Empty(t, msg)
@@ -248,11 +601,28 @@ func TestClosePoll(t *testing.T) {
 		pollId := "valid_id"
 		userId := "not_creator_id"
 
-		msg, err := d.ClosePoll(pollId, userId)
+		msg, err := d.ClosePoll(pollId, userId, false, 0)
 		require.Error(t, err)
 		require.Equal(t, "**You don't have the permission to close a vote!**", err.Error())
 		require.Empty(t, msg)
 	})
+
+	t.Run("moderator", func(t *testing.T) {
+		t.Cleanup(func() { truncateTable("polls", t) })
+		createTestPoll(poll, t)
+
+		msg, err := d.ClosePoll(poll.PollId, "admin_id", true, 0)
+		require.NoError(t, err)
+		require.NotEmpty(t, msg)
+
+		msg, err = d.ReopenPoll(poll.PollId, "admin_id", true)
+		require.NoError(t, err)
+		require.NotEmpty(t, msg)
+
+		msg, err = d.DeletePoll(poll.PollId, "admin_id", true)
+		require.NoError(t, err)
+		require.NotEmpty(t, msg)
+	})
 }
 
 // TestDeletePoll проверяет различные сценарии удаления голосования.
@@ -261,9 +631,9 @@ func TestDeletePoll(t *testing.T) {
 		t.Cleanup(func() { truncateTable("polls", t) })
 		createTestPoll(poll, t)
 
-		msg, err := d.DeletePoll(poll.PollId, "creator_id")
+		msg, err := d.DeletePoll(poll.PollId, "creator_id", false)
 		require.NoError(t, err)
-		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully delete!**", poll.PollId), msg)
+		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully deleted!**", poll.PollId), msg.String())
 
 		deletedPoll, err := getPoll(poll.PollId)
 		require.Error(t, err)
@@ -277,7 +647,7 @@ func TestDeletePoll(t *testing.T) {
 		pollId := "invalid_id"
 		userId := "creator_id"
 
-		msg, err := d.DeletePoll(pollId, userId)
+		msg, err := d.DeletePoll(pollId, userId, false)
 		require.Error(t, err)
 		require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
 		require.Empty(t, msg)
@@ -290,7 +660,7 @@ func TestDeletePoll(t *testing.T) {
 		pollId := "valid_id"
 		userId := "not_creator_id"
 
-		msg, err := d.DeletePoll(pollId, userId)
+		msg, err := d.DeletePoll(pollId, userId, false)
 		require.Error(t, err)
 		require.Equal(t, "**You don't have the permission to delete a vote!**", err.Error())
 		require.Empty(t, msg)
@@ -303,10 +673,10 @@ func TestDeletePoll(t *testing.T) {
 		pollId := "valid_id"
 		userId := "creator_id"
 
-		_, err := d.DeletePoll(pollId, userId)
+		_, err := d.DeletePoll(pollId, userId, false)
 		require.NoError(t, err)
 
-		msg, err := d.ClosePoll(pollId, userId)
+		msg, err := d.ClosePoll(pollId, userId, false, 0)
 		require.Error(t, err)
 		require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
 		require.Empty(t, msg)
@@ -344,6 +714,92 @@ func TestValidateCmdToken(t *testing.T) {
 	require.False(t, isInvalid)
 }
 
+// TestSchedules проверяет сохранение, выборку, приостановку, возобновление и удаление расписаний повторяющихся опросов.
+func TestSchedules(t *testing.T) {
+	t.Cleanup(func() { truncateTable("schedules", t) })
+
+	schedule := &entities.Schedule{
+		ScheduleId: "schedule_id",
+		Cron:       "0 12 * * 5",
+		Question:   "test_question",
+		Options:    []string{"opt1", "opt2"},
+		Creator:    "creator_id",
+		ChannelId:  "channel_id",
+		TeamId:     "team_id",
+		MaxVotes:   1,
+		Duration:   7200,
+		NextRunAt:  1735905600,
+		CreatedAt:  1735689600,
+	}
+	require.NoError(t, d.CreateSchedule(schedule))
+
+	actualSchedule, err := d.GetSchedule(schedule.ScheduleId)
+	require.NoError(t, err)
+	require.Equal(t, schedule, actualSchedule)
+
+	schedules, err := d.ListSchedules("channel_id")
+	require.NoError(t, err)
+	require.Equal(t, []*entities.Schedule{schedule}, schedules)
+
+	dueSchedules, err := d.ListDueSchedules(schedule.NextRunAt)
+	require.NoError(t, err)
+	require.Len(t, dueSchedules, 1)
+
+	require.NoError(t, d.SetScheduleNextRun(schedule.ScheduleId, schedule.NextRunAt+604800))
+	dueSchedules, err = d.ListDueSchedules(schedule.NextRunAt)
+	require.NoError(t, err)
+	require.Empty(t, dueSchedules)
+
+	_, err = d.PauseSchedule(schedule.ScheduleId, "other_user")
+	require.Error(t, err)
+	require.Equal(t, "**You don't have the permission to pause a schedule!**", err.Error())
+
+	msg, err := d.PauseSchedule(schedule.ScheduleId, schedule.Creator)
+	require.NoError(t, err)
+	require.Equal(t, "*Schedule*: `schedule_id` **has been paused!**", msg.String())
+
+	actualSchedule, err = d.GetSchedule(schedule.ScheduleId)
+	require.NoError(t, err)
+	require.True(t, actualSchedule.Paused)
+
+	msg, err = d.ResumeSchedule(schedule.ScheduleId, schedule.Creator, schedule.NextRunAt+1209600)
+	require.NoError(t, err)
+	require.Equal(t, "*Schedule*: `schedule_id` **has been resumed!**", msg.String())
+
+	actualSchedule, err = d.GetSchedule(schedule.ScheduleId)
+	require.NoError(t, err)
+	require.False(t, actualSchedule.Paused)
+	require.Equal(t, schedule.NextRunAt+1209600, actualSchedule.NextRunAt)
+
+	// Параллельная приостановка из двух экземпляров бота должна выполниться ровно один раз
+	other := database.NewDatabaseStore(conn)
+	var wg sync.WaitGroup
+	var paused atomic.Int32
+	for i := range 4 {
+		store := d
+		if i%2 == 1 {
+			store = other
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := store.PauseSchedule(schedule.ScheduleId, schedule.Creator); err == nil {
+				paused.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), paused.Load())
+
+	msg, err = d.DeleteSchedule(schedule.ScheduleId, schedule.Creator)
+	require.NoError(t, err)
+	require.Equal(t, "*Schedule*: `schedule_id` **has been removed!**", msg.String())
+
+	_, err = d.GetSchedule(schedule.ScheduleId)
+	require.Error(t, err)
+	require.Equal(t, "**Invalid Schedule_ID or not exists!**", err.Error())
+}
+
 // createTestPoll создает тестовый опрос в базе данных.
 func createTestPoll(poll *entities.Poll, t *testing.T) {
 	err := d.CreatePoll(poll)
@@ -2,12 +2,14 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"matterpoll-bot/internal/entities"
 	"matterpoll-bot/internal/storage"
 	"time"
 
+	"github.com/tarantool/go-iproto"
 	"github.com/tarantool/go-tarantool/v2"
 	_ "github.com/tarantool/go-tarantool/v2/datetime"
 	_ "github.com/tarantool/go-tarantool/v2/decimal"
@@ -23,9 +25,19 @@ func NewDatabaseStore(conn *tarantool.Connection) *Database {
 	return &Database{Conn: conn}
 }
 
+// Значения по умолчанию для настроек подключения к БД.
+const (
+	defaultConnectTimeout    = time.Second
+	defaultRequestTimeout    = time.Minute
+	defaultReconnectInterval = 500 * time.Millisecond
+	defaultMaxReconnects     = 10
+)
+
 // NewDatabaseConection создает соединение с БД.
+// Незаданные тайм-ауты и число переподключений заменяются значениями по умолчанию.
 func NewDatabaseConection(conf *entities.TarantoolConfig) (*tarantool.Connection, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	connectTimeout := orDefault(conf.ConnectTimeout, defaultConnectTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
 	defer cancel()
 
 	dialer := tarantool.NetDialer{
@@ -34,10 +46,15 @@ func NewDatabaseConection(conf *entities.TarantoolConfig) (*tarantool.Connection
 		Password: conf.Password,
 	}
 
+	maxReconnects := conf.MaxReconnects
+	if maxReconnects == 0 {
+		maxReconnects = defaultMaxReconnects
+	}
+
 	opts := tarantool.Opts{
-		Timeout:       1 * time.Minute,
-		Reconnect:     500 * time.Millisecond,
-		MaxReconnects: 10,
+		Timeout:       orDefault(conf.RequestTimeout, defaultRequestTimeout),
+		Reconnect:     orDefault(conf.ReconnectInterval, defaultReconnectInterval),
+		MaxReconnects: maxReconnects,
 		RateLimit:     100,
 	}
 
@@ -49,8 +66,23 @@ func NewDatabaseConection(conf *entities.TarantoolConfig) (*tarantool.Connection
 	return conn, err
 }
 
+// orDefault возвращает d, а если он не задан - def.
+func orDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+
+	return d
+}
+
 // CreatePoll добавляет новый опрос в базу данных.
+// Веса голосов опроса без весов сохраняются пустой картой.
 func (d *Database) CreatePoll(poll *entities.Poll) error {
+	weights := poll.Weights
+	if weights == nil {
+		weights = map[string]int32{}
+	}
+
 	tuple := []interface{}{
 		poll.PollId,
 		poll.Question,
@@ -58,6 +90,21 @@ func (d *Database) CreatePoll(poll *entities.Poll) error {
 		poll.Voters,
 		poll.Creator,
 		poll.Closed,
+		poll.PostId,
+		poll.MaxVotes,
+		poll.Public,
+		poll.Ranked,
+		poll.ChannelId,
+		poll.EndsAt,
+		poll.TeamId,
+		poll.CreatedAt,
+		poll.OpenOptions,
+		poll.HideResults,
+		weights,
+		poll.Quorum,
+		poll.QuorumPercent,
+		poll.Threshold,
+		poll.RequiredVoters,
 	}
 
 	reqPost := tarantool.NewInsertRequest(entities.PollsSpaceName).Tuple(tuple)
@@ -68,125 +115,501 @@ func (d *Database) CreatePoll(poll *entities.Poll) error {
 	return nil
 }
 
-// Vote регистрирует голос пользователя в опросе,
-// в соответствии с выбранным вариантом и обновляет данные БД.
-func (d *Database) Vote(voice *entities.Voice) (string, error) {
+// GetPoll получает опрос из БД по его идентификатору.
+func (d *Database) GetPoll(pollId string) (*entities.Poll, error) {
+	return selectPoll(d.Conn, pollId)
+}
+
+// selectPoll получает опрос по его идентификатору через соединение или поток транзакции doer.
+func selectPoll(doer tarantool.Doer, pollId string) (*entities.Poll, error) {
 	reqGet := tarantool.NewSelectRequest(entities.PollsSpaceName).
 		Index("primary").
 		Iterator(tarantool.IterEq).
-		Key([]interface{}{voice.PollId})
-	data, err := d.Conn.Do(reqGet).Get()
+		Key([]interface{}{pollId})
+	data, err := doer.Do(reqGet).Get()
 	if err != nil {
-		return "", fmt.Errorf("failed to execute select request: %w", err)
+		return nil, fmt.Errorf("failed to execute select request: %w", err)
 	}
 
-	poll, err := ParseData(data)
+	return ParseData(data)
+}
+
+// SetPollPost сохраняет в БД идентификатор сообщения, в котором опубликован опрос.
+func (d *Database) SetPollPost(pollId, postId string) error {
+	reqUpd := tarantool.NewUpdateRequest(entities.PollsSpaceName).
+		Key([]interface{}{pollId}).
+		Operations(tarantool.NewOperations().
+			Assign(6, postId))
+	if _, err := d.Conn.Do(reqUpd).Get(); err != nil {
+		return fmt.Errorf("failed to execute update request: %w", err)
+	}
+
+	return nil
+}
+
+// Vote регистрирует голос пользователя в опросе,
+// в соответствии с выбранным вариантом и обновляет данные БД.
+func (d *Database) Vote(voice *entities.Voice) (*entities.Message, error) {
+	err := d.modifyPoll(voice.PollId, func(poll *entities.Poll) (tarantool.Request, error) {
+		if err := storage.AddVoice(poll, voice); err != nil {
+			return nil, err
+		}
+
+		return updateVoicesRequest(poll), nil
+	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err := storage.ValidateVoice(poll, voice); err != nil {
-		return "", err
+	return entities.NewMessage("vote.recorded"), nil
+}
+
+// RetractVote отзывает голос пользователя в опросе и обновляет данные БД.
+// Если вариант в голосе не указан, отзываются все варианты, выбранные пользователем.
+func (d *Database) RetractVote(voice *entities.Voice) (*entities.Message, error) {
+	err := d.modifyPoll(voice.PollId, func(poll *entities.Poll) (tarantool.Request, error) {
+		if err := storage.RemoveVoice(poll, voice); err != nil {
+			return nil, err
+		}
+
+		return updateVoicesRequest(poll), nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	poll.Options[voice.Option]++
-	poll.Voters[voice.UserId] = true
+	return entities.NewMessage("vote.retracted"), nil
+}
 
-	reqUpd := tarantool.NewUpdateRequest(entities.PollsSpaceName).
-		Key([]interface{}{voice.PollId}).
+// ChangeVote заменяет выбор пользователя в опросе на новый вариант и обновляет данные БД.
+func (d *Database) ChangeVote(voice *entities.Voice) (*entities.Message, error) {
+	err := d.modifyPoll(voice.PollId, func(poll *entities.Poll) (tarantool.Request, error) {
+		if err := storage.ChangeVoice(poll, voice); err != nil {
+			return nil, err
+		}
+
+		return updateVoicesRequest(poll), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewMessage("vote.changed"), nil
+}
+
+// AddOption добавляет в опрос с открытыми вариантами новый вариант и обновляет данные БД.
+func (d *Database) AddOption(pollId, option string) (*entities.Message, error) {
+	err := d.modifyPoll(pollId, func(poll *entities.Poll) (tarantool.Request, error) {
+		if err := storage.AddOption(poll, option); err != nil {
+			return nil, err
+		}
+
+		return updateVoicesRequest(poll), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewMessage("option.added"), nil
+}
+
+// updateVoicesRequest возвращает запрос, сохраняющий в БД счетчики голосов и выбор пользователей опроса.
+func updateVoicesRequest(poll *entities.Poll) tarantool.Request {
+	return tarantool.NewUpdateRequest(entities.PollsSpaceName).
+		Key([]interface{}{poll.PollId}).
 		Operations(tarantool.NewOperations().
 			Assign(2, poll.Options).
 			Assign(3, poll.Voters))
-	if _, err = d.Conn.Do(reqUpd).Get(); err != nil {
-		return "", fmt.Errorf("failed to execute update request: %w", err)
+}
+
+// maxTxAttempts - количество попыток транзакции, прерванной конфликтом с параллельным изменением тех же данных.
+const maxTxAttempts = 5
+
+// modifyPoll атомарно изменяет опрос pollId в интерактивной транзакции Tarantool: читает опрос,
+// передает его в modify и выполняет возвращенный ею запрос изменения. Ошибка modify отменяет транзакцию.
+func (d *Database) modifyPoll(pollId string, modify func(poll *entities.Poll) (tarantool.Request, error)) error {
+	return d.inTransaction(func(stream *tarantool.Stream) error {
+		poll, err := selectPoll(stream, pollId)
+		if err != nil {
+			return err
+		}
+
+		req, err := modify(poll)
+		if err != nil {
+			return err
+		}
+
+		return executeModify(stream, req)
+	})
+}
+
+// modifySchedule атомарно изменяет расписание scheduleId так же, как modifyPoll изменяет опрос.
+func (d *Database) modifySchedule(scheduleId string, modify func(schedule *entities.Schedule) (tarantool.Request, error)) error {
+	return d.inTransaction(func(stream *tarantool.Stream) error {
+		schedule, err := selectSchedule(stream, scheduleId)
+		if err != nil {
+			return err
+		}
+
+		req, err := modify(schedule)
+		if err != nil {
+			return err
+		}
+
+		return executeModify(stream, req)
+	})
+}
+
+// inTransaction выполняет body в интерактивной транзакции Tarantool. Изменение тех же данных
+// параллельной транзакцией (в том числе другим экземпляром бота) прерывает транзакцию при фиксации,
+// и тогда body выполняется заново. Ошибка body отменяет транзакцию.
+func (d *Database) inTransaction(body func(stream *tarantool.Stream) error) error {
+	for attempt := 1; ; attempt++ {
+		err := d.tryTransaction(body)
+		var tntErr tarantool.Error
+		if err == nil || !errors.As(err, &tntErr) || tntErr.Code != iproto.ER_TRANSACTION_CONFLICT || attempt == maxTxAttempts {
+			return err
+		}
+	}
+}
+
+// tryTransaction выполняет одну попытку транзакции inTransaction в отдельном потоке соединения.
+func (d *Database) tryTransaction(body func(stream *tarantool.Stream) error) error {
+	stream, err := d.Conn.NewStream()
+	if err != nil {
+		return fmt.Errorf("failed to create stream: %w", err)
 	}
 
-	return "**Voice recorded!**", nil
+	reqBegin := tarantool.NewBeginRequest().TxnIsolation(tarantool.BestEffortLevel)
+	if _, err := stream.Do(reqBegin).Get(); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := body(stream); err != nil {
+		if _, rbErr := stream.Do(tarantool.NewRollbackRequest()).Get(); rbErr != nil {
+			log.Printf("failed to rollback transaction: %v\n", rbErr)
+		}
+		return err
+	}
+
+	if _, err := stream.Do(tarantool.NewCommitRequest()).Get(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
 
-// GetPollResult получает результаты опроса из БД.
-func (d *Database) GetPollResult(pollId string) (string, error) {
-	reqGet := tarantool.NewSelectRequest(entities.PollsSpaceName).
-		Index("primary").
-		Iterator(tarantool.IterEq).
-		Key([]interface{}{pollId})
-	data, err := d.Conn.Do(reqGet).Get()
+// executeModify выполняет запрос изменения req в открытой транзакции потока stream.
+func executeModify(stream *tarantool.Stream, req tarantool.Request) error {
+	if _, err := stream.Do(req).Get(); err != nil {
+		return fmt.Errorf("failed to execute modify request: %w", err)
+	}
+
+	return nil
+}
+
+// ListPolls возвращает опросы, удовлетворяющие условиям filter, и общее количество таких опросов.
+// Опросы выбираются по индексу "channel" (channel_id, closed), "creator" (creator, closed)
+// или "deadline" (closed, ends_at) в зависимости от заданных условий, остальные условия,
+// порядок и разбиение на страницы применяются к выбранным кортежам.
+func (d *Database) ListPolls(filter *entities.PollFilter) ([]*entities.Poll, int, error) {
+	index, key := "primary", []interface{}{}
+	switch {
+	case filter.ChannelId != "":
+		index, key = "channel", []interface{}{filter.ChannelId}
+	case filter.Creator != "":
+		index, key = "creator", []interface{}{filter.Creator}
+	case filter.Closed != nil:
+		index = "deadline"
+	}
+	if filter.Closed != nil && index != "primary" {
+		key = append(key, *filter.Closed)
+	}
+
+	iterator := tarantool.IterEq
+	if len(key) == 0 {
+		iterator = tarantool.IterAll
+	}
+
+	reqSelect := tarantool.NewSelectRequest(entities.PollsSpaceName).
+		Index(index).
+		Iterator(iterator).
+		Key(key)
+	data, err := d.Conn.Do(reqSelect).Get()
 	if err != nil {
-		return "", fmt.Errorf("failed to execute select request: %w", err)
+		return nil, 0, fmt.Errorf("failed to execute select request: %w", err)
 	}
 
-	poll, err := ParseData(data)
+	polls, err := ParseList(data)
 	if err != nil {
-		return "", err
+		return nil, 0, err
 	}
 
-	tbl := storage.PrintTable(poll)
+	page, total := storage.FilterPolls(polls, filter)
 
-	return tbl, nil
+	return page, total, nil
 }
 
-// ClosePoll закрывает опрос и обновляет данные в БД.
-func (d *Database) ClosePoll(pollId, userId string) (string, error) {
-	reqGet := tarantool.NewSelectRequest(entities.PollsSpaceName).
-		Index("primary").
-		Iterator(tarantool.IterEq).
-		Key([]interface{}{pollId})
-	data, err := d.Conn.Do(reqGet).Get()
+// ListDuePolls возвращает открытые опросы, срок которых истек к моменту now (в формате Unix).
+// Используется индекс "deadline" по полям closed и ends_at, поэтому выбираются только открытые опросы
+// со сроком не позднее now; опросы без срока (ends_at = 0) отбрасываются.
+func (d *Database) ListDuePolls(now int64) ([]*entities.Poll, error) {
+	reqSelect := tarantool.NewSelectRequest(entities.PollsSpaceName).
+		Index("deadline").
+		Iterator(tarantool.IterLe).
+		Key([]interface{}{false, now})
+	data, err := d.Conn.Do(reqSelect).Get()
 	if err != nil {
-		return "", fmt.Errorf("failed to execute select request: %w", err)
+		return nil, fmt.Errorf("failed to execute select request: %w", err)
 	}
 
-	poll, err := ParseData(data)
+	polls, err := ParseList(data)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if poll.Closed {
-		return "", entities.NewUserError(fmt.Sprintf("*Poll*: `%s` **is already closed!**", pollId))
+	duePolls := []*entities.Poll{}
+	for _, poll := range polls {
+		if poll.EndsAt > 0 {
+			duePolls = append(duePolls, poll)
+		}
 	}
-	if poll.Creator != userId {
-		return "", entities.NewUserError("**You don't have the permission to close a vote!**")
+
+	return duePolls, nil
+}
+
+// ClosePoll закрывает опрос и тем же изменением сохраняет в БД количество проголосовавших requiredVoters,
+// необходимое для кворума.
+// Действие разрешено создателю опроса userId или модератору (moderator).
+func (d *Database) ClosePoll(pollId, userId string, moderator bool, requiredVoters int32) (*entities.Message, error) {
+	err := d.modifyPoll(pollId, func(poll *entities.Poll) (tarantool.Request, error) {
+		if poll.Closed {
+			return nil, entities.NewUserError("poll.already_closed", pollId)
+		}
+		if !storage.CanManagePoll(poll, userId, moderator) {
+			return nil, entities.NewUserError("poll.close_forbidden")
+		}
+
+		return tarantool.NewUpdateRequest(entities.PollsSpaceName).
+			Key([]interface{}{pollId}).
+			Operations(tarantool.NewOperations().
+				Assign(5, true).
+				Assign(20, requiredVoters)), nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	poll.Closed = true
 
-	reqUpdate := tarantool.NewUpdateRequest(entities.PollsSpaceName).
-		Key([]interface{}{pollId}).
-		Operations(tarantool.NewOperations().
-			Assign(5, poll.Closed))
-	if _, err = d.Conn.Do(reqUpdate).Get(); err != nil {
-		return "", fmt.Errorf("failed to execute update request: %w", err)
+	return entities.NewMessage("poll.closed", pollId), nil
+}
+
+// ReopenPoll снова открывает закрытый опрос и снимает срок его автоматического закрытия в БД.
+// Действие разрешено создателю опроса userId или модератору (moderator).
+func (d *Database) ReopenPoll(pollId, userId string, moderator bool) (*entities.Message, error) {
+	err := d.modifyPoll(pollId, func(poll *entities.Poll) (tarantool.Request, error) {
+		if !poll.Closed {
+			return nil, entities.NewUserError("poll.not_closed", pollId)
+		}
+		if !storage.CanManagePoll(poll, userId, moderator) {
+			return nil, entities.NewUserError("poll.reopen_forbidden")
+		}
+
+		return tarantool.NewUpdateRequest(entities.PollsSpaceName).
+			Key([]interface{}{pollId}).
+			Operations(tarantool.NewOperations().
+				Assign(5, false).
+				Assign(11, int64(0))), nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return fmt.Sprintf("*Poll*: `%s` **has been successfully closed!**", pollId), nil
+	return entities.NewMessage("poll.reopened", pollId), nil
 }
 
 // DeletePoll удаляет опрос из БД.
-func (d *Database) DeletePoll(pollId, userId string) (string, error) {
-	reqGet := tarantool.NewSelectRequest(entities.PollsSpaceName).
+// Действие разрешено создателю опроса userId или модератору (moderator).
+func (d *Database) DeletePoll(pollId, userId string, moderator bool) (*entities.Message, error) {
+	err := d.modifyPoll(pollId, func(poll *entities.Poll) (tarantool.Request, error) {
+		if !storage.CanManagePoll(poll, userId, moderator) {
+			return nil, entities.NewUserError("poll.delete_forbidden")
+		}
+
+		return tarantool.NewDeleteRequest(entities.PollsSpaceName).
+			Key([]interface{}{pollId}), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewMessage("poll.deleted", pollId), nil
+}
+
+// CreateSchedule сохраняет новое расписание повторяющегося опроса в пространстве SchedulesSpaceName.
+func (d *Database) CreateSchedule(schedule *entities.Schedule) error {
+	tuple := []interface{}{
+		schedule.ScheduleId,
+		schedule.Cron,
+		schedule.Question,
+		schedule.Options,
+		schedule.Creator,
+		schedule.ChannelId,
+		schedule.TeamId,
+		schedule.MaxVotes,
+		schedule.Public,
+		schedule.Ranked,
+		schedule.Duration,
+		schedule.Paused,
+		schedule.NextRunAt,
+		schedule.CreatedAt,
+	}
+
+	reqPost := tarantool.NewInsertRequest(entities.SchedulesSpaceName).Tuple(tuple)
+	if _, err := d.Conn.Do(reqPost).Get(); err != nil {
+		return fmt.Errorf("failed to execute insert request: %w", err)
+	}
+
+	return nil
+}
+
+// GetSchedule получает расписание из БД по его идентификатору.
+func (d *Database) GetSchedule(scheduleId string) (*entities.Schedule, error) {
+	return selectSchedule(d.Conn, scheduleId)
+}
+
+// selectSchedule получает расписание по его идентификатору через соединение или поток транзакции doer.
+func selectSchedule(doer tarantool.Doer, scheduleId string) (*entities.Schedule, error) {
+	reqGet := tarantool.NewSelectRequest(entities.SchedulesSpaceName).
 		Index("primary").
 		Iterator(tarantool.IterEq).
-		Key([]interface{}{pollId})
-	data, err := d.Conn.Do(reqGet).Get()
+		Key([]interface{}{scheduleId})
+	data, err := doer.Do(reqGet).Get()
 	if err != nil {
-		return "", fmt.Errorf("failed to execute select request: %w", err)
+		return nil, fmt.Errorf("failed to execute select request: %w", err)
 	}
 
-	poll, err := ParseData(data)
+	return ParseSchedule(data)
+}
+
+// ListSchedules возвращает расписания канала channelId, упорядоченные по времени создания.
+// Используется индекс "channel" по полю channel_id.
+func (d *Database) ListSchedules(channelId string) ([]*entities.Schedule, error) {
+	reqSelect := tarantool.NewSelectRequest(entities.SchedulesSpaceName).
+		Index("channel").
+		Iterator(tarantool.IterEq).
+		Key([]interface{}{channelId})
+	data, err := d.Conn.Do(reqSelect).Get()
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to execute select request: %w", err)
 	}
 
-	if poll.Creator != userId {
-		return "", entities.NewUserError("**You don't have the permission to delete a vote!**")
+	schedules, err := ParseScheduleList(data)
+	if err != nil {
+		return nil, err
 	}
+	storage.SortSchedules(schedules)
 
-	reqUpd := tarantool.NewDeleteRequest(entities.PollsSpaceName).
-		Key([]interface{}{pollId})
-	if _, err = d.Conn.Do(reqUpd).Get(); err != nil {
-		return "", fmt.Errorf("failed to execute delete request: %w", err)
+	return schedules, nil
+}
+
+// ListDueSchedules возвращает активные расписания, время запуска которых наступило к моменту now (в формате Unix).
+// Используется индекс "due" по полям paused и next_run_at; расписания без следующего запуска
+// (next_run_at = 0) отбрасываются.
+func (d *Database) ListDueSchedules(now int64) ([]*entities.Schedule, error) {
+	reqSelect := tarantool.NewSelectRequest(entities.SchedulesSpaceName).
+		Index("due").
+		Iterator(tarantool.IterLe).
+		Key([]interface{}{false, now})
+	data, err := d.Conn.Do(reqSelect).Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute select request: %w", err)
+	}
+
+	schedules, err := ParseScheduleList(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dueSchedules := []*entities.Schedule{}
+	for _, schedule := range schedules {
+		if schedule.NextRunAt > 0 {
+			dueSchedules = append(dueSchedules, schedule)
+		}
+	}
+
+	return dueSchedules, nil
+}
+
+// SetScheduleNextRun сохраняет в БД время следующего запуска расписания.
+func (d *Database) SetScheduleNextRun(scheduleId string, nextRunAt int64) error {
+	reqUpd := tarantool.NewUpdateRequest(entities.SchedulesSpaceName).
+		Key([]interface{}{scheduleId}).
+		Operations(tarantool.NewOperations().
+			Assign(12, nextRunAt))
+	if _, err := d.Conn.Do(reqUpd).Get(); err != nil {
+		return fmt.Errorf("failed to execute update request: %w", err)
+	}
+
+	return nil
+}
+
+// PauseSchedule приостанавливает расписание и обновляет данные в БД.
+// Действие разрешено только создателю расписания userId.
+func (d *Database) PauseSchedule(scheduleId, userId string) (*entities.Message, error) {
+	err := d.modifySchedule(scheduleId, func(schedule *entities.Schedule) (tarantool.Request, error) {
+		if err := storage.SetSchedulePaused(schedule, userId, true); err != nil {
+			return nil, err
+		}
+
+		return tarantool.NewUpdateRequest(entities.SchedulesSpaceName).
+			Key([]interface{}{scheduleId}).
+			Operations(tarantool.NewOperations().
+				Assign(11, true)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.PauseMessage(scheduleId, true), nil
+}
+
+// ResumeSchedule возобновляет расписание и тем же изменением сохраняет в БД время следующего запуска nextRunAt,
+// чтобы планировщик не выполнил запуск, пропущенный во время паузы.
+// Действие разрешено только создателю расписания userId.
+func (d *Database) ResumeSchedule(scheduleId, userId string, nextRunAt int64) (*entities.Message, error) {
+	err := d.modifySchedule(scheduleId, func(schedule *entities.Schedule) (tarantool.Request, error) {
+		if err := storage.SetSchedulePaused(schedule, userId, false); err != nil {
+			return nil, err
+		}
+
+		return tarantool.NewUpdateRequest(entities.SchedulesSpaceName).
+			Key([]interface{}{scheduleId}).
+			Operations(tarantool.NewOperations().
+				Assign(11, false).
+				Assign(12, nextRunAt)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.PauseMessage(scheduleId, false), nil
+}
+
+// DeleteSchedule удаляет расписание из БД, если userId является его создателем.
+func (d *Database) DeleteSchedule(scheduleId, userId string) (*entities.Message, error) {
+	err := d.modifySchedule(scheduleId, func(schedule *entities.Schedule) (tarantool.Request, error) {
+		if schedule.Creator != userId {
+			return nil, entities.NewUserError("schedule.remove_forbidden")
+		}
+
+		return tarantool.NewDeleteRequest(entities.SchedulesSpaceName).
+			Key([]interface{}{scheduleId}), nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return fmt.Sprintf("*Poll*: `%s` **has been successfully delete!**", pollId), nil
+	return entities.NewMessage("schedule.removed", scheduleId), nil
 }
 
 // AddCmdToken добавляет новую запись с командным путем и токеном в пространство TokensSpaceName.
@@ -202,7 +625,7 @@ func (d *Database) AddCmdToken(cmdPath, token string) error {
 	return nil
 }
 
-// ValidateCmdToken проверяет, соответствует ли переданный токен 
+// ValidateCmdToken проверяет, соответствует ли переданный токен
 // заданному пути команды в базе данных Tarantool.
 func (d *Database) ValidateCmdToken(cmdPath, token string) bool {
 	reqGet := tarantool.NewSelectRequest(entities.TokensSpaceName).
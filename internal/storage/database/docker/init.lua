@@ -2,7 +2,8 @@ box.cfg{
     listen = 3301,
     replication = nil, -- Отключение репликации
     read_only = false, -- Разрешение записи
-    wal_mode = "write" -- Включение упреждающего журналирования
+    wal_mode = "write", -- Включение упреждающего журналирования
+    memtx_use_mvcc_engine = true -- Интерактивные транзакции для атомарного изменения опросов
 }
 
 -- Создаем пользователя, если его нет
@@ -17,17 +18,56 @@ else
     print("User' already exists")
 end
 
+-- Формат пространства 'polls'. Новые поля добавляются только в конец списка,
+-- а их значения для уже сохраненных опросов задаются в polls_defaults
+local polls_format = {
+    {name = 'id', type = 'string'},
+    {name = 'question', type = 'string'},
+    {name = 'options', type = 'map'},
+    {name = 'voters', type = 'map'},
+    {name = 'creator', type = 'string'},
+    {name = 'closed', type = 'boolean'},
+    {name = 'post_id', type = 'string'},
+    {name = 'max_votes', type = 'integer'},
+    {name = 'public', type = 'boolean'},
+    {name = 'ranked', type = 'boolean'},
+    {name = 'channel_id', type = 'string'},
+    {name = 'ends_at', type = 'integer'},
+    {name = 'team_id', type = 'string'},
+    {name = 'created_at', type = 'integer'},
+    {name = 'open_options', type = 'boolean'},
+    {name = 'hide_results', type = 'boolean'},
+    {name = 'weights', type = 'map'},
+    {name = 'quorum', type = 'integer'},
+    {name = 'quorum_percent', type = 'integer'},
+    {name = 'threshold', type = 'double'},
+    {name = 'required_voters', type = 'integer'}
+}
+
+-- Значения полей, добавленных после исходного формата (id, question, options, voters, creator, closed),
+-- для опросов, сохраненных до обновления схемы: опрос с одним голосом, без срока, весов и кворума
+local polls_defaults = {
+    post_id = '',
+    max_votes = 1,
+    public = false,
+    ranked = false,
+    channel_id = '',
+    ends_at = 0,
+    team_id = '',
+    created_at = 0,
+    open_options = false,
+    hide_results = false,
+    weights = setmetatable({}, { __serialize = 'map' }),
+    quorum = 0,
+    quorum_percent = 0,
+    threshold = require('ffi').cast('double', 0),
+    required_voters = 0
+}
+
 -- Создание пространтсва 'polls'
 if not box.space.polls then
     box.schema.space.create('polls', {
-        format = {
-            {name = 'id', type = 'string'},
-            {name = 'question', type = 'string'},
-            {name = 'options', type = 'map'},
-            {name = 'voters', type = 'map'},
-            {name = 'creator', type = 'string'},
-            {name = 'closed', type = 'boolean'}
-        },
+        format = polls_format,
         if_not_exists = true
     })
     print("Space 'polls' created")
@@ -35,6 +75,31 @@ else
     print("Space 'polls' already exists")
 end
 
+-- Обновление схемы пространства 'polls', созданного предыдущей версией бота:
+-- кортежи старого формата дополняются значениями по умолчанию, после чего задается текущий формат.
+-- При перезаписи счетчики вариантов сохраняются компактными целыми, а голоса в voters остаются
+-- в исходном виде (true), оба представления разбирает parseTuple.
+-- Повторный запуск ничего не меняет, так как все кортежи уже имеют полный формат
+if #box.space.polls:format() < #polls_format then
+    local legacy = {}
+    for _, tuple in box.space.polls:pairs() do
+        if #tuple < #polls_format then
+            table.insert(legacy, tuple:totable())
+        end
+    end
+
+    box.atomic(function()
+        for _, fields in ipairs(legacy) do
+            for i = #fields + 1, #polls_format do
+                fields[i] = polls_defaults[polls_format[i].name]
+            end
+            box.space.polls:replace(fields)
+        end
+    end)
+    box.space.polls:format(polls_format)
+    print("Space 'polls' upgraded")
+end
+
 -- Создание индексов для пространства 'polls'
 box.space.polls:create_index('primary', { 
     parts = { {field = 'id', type = 'string'} }, 
@@ -42,6 +107,79 @@ box.space.polls:create_index('primary', {
     if_not_exists = true 
 })
 
+-- Индекс для поиска открытых опросов с истекшим сроком
+box.space.polls:create_index('deadline', {
+    parts = { {field = 'closed', type = 'boolean'}, {field = 'ends_at', type = 'integer'} },
+    type = 'tree',
+    unique = false,
+    if_not_exists = true
+})
+
+-- Индекс для поиска опросов канала (с фильтром по статусу)
+box.space.polls:create_index('channel', {
+    parts = { {field = 'channel_id', type = 'string'}, {field = 'closed', type = 'boolean'} },
+    type = 'tree',
+    unique = false,
+    if_not_exists = true
+})
+
+-- Индекс для поиска опросов создателя (с фильтром по статусу)
+box.space.polls:create_index('creator', {
+    parts = { {field = 'creator', type = 'string'}, {field = 'closed', type = 'boolean'} },
+    type = 'tree',
+    unique = false,
+    if_not_exists = true
+})
+
+-- Создание пространства 'schedules' для расписаний повторяющихся опросов
+if not box.space.schedules then
+    box.schema.space.create('schedules', {
+        format = {
+            {name = 'id', type = 'string'},
+            {name = 'cron', type = 'string'},
+            {name = 'question', type = 'string'},
+            {name = 'options', type = 'array'},
+            {name = 'creator', type = 'string'},
+            {name = 'channel_id', type = 'string'},
+            {name = 'team_id', type = 'string'},
+            {name = 'max_votes', type = 'integer'},
+            {name = 'public', type = 'boolean'},
+            {name = 'ranked', type = 'boolean'},
+            {name = 'duration', type = 'integer'},
+            {name = 'paused', type = 'boolean'},
+            {name = 'next_run_at', type = 'integer'},
+            {name = 'created_at', type = 'integer'}
+        },
+        if_not_exists = true
+    })
+    print("Space 'schedules' created")
+else
+    print("Space 'schedules' already exists")
+end
+
+-- Создание индексов для пространства 'schedules'
+box.space.schedules:create_index('primary', {
+    parts = { {field = 'id', type = 'string'} },
+    type = 'hash',
+    if_not_exists = true
+})
+
+-- Индекс для поиска расписаний канала
+box.space.schedules:create_index('channel', {
+    parts = { {field = 'channel_id', type = 'string'} },
+    type = 'tree',
+    unique = false,
+    if_not_exists = true
+})
+
+-- Индекс для поиска активных расписаний, время запуска которых наступило
+box.space.schedules:create_index('due', {
+    parts = { {field = 'paused', type = 'boolean'}, {field = 'next_run_at', type = 'integer'} },
+    type = 'tree',
+    unique = false,
+    if_not_exists = true
+})
+
 -- Создание пространтсва 'cmd_tokens' (для валидации токенов в "memory" режиме)
 if not box.space.cmd_tokens then
     box.schema.space.create('cmd_tokens', {
@@ -0,0 +1,90 @@
+package database_test
+
+import (
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/storage/database"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseData тестирует разбор кортежей опроса текущего и старых форматов.
+func TestParseData(t *testing.T) {
+	options := map[interface{}]interface{}{"opt1": int32(1), "opt2": int32(0)}
+	voters := map[interface{}]interface{}{"user1": []interface{}{"opt1"}}
+	fields := []interface{}{"poll_id", "question", options, voters, "creator_id", false}
+
+	// Кортеж исходного формата после обновления схемы из Lua: счетчики вариантов записаны
+	// компактными целыми, а в voters хранился только факт голосования
+	legacy := []interface{}{
+		"poll_id", "question", map[interface{}]interface{}{"opt1": int8(1), "opt2": uint8(0)},
+		map[interface{}]interface{}{"user1": true}, "creator_id", false,
+	}
+
+	t.Run("Full tuple", func(t *testing.T) {
+		tuple := append(append([]interface{}{}, fields...),
+			"post_id", uint64(2), true, false, "channel_id", int64(1735693200), "team_id", int64(1735689600), true, false,
+			map[interface{}]interface{}{"user1": int32(3)}, uint64(2), uint64(0), 0.5, uint64(4))
+
+		poll, err := database.ParseData([]interface{}{tuple})
+		require.NoError(t, err)
+		require.Equal(t, &entities.Poll{
+			PollId: "poll_id", Question: "question", Options: map[string]int32{"opt1": 1, "opt2": 0},
+			Voters: map[string][]string{"user1": {"opt1"}}, Creator: "creator_id", PostId: "post_id", MaxVotes: 2,
+			Public: true, ChannelId: "channel_id", EndsAt: 1735693200, TeamId: "team_id", CreatedAt: 1735689600,
+			OpenOptions: true, Weights: map[string]int32{"user1": 3}, Quorum: 2, Threshold: 0.5, RequiredVoters: 4,
+		}, poll)
+	})
+
+	t.Run("Legacy tuple", func(t *testing.T) {
+		poll, err := database.ParseData([]interface{}{legacy})
+		require.NoError(t, err)
+		require.Equal(t, &entities.Poll{
+			PollId: "poll_id", Question: "question", Options: map[string]int32{"opt1": 1, "opt2": 0},
+			Voters: map[string][]string{"user1": {entities.LegacyChoice}}, Creator: "creator_id", MaxVotes: 1,
+		}, poll)
+	})
+
+	t.Run("Partially upgraded tuple", func(t *testing.T) {
+		tuple := append(append([]interface{}{}, fields...), "post_id", uint64(2), true, false, "channel_id")
+
+		poll, err := database.ParseData([]interface{}{tuple})
+		require.NoError(t, err)
+		require.Equal(t, "post_id", poll.PostId)
+		require.Equal(t, int32(2), poll.MaxVotes)
+		require.Equal(t, "channel_id", poll.ChannelId)
+		require.Zero(t, poll.EndsAt)
+		require.Nil(t, poll.Weights)
+		// Исходный кортеж не изменяется
+		require.Len(t, tuple, 11)
+	})
+
+	t.Run("Upgraded legacy tuple", func(t *testing.T) {
+		tuple := append(append([]interface{}{}, legacy...),
+			"", int8(1), false, false, "", int8(0), "", int8(0), false, false,
+			map[interface{}]interface{}{}, int8(0), int8(0), float64(0), int8(0))
+
+		poll, err := database.ParseData([]interface{}{tuple})
+		require.NoError(t, err)
+		require.Equal(t, &entities.Poll{
+			PollId: "poll_id", Question: "question", Options: map[string]int32{"opt1": 1, "opt2": 0},
+			Voters: map[string][]string{"user1": {entities.LegacyChoice}}, Creator: "creator_id", MaxVotes: 1,
+		}, poll)
+	})
+
+	t.Run("Invalid tuples", func(t *testing.T) {
+		_, err := database.ParseData([]interface{}{fields[:5]})
+		require.EqualError(t, err, "unexpected data format")
+
+		invalidCount := append(append([]interface{}{}, fields...), "")
+		invalidCount[2] = map[interface{}]interface{}{"opt1": "1"}
+		_, err = database.ParseData([]interface{}{invalidCount})
+		require.ErrorContains(t, err, "unexpected value type")
+
+		_, err = database.ParseData([]interface{}{"poll_id"})
+		require.ErrorContains(t, err, "unexpected type for data")
+
+		_, err = database.ParseData(nil)
+		require.Equal(t, entities.NewUserError("poll.not_found"), err)
+	})
+}
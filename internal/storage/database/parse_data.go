@@ -5,22 +5,64 @@ import (
 	"matterpoll-bot/internal/entities"
 )
 
+// Количество полей кортежа опроса в исходном формате пространства polls
+// (id, question, options, voters, creator, closed) и в текущем формате.
+const (
+	legacyPollTupleLen = 6
+	pollTupleLen       = 21
+)
+
+// pollFieldDefaults - значения полей, добавленных в пространство polls после исходного формата
+// (начиная с post_id), для кортежей, сохраненных до обновления схемы: опрос с одним голосом,
+// без срока, весов и кворума.
+var pollFieldDefaults = []interface{}{
+	"", int64(1), false, false, "", int64(0), "", int64(0), false, false,
+	map[interface{}]interface{}{}, int64(0), int64(0), float64(0), int64(0),
+}
+
 // ParseData преобразовывает слайс интерфейсов к ожидаемым типам.
-//   - `pollId`, `questions`, `creator` — строки.
-//   - `options` и `voters` — карты, которые преобразуются с помощью вспомогательных функций.
-//   - `closed` — булево значение.
+//   - `pollId`, `questions`, `creator`, `postId`, `channelId`, `teamId` — строки.
+//   - `options`, `voters` и `weights` — карты, которые преобразуются с помощью вспомогательных функций
+//     (пустая карта весов преобразуется в nil).
+//   - `closed`, `public`, `ranked`, `openOptions` и `hideResults` — булевы значения.
+//   - `maxVotes`, `endsAt`, `createdAt`, `quorum`, `quorumPercent` и `requiredVoters` — целые числа.
+//   - `threshold` — число с плавающей точкой.
+//
+// Кортежи старого формата, в которых нет части полей, дополняются значениями по умолчанию.
 func ParseData(data []interface{}) (*entities.Poll, error) {
 	if len(data) == 0 {
-		return nil, entities.NewUserError("**Invalid Poll_ID or not exists!**")
+		return nil, entities.NewUserError("poll.not_found")
+	}
+
+	return parseTuple(data[0])
+}
+
+// ParseList преобразовывает результат выборки нескольких опросов к слайсу опросов.
+func ParseList(data []interface{}) ([]*entities.Poll, error) {
+	polls := make([]*entities.Poll, 0, len(data))
+	for _, row := range data {
+		poll, err := parseTuple(row)
+		if err != nil {
+			return nil, err
+		}
+		polls = append(polls, poll)
 	}
 
-	tuple, ok := data[0].([]interface{})
+	return polls, nil
+}
+
+// parseTuple преобразовывает кортеж опроса из БД к структуре опроса.
+func parseTuple(row interface{}) (*entities.Poll, error) {
+	tuple, ok := row.([]interface{})
 	if !ok {
-		return nil, fmt.Errorf("unexpected type for data: %v", data)
+		return nil, fmt.Errorf("unexpected type for data: %v", row)
 	}
-	if len(tuple) != 6 {
+	if len(tuple) < legacyPollTupleLen || len(tuple) > pollTupleLen {
 		return nil, fmt.Errorf("unexpected data format")
 	}
+	if len(tuple) < pollTupleLen {
+		tuple = append(tuple[:len(tuple):len(tuple)], pollFieldDefaults[len(tuple)-legacyPollTupleLen:]...)
+	}
 
 	pollId, ok := tuple[0].(string)
 	if !ok {
@@ -45,7 +87,7 @@ func ParseData(data []interface{}) (*entities.Poll, error) {
 	if !ok {
 		return nil, fmt.Errorf("unexpected type for voters: %v", tuple[3])
 	}
-	voters, err := convertMapInterfaceToStringBool(votersRow)
+	voters, err := convertMapInterfaceToStringSlice(votersRow)
 	if err != nil {
 		return nil, err
 	}
@@ -60,5 +102,180 @@ func ParseData(data []interface{}) (*entities.Poll, error) {
 		return nil, fmt.Errorf("unexpected type for closed: %v", tuple[5])
 	}
 
-	return &entities.Poll{PollId: pollId, Question: questions, Options: options, Voters: voters, Creator: creator, Closed: closed}, nil
+	postId, ok := tuple[6].(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for postId: %v", tuple[6])
+	}
+
+	maxVotes, ok := convertToInt64(tuple[7])
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for maxVotes: %v", tuple[7])
+	}
+
+	public, ok := tuple[8].(bool)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for public: %v", tuple[8])
+	}
+
+	ranked, ok := tuple[9].(bool)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for ranked: %v", tuple[9])
+	}
+
+	channelId, ok := tuple[10].(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for channelId: %v", tuple[10])
+	}
+
+	endsAt, ok := convertToInt64(tuple[11])
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for endsAt: %v", tuple[11])
+	}
+
+	teamId, ok := tuple[12].(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for teamId: %v", tuple[12])
+	}
+
+	createdAt, ok := convertToInt64(tuple[13])
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for createdAt: %v", tuple[13])
+	}
+
+	openOptions, ok := tuple[14].(bool)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for openOptions: %v", tuple[14])
+	}
+
+	hideResults, ok := tuple[15].(bool)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for hideResults: %v", tuple[15])
+	}
+
+	weightsRow, ok := tuple[16].(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for weights: %v", tuple[16])
+	}
+	weights, err := convertMapInterfaceToStringInt(weightsRow)
+	if err != nil {
+		return nil, err
+	}
+	if len(weights) == 0 {
+		weights = nil
+	}
+
+	quorum, ok := convertToInt64(tuple[17])
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for quorum: %v", tuple[17])
+	}
+
+	quorumPercent, ok := convertToInt64(tuple[18])
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for quorumPercent: %v", tuple[18])
+	}
+
+	threshold, ok := convertToFloat64(tuple[19])
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for threshold: %v", tuple[19])
+	}
+
+	requiredVoters, ok := convertToInt64(tuple[20])
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for requiredVoters: %v", tuple[20])
+	}
+
+	return &entities.Poll{PollId: pollId, Question: questions, Options: options, Voters: voters, Creator: creator, Closed: closed, PostId: postId, MaxVotes: int32(maxVotes), Public: public, Ranked: ranked, ChannelId: channelId, EndsAt: endsAt, TeamId: teamId, CreatedAt: createdAt, OpenOptions: openOptions, HideResults: hideResults, Weights: weights, Quorum: int32(quorum), QuorumPercent: int32(quorumPercent), Threshold: threshold, RequiredVoters: int32(requiredVoters)}, nil
+}
+
+// ParseSchedule преобразовывает результат выборки расписания к структуре расписания.
+func ParseSchedule(data []interface{}) (*entities.Schedule, error) {
+	if len(data) == 0 {
+		return nil, entities.NewUserError("schedule.not_found")
+	}
+
+	return parseScheduleTuple(data[0])
+}
+
+// ParseScheduleList преобразовывает результат выборки нескольких расписаний к слайсу расписаний.
+func ParseScheduleList(data []interface{}) ([]*entities.Schedule, error) {
+	schedules := make([]*entities.Schedule, 0, len(data))
+	for _, row := range data {
+		schedule, err := parseScheduleTuple(row)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+// parseScheduleTuple преобразовывает кортеж расписания из БД к структуре расписания.
+//   - `scheduleId`, `cron`, `question`, `creator`, `channelId`, `teamId` — строки.
+//   - `options` — массив строк.
+//   - `public`, `ranked` и `paused` — булевы значения.
+//   - `maxVotes`, `duration`, `nextRunAt` и `createdAt` — целые числа.
+func parseScheduleTuple(row interface{}) (*entities.Schedule, error) {
+	tuple, ok := row.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for data: %v", row)
+	}
+	if len(tuple) != 14 {
+		return nil, fmt.Errorf("unexpected data format")
+	}
+
+	strs := make([]string, 0, 6)
+	for _, i := range []int{0, 1, 2, 4, 5, 6} {
+		str, ok := tuple[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type for schedule field %d: %v", i, tuple[i])
+		}
+		strs = append(strs, str)
+	}
+
+	optionsRow, ok := tuple[3].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for options: %v", tuple[3])
+	}
+	options, err := convertToStringSlice(optionsRow)
+	if err != nil {
+		return nil, err
+	}
+
+	maxVotes, ok := convertToInt64(tuple[7])
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for maxVotes: %v", tuple[7])
+	}
+
+	public, ok := tuple[8].(bool)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for public: %v", tuple[8])
+	}
+
+	ranked, ok := tuple[9].(bool)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for ranked: %v", tuple[9])
+	}
+
+	duration, ok := convertToInt64(tuple[10])
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for duration: %v", tuple[10])
+	}
+
+	paused, ok := tuple[11].(bool)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for paused: %v", tuple[11])
+	}
+
+	nextRunAt, ok := convertToInt64(tuple[12])
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for nextRunAt: %v", tuple[12])
+	}
+
+	createdAt, ok := convertToInt64(tuple[13])
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for createdAt: %v", tuple[13])
+	}
+
+	return &entities.Schedule{ScheduleId: strs[0], Cron: strs[1], Question: strs[2], Options: options, Creator: strs[3], ChannelId: strs[4], TeamId: strs[5], MaxVotes: int32(maxVotes), Public: public, Ranked: ranked, Duration: duration, Paused: paused, NextRunAt: nextRunAt, CreatedAt: createdAt}, nil
 }
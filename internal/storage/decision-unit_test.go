@@ -0,0 +1,134 @@
+package storage_test
+
+import (
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/storage"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRequiredVoters тестирует подсчет количества проголосовавших, необходимого для кворума.
+func TestRequiredVoters(t *testing.T) {
+	require.Equal(t, int32(0), storage.RequiredVoters(&entities.Poll{}, 30))
+	require.Equal(t, int32(10), storage.RequiredVoters(&entities.Poll{Quorum: 10}, 30))
+	require.Equal(t, int32(11), storage.RequiredVoters(&entities.Poll{QuorumPercent: 50}, 21))
+	require.Equal(t, int32(15), storage.RequiredVoters(&entities.Poll{Quorum: 10, QuorumPercent: 50}, 30))
+	require.Equal(t, int32(10), storage.RequiredVoters(&entities.Poll{Quorum: 10, QuorumPercent: 50}, 4))
+}
+
+// TestEvaluateDecision тестирует подведение итога опроса для принятия решения.
+func TestEvaluateDecision(t *testing.T) {
+	poll := &entities.Poll{
+		Options:        map[string]int32{"Yes": 2, "No": 1},
+		Voters:         map[string][]string{"user1": {"Yes"}, "user2": {"Yes"}, "user3": {"No"}},
+		MaxVotes:       1,
+		Threshold:      2.0 / 3,
+		RequiredVoters: 3,
+	}
+
+	decision, leader, share := storage.EvaluateDecision(poll)
+	require.Equal(t, storage.DecisionPassed, decision)
+	require.Equal(t, "Yes", leader)
+	require.InDelta(t, 2.0/3, share, 1e-9)
+
+	t.Run("No quorum", func(t *testing.T) {
+		noQuorum := *poll
+		noQuorum.RequiredVoters = 4
+
+		decision, leader, _ := storage.EvaluateDecision(&noQuorum)
+		require.Equal(t, storage.DecisionNoQuorum, decision)
+		require.Empty(t, leader)
+	})
+
+	t.Run("Quorum unknown", func(t *testing.T) {
+		unknown := *poll
+		unknown.RequiredVoters = entities.QuorumUnknown
+
+		decision, leader, _ := storage.EvaluateDecision(&unknown)
+		require.Equal(t, storage.DecisionQuorumUnknown, decision)
+		require.Empty(t, leader)
+	})
+
+	t.Run("Below threshold", func(t *testing.T) {
+		strict := *poll
+		strict.Threshold = 0.75
+
+		decision, leader, _ := storage.EvaluateDecision(&strict)
+		require.Equal(t, storage.DecisionFailed, decision)
+		require.Equal(t, "Yes", leader)
+	})
+
+	t.Run("Tie", func(t *testing.T) {
+		tie := *poll
+		tie.Options = map[string]int32{"Yes": 1, "No": 1}
+		tie.Voters = map[string][]string{"user1": {"Yes"}, "user2": {"No"}}
+		tie.Threshold = 0
+		tie.RequiredVoters = 0
+
+		decision, leader, _ := storage.EvaluateDecision(&tie)
+		require.Equal(t, storage.DecisionFailed, decision)
+		require.Empty(t, leader)
+	})
+
+	t.Run("Weighted poll", func(t *testing.T) {
+		weighted := *poll
+		weighted.Options = map[string]int32{"Yes": 2, "No": 3}
+		weighted.Weights = map[string]int32{"user3": 3}
+
+		decision, leader, share := storage.EvaluateDecision(&weighted)
+		require.Equal(t, storage.DecisionFailed, decision)
+		require.Equal(t, "No", leader)
+		require.InDelta(t, 0.6, share, 1e-9)
+	})
+
+	t.Run("Ranked poll", func(t *testing.T) {
+		ranked := &entities.Poll{
+			Options: map[string]int32{"A": 2, "B": 1, "C": 1},
+			Voters: map[string][]string{
+				"user1": {"A", "B"}, "user2": {"A", "C"}, "user3": {"B", "A"}, "user4": {"C", "B"},
+			},
+			MaxVotes:  1,
+			Ranked:    true,
+			Threshold: 0.5,
+		}
+
+		decision, leader, share := storage.EvaluateDecision(ranked)
+		require.Equal(t, storage.DecisionPassed, decision)
+		require.Equal(t, "A", leader)
+		require.GreaterOrEqual(t, share, 0.5)
+	})
+}
+
+// TestPrintDecision тестирует вывод итога и условий опроса для принятия решения.
+func TestPrintDecision(t *testing.T) {
+	poll := &entities.Poll{
+		Options:        map[string]int32{"Yes": 2, "No": 1},
+		Voters:         map[string][]string{"user1": {"Yes"}, "user2": {"Yes"}, "user3": {"No"}},
+		MaxVotes:       1,
+		Quorum:         3,
+		Threshold:      0.6,
+		RequiredVoters: 3,
+	}
+
+	require.Empty(t, storage.PrintDecision(poll, "en"))
+	require.Equal(t, "*Decision poll: at least 3 voters, 60.0% of votes to pass.*", storage.PrintDecisionRules(poll, "en"))
+	require.Empty(t, storage.PrintDecisionRules(&entities.Poll{}, "en"))
+
+	poll.Closed = true
+	require.Equal(t, "**Decision**: ✅ PASSED — `Yes` (`66.7％` of votes, `60.0％` required)", storage.PrintDecision(poll, "en"))
+
+	poll.RequiredVoters = 5
+	require.Equal(t, "**Decision**: ⚪ NO QUORUM (voters: `3` of `5` required)", storage.PrintDecision(poll, "en"))
+
+	poll.RequiredVoters = entities.QuorumUnknown
+	require.Equal(t, "**Decision**: ⚪ QUORUM UNKNOWN (voters: `3`, the number of channel members was unavailable when the poll was closed)", storage.PrintDecision(poll, "en"))
+
+	poll.RequiredVoters = 3
+	poll.Threshold = 0.7
+	require.Equal(t, "**Decision**: ❌ FAILED — `Yes` (`66.7％` of votes, `70.0％` required)", storage.PrintDecision(poll, "en"))
+	require.Equal(t, "**Решение**: ❌ НЕ ПРИНЯТО — `Yes` (`66.7％` голосов, необходимо `70.0％`)", storage.PrintDecision(poll, "ru"))
+	require.Equal(t, "*Опрос для принятия решения: не меньше 3 проголосовавших, 70.0% голосов для принятия.*", storage.PrintDecisionRules(poll, "ru"))
+
+	require.Empty(t, storage.PrintDecision(&entities.Poll{Closed: true}, "en"))
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
+	"strings"
+)
+
+// Итоги опроса для принятия решения.
+const (
+	DecisionPassed        = "PASSED"
+	DecisionFailed        = "FAILED"
+	DecisionNoQuorum      = "NO QUORUM"
+	DecisionQuorumUnknown = "QUORUM UNKNOWN"
+)
+
+// IsDecision сообщает, задан ли для опроса кворум или порог принятия решения.
+func IsDecision(poll *entities.Poll) bool {
+	return poll.Quorum > 0 || poll.QuorumPercent > 0 || poll.Threshold > 0
+}
+
+// RequiredVoters возвращает количество проголосовавших, необходимое для кворума опроса
+// в канале с members участниками: наибольшее из минимального числа проголосовавших
+// и доли участников канала (с округлением вверх).
+func RequiredVoters(poll *entities.Poll, members int64) int32 {
+	required := int64(poll.Quorum)
+	if poll.QuorumPercent > 0 {
+		required = max(required, (members*int64(poll.QuorumPercent)+99)/100)
+	}
+
+	return int32(required)
+}
+
+// EvaluateDecision подводит итог опроса для принятия решения.
+// Если при закрытии опроса кворум вычислить не удалось (poll.RequiredVoters равно entities.QuorumUnknown),
+// решение не принимается. Если проголосовало меньше poll.RequiredVoters пользователей, кворум не набран.
+// Иначе решение принимается, если у опроса есть единственный лидирующий вариант (для рейтингового опроса -
+// победитель мгновенного второго тура), доля голосов которого не меньше порога poll.Threshold.
+// Возвращает итог, лидирующий вариант (пустая строка, если его нет) и долю его голосов.
+func EvaluateDecision(poll *entities.Poll) (string, string, float64) {
+	if poll.RequiredVoters == entities.QuorumUnknown {
+		return DecisionQuorumUnknown, "", 0
+	}
+	if int32(len(poll.Voters)) < poll.RequiredVoters {
+		return DecisionNoQuorum, "", 0
+	}
+
+	leader, share := leadingOption(poll)
+	if leader == "" || share < poll.Threshold {
+		return DecisionFailed, leader, share
+	}
+
+	return DecisionPassed, leader, share
+}
+
+// leadingOption возвращает единственный лидирующий вариант опроса и долю его голосов.
+// Для рейтингового опроса доля считается по последнему туру подсчета.
+// Если голосов нет или несколько вариантов набрали поровну голосов, возвращается пустая строка.
+func leadingOption(poll *entities.Poll) (string, float64) {
+	if poll.Ranked {
+		rounds, winner := InstantRunoff(poll)
+		if winner == "" {
+			return "", 0
+		}
+
+		var total int32
+		counts := rounds[len(rounds)-1].Counts
+		for _, count := range counts {
+			total += count
+		}
+
+		return winner, float64(counts[winner]) / float64(total)
+	}
+
+	var leader string
+	var top int32
+	tie := false
+	for option, count := range poll.Options {
+		switch {
+		case count > top:
+			leader, top, tie = option, count, false
+		case count == top:
+			tie = true
+		}
+	}
+	if top == 0 || tie {
+		return "", 0
+	}
+
+	return leader, float64(top) / float64(TotalWeight(poll))
+}
+
+// PrintDecision возвращает строку с итогом закрытого опроса для принятия решения на языке locale.
+// Для открытого опроса и опроса без кворума и порога возвращается пустая строка.
+func PrintDecision(poll *entities.Poll, locale string) string {
+	if !poll.Closed || !IsDecision(poll) {
+		return ""
+	}
+
+	decision, leader, share := EvaluateDecision(poll)
+	switch decision {
+	case DecisionNoQuorum:
+		return i18n.T(locale, "decision.no_quorum", len(poll.Voters), poll.RequiredVoters)
+	case DecisionQuorumUnknown:
+		return i18n.T(locale, "decision.quorum_unknown", len(poll.Voters))
+	case DecisionPassed:
+		return i18n.T(locale, "decision.passed", leader, printShare(poll, share, locale))
+	}
+
+	if leader == "" {
+		return i18n.T(locale, "decision.failed_no_leader")
+	}
+	return i18n.T(locale, "decision.failed", leader, printShare(poll, share, locale))
+}
+
+// PrintDecisionRules возвращает строку с условиями принятия решения опроса на языке locale
+// или пустую строку, если кворум и порог не заданы.
+func PrintDecisionRules(poll *entities.Poll, locale string) string {
+	var rules []string
+	if poll.Quorum > 0 {
+		rules = append(rules, i18n.T(locale, "decision.rule_quorum", poll.Quorum))
+	}
+	if poll.QuorumPercent > 0 {
+		rules = append(rules, i18n.T(locale, "decision.rule_quorum_percent", poll.QuorumPercent))
+	}
+	if poll.Threshold > 0 {
+		rules = append(rules, i18n.T(locale, "decision.rule_threshold", poll.Threshold*100))
+	}
+	if len(rules) == 0 {
+		return ""
+	}
+
+	return i18n.T(locale, "decision.rules", strings.Join(rules, ", "))
+}
+
+// printShare возвращает долю голосов лидирующего варианта и, если задан, порог принятия решения на языке locale.
+func printShare(poll *entities.Poll, share float64, locale string) string {
+	if poll.Threshold > 0 {
+		return i18n.T(locale, "decision.share_threshold", share*100, poll.Threshold*100)
+	}
+
+	return i18n.T(locale, "decision.share", share*100)
+}
@@ -0,0 +1,114 @@
+package storage_test
+
+import (
+	"encoding/json"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/storage"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestExportResults тестирует выгрузку результатов опроса в форматы CSV и JSON.
+func TestExportResults(t *testing.T) {
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Question: "Question, with comma",
+		Options:  map[string]int32{"1": 2, "2": 1, "3": 0},
+		Voters:   map[string][]string{"user1": {"1"}, "user2": {"1", "2"}, "user3": {}},
+		MaxVotes: 2,
+	}
+
+	t.Run("Anonymous poll CSV", func(t *testing.T) {
+		data, err := storage.ExportCSV(poll, map[string]string{"user1": "alice"})
+		require.NoError(t, err)
+		require.Equal(t, "poll_id,question,status,voters\n"+
+			"poll1,\"Question, with comma\",active,3\n"+
+			"\n"+
+			"option,votes,percent\n"+
+			"1,2,66.67\n"+
+			"2,1,33.33\n"+
+			"3,0,0.00\n", string(data))
+	})
+
+	t.Run("Public poll CSV", func(t *testing.T) {
+		publicPoll := *poll
+		publicPoll.Public = true
+		publicPoll.Closed = true
+
+		data, err := storage.ExportCSV(&publicPoll, map[string]string{"user1": "alice", "user2": "bob"})
+		require.NoError(t, err)
+		require.Contains(t, string(data), "poll1,\"Question, with comma\",closed,3\n")
+		require.Contains(t, string(data), "\nvoter,option,rank\n@alice,1,\n@bob,1,\n@bob,2,\n")
+	})
+
+	t.Run("Ranked poll CSV", func(t *testing.T) {
+		rankedPoll := *poll
+		rankedPoll.Public = true
+		rankedPoll.Ranked = true
+
+		data, err := storage.ExportCSV(&rankedPoll, nil)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "\nvoter,option,rank\nuser1,1,1\nuser2,1,1\nuser2,2,2\n")
+	})
+
+	t.Run("Anonymous poll JSON", func(t *testing.T) {
+		data, err := storage.ExportJSON(poll, map[string]string{"user1": "alice"})
+		require.NoError(t, err)
+
+		var export map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &export))
+		require.Equal(t, "active", export["status"])
+		require.EqualValues(t, 3, export["voters"])
+		require.Len(t, export["options"], 3)
+		require.NotContains(t, export, "votes")
+		require.NotContains(t, string(data), "alice")
+	})
+
+	t.Run("Public poll JSON", func(t *testing.T) {
+		publicPoll := *poll
+		publicPoll.Public = true
+
+		data, err := storage.ExportJSON(&publicPoll, map[string]string{"user1": "alice"})
+		require.NoError(t, err)
+
+		var export storage.PollExport
+		require.NoError(t, json.Unmarshal(data, &export))
+		require.Equal(t, storage.OptionExport{Option: "1", Votes: 2, Percent: 66.67}, export.Options[0])
+		require.Equal(t, []storage.VoterExport{
+			{Voter: "@alice", Options: []string{"1"}},
+			{Voter: "user2", Options: []string{"1", "2"}},
+			{Voter: "user3", Options: []string{}},
+		}, export.Votes)
+	})
+
+	t.Run("Weighted poll", func(t *testing.T) {
+		weightedPoll := *poll
+		weightedPoll.Options = map[string]int32{"1": 4, "2": 3, "3": 0}
+		weightedPoll.Weights = map[string]int32{"user2": 3}
+
+		data, err := storage.ExportCSV(&weightedPoll, nil)
+		require.NoError(t, err)
+		require.Equal(t, "poll_id,question,status,voters,total_weight\n"+
+			"poll1,\"Question, with comma\",active,3,5\n"+
+			"\n"+
+			"option,votes,weight,percent\n"+
+			"1,2,4,80.00\n"+
+			"2,1,3,60.00\n"+
+			"3,0,0,0.00\n", string(data))
+
+		data, err = storage.ExportJSON(&weightedPoll, nil)
+		require.NoError(t, err)
+
+		var export map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &export))
+		require.EqualValues(t, 5, export["total_weight"])
+		require.Equal(t, map[string]interface{}{"option": "1", "votes": 2.0, "weight": 4.0, "percent": 80.0}, export["options"].([]interface{})[0])
+	})
+
+	t.Run("Unweighted poll JSON has no weights", func(t *testing.T) {
+		data, err := storage.ExportJSON(poll, nil)
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "weight")
+	})
+}
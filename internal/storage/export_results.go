@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"matterpoll-bot/internal/entities"
+	"sort"
+	"strconv"
+)
+
+// PollExport - результаты опроса в виде, пригодном для выгрузки в файл.
+type PollExport struct {
+	PollId      string         `json:"poll_id"`
+	Question    string         `json:"question"`
+	Status      string         `json:"status"`
+	Voters      int            `json:"voters"`
+	TotalWeight *int32         `json:"total_weight,omitempty"`
+	Options     []OptionExport `json:"options"`
+	Votes       []VoterExport  `json:"votes,omitempty"`
+}
+
+// OptionExport - количество и процент голосов за вариант ответа.
+// Для взвешенного опроса Votes - количество бюллетеней без учета весов, а Weight - их суммарный вес.
+type OptionExport struct {
+	Option  string  `json:"option"`
+	Votes   int32   `json:"votes"`
+	Weight  *int32  `json:"weight,omitempty"`
+	Percent float64 `json:"percent"`
+}
+
+// VoterExport - выбор отдельного пользователя (для рейтингового опроса - в порядке предпочтения).
+type VoterExport struct {
+	Voter   string   `json:"voter"`
+	Options []string `json:"options"`
+}
+
+// NewPollExport формирует результаты опроса для выгрузки.
+// Процент голосов считается от общего веса проголосовавших, как и в таблице PrintTable.
+// Для взвешенного опроса выгружаются и количество бюллетеней, и их суммарный вес.
+// Выбор отдельных пользователей добавляется только для публичного опроса,
+// имена которых берутся из voterNames по идентификатору (при отсутствии имени выводится идентификатор).
+func NewPollExport(poll *entities.Poll, voterNames map[string]string) *PollExport {
+	export := &PollExport{
+		PollId:   poll.PollId,
+		Question: poll.Question,
+		Status:   "active",
+		Voters:   len(poll.Voters),
+		Options:  make([]OptionExport, 0, len(poll.Options)),
+	}
+	if poll.Closed {
+		export.Status = "closed"
+	}
+
+	weighted := len(poll.Weights) != 0
+	totalWeight := TotalWeight(poll)
+	if weighted {
+		export.TotalWeight = &totalWeight
+	}
+	ballots := RawBallots(poll)
+
+	for _, option := range SortedOptions(poll) {
+		count := poll.Options[option]
+		var percent float64
+		if totalWeight != 0 {
+			percent = math.Round(float64(count)/float64(totalWeight)*10000) / 100
+		}
+		optionExport := OptionExport{Option: option, Votes: count, Percent: percent}
+		if weighted {
+			optionExport.Votes = ballots[option]
+			optionExport.Weight = &count
+		}
+		export.Options = append(export.Options, optionExport)
+	}
+
+	if !poll.Public {
+		return export
+	}
+
+	for userId, choices := range poll.Voters {
+		name := userId
+		if username, ok := voterNames[userId]; ok {
+			name = "@" + username
+		}
+		export.Votes = append(export.Votes, VoterExport{Voter: name, Options: choices})
+	}
+	sort.Slice(export.Votes, func(i, j int) bool { return export.Votes[i].Voter < export.Votes[j].Voter })
+
+	return export
+}
+
+// ExportJSON возвращает результаты опроса в формате JSON.
+func ExportJSON(poll *entities.Poll, voterNames map[string]string) ([]byte, error) {
+	data, err := json.MarshalIndent(NewPollExport(poll, voterNames), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal poll results: %w", err)
+	}
+
+	return data, nil
+}
+
+// ExportCSV возвращает результаты опроса в формате CSV.
+// Файл состоит из разделенных пустой строкой секций: сведения об опросе, голоса по вариантам
+// и, для публичного опроса, по одной строке на каждый выбор пользователя.
+// Для взвешенного опроса добавляются столбцы с общим весом проголосовавших и весом голосов за вариант.
+func ExportCSV(poll *entities.Poll, voterNames map[string]string) ([]byte, error) {
+	export := NewPollExport(poll, voterNames)
+
+	weighted := export.TotalWeight != nil
+
+	info := [][]string{
+		{"poll_id", "question", "status", "voters"},
+		{export.PollId, export.Question, export.Status, strconv.Itoa(export.Voters)},
+	}
+	optionHeader := []string{"option", "votes", "percent"}
+	if weighted {
+		info[0] = append(info[0], "total_weight")
+		info[1] = append(info[1], strconv.Itoa(int(*export.TotalWeight)))
+		optionHeader = []string{"option", "votes", "weight", "percent"}
+	}
+
+	records := append(info, []string{}, optionHeader)
+	for _, option := range export.Options {
+		record := []string{option.Option, strconv.Itoa(int(option.Votes))}
+		if weighted {
+			record = append(record, strconv.Itoa(int(*option.Weight)))
+		}
+		records = append(records, append(record, strconv.FormatFloat(option.Percent, 'f', 2, 64)))
+	}
+
+	if len(export.Votes) != 0 {
+		records = append(records, []string{}, []string{"voter", "option", "rank"})
+		for _, vote := range export.Votes {
+			for i, option := range vote.Options {
+				rank := ""
+				if poll.Ranked {
+					rank = strconv.Itoa(i + 1)
+				}
+				records = append(records, []string{vote.Voter, option, rank})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, fmt.Errorf("failed to write poll results: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,49 @@
+package storage_test
+
+import (
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/storage"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFilterPolls тестирует отбор, упорядочивание и разбиение на страницы списка опросов.
+func TestFilterPolls(t *testing.T) {
+	polls := []*entities.Poll{
+		{PollId: "poll1", Question: "B", ChannelId: "channel1", Creator: "user1", Closed: true},
+		{PollId: "poll2", Question: "B", ChannelId: "channel1", Creator: "user2"},
+		{PollId: "poll3", Question: "A", ChannelId: "channel2", Creator: "user1"},
+		{PollId: "poll4", Question: "B", ChannelId: "channel1", Creator: "user1"},
+	}
+
+	open, closed := false, true
+	tests := []struct {
+		name     string
+		filter   *entities.PollFilter
+		expected []string
+		total    int
+	}{
+		{"Without conditions", &entities.PollFilter{}, []string{"poll3", "poll2", "poll4", "poll1"}, 4},
+		{"Channel", &entities.PollFilter{ChannelId: "channel1"}, []string{"poll2", "poll4", "poll1"}, 3},
+		{"Creator", &entities.PollFilter{Creator: "user1"}, []string{"poll3", "poll4", "poll1"}, 3},
+		{"Open", &entities.PollFilter{Closed: &open}, []string{"poll3", "poll2", "poll4"}, 3},
+		{"Closed", &entities.PollFilter{Closed: &closed}, []string{"poll1"}, 1},
+		{"First page", &entities.PollFilter{Limit: 3}, []string{"poll3", "poll2", "poll4"}, 4},
+		{"Last page", &entities.PollFilter{Offset: 3, Limit: 3}, []string{"poll1"}, 4},
+		{"Page out of range", &entities.PollFilter{Offset: 6, Limit: 3}, []string{}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, total := storage.FilterPolls(polls, tt.filter)
+			require.Equal(t, tt.total, total)
+
+			ids := []string{}
+			for _, poll := range page {
+				ids = append(ids, poll.PollId)
+			}
+			require.Equal(t, tt.expected, ids)
+		})
+	}
+}
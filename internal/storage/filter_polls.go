@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"matterpoll-bot/internal/entities"
+	"sort"
+)
+
+// MatchPoll проверяет, удовлетворяет ли опрос условиям filter (без учета Offset и Limit).
+func MatchPoll(poll *entities.Poll, filter *entities.PollFilter) bool {
+	if filter.ChannelId != "" && poll.ChannelId != filter.ChannelId {
+		return false
+	}
+	if filter.Creator != "" && poll.Creator != filter.Creator {
+		return false
+	}
+	if filter.Closed != nil && poll.Closed != *filter.Closed {
+		return false
+	}
+
+	return true
+}
+
+// FilterPolls отбирает опросы, удовлетворяющие условиям filter, упорядочивает их
+// (сначала открытые, затем по вопросу и идентификатору) и возвращает страницу,
+// заданную Offset и Limit, вместе с общим количеством подходящих опросов.
+func FilterPolls(polls []*entities.Poll, filter *entities.PollFilter) ([]*entities.Poll, int) {
+	matched := []*entities.Poll{}
+	for _, poll := range polls {
+		if MatchPoll(poll, filter) {
+			matched = append(matched, poll)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Closed != matched[j].Closed {
+			return !matched[i].Closed
+		}
+		if matched[i].Question != matched[j].Question {
+			return matched[i].Question < matched[j].Question
+		}
+		return matched[i].PollId < matched[j].PollId
+	})
+
+	total := len(matched)
+	if filter.Offset >= total {
+		return []*entities.Poll{}, total
+	}
+
+	matched = matched[filter.Offset:]
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+
+	return matched, total
+}
@@ -0,0 +1,84 @@
+package storage_test
+
+import (
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/storage"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestInstantRunoff тестирует подсчет результатов рейтингового опроса методом мгновенного второго тура.
+func TestInstantRunoff(t *testing.T) {
+	newPoll := func(ballots map[string][]string) *entities.Poll {
+		return &entities.Poll{
+			Options: map[string]int32{"A": 0, "B": 0, "C": 0},
+			Voters:  ballots,
+			Ranked:  true,
+		}
+	}
+
+	t.Run("Majority in first round", func(t *testing.T) {
+		poll := newPoll(map[string][]string{
+			"user1": {"A", "B"},
+			"user2": {"A"},
+			"user3": {"B", "A"},
+		})
+
+		rounds, winner := storage.InstantRunoff(poll)
+		require.Equal(t, "A", winner)
+		require.Len(t, rounds, 1)
+		require.Equal(t, map[string]int32{"A": 2, "B": 1, "C": 0}, rounds[0].Counts)
+	})
+
+	t.Run("Weighted ballots", func(t *testing.T) {
+		poll := newPoll(map[string][]string{
+			"user1": {"A", "B"},
+			"user2": {"A"},
+			"user3": {"B", "A"},
+		})
+		poll.Weights = map[string]int32{"user3": 3}
+
+		rounds, winner := storage.InstantRunoff(poll)
+		require.Equal(t, "B", winner)
+		require.Len(t, rounds, 1)
+		require.Equal(t, map[string]int32{"A": 2, "B": 3, "C": 0}, rounds[0].Counts)
+	})
+
+	t.Run("Votes transferred after elimination", func(t *testing.T) {
+		poll := newPoll(map[string][]string{
+			"user1": {"A"},
+			"user2": {"A"},
+			"user3": {"B"},
+			"user4": {"B"},
+			"user5": {"C", "B"},
+		})
+
+		rounds, winner := storage.InstantRunoff(poll)
+		require.Equal(t, "B", winner)
+		require.Len(t, rounds, 2)
+		require.Equal(t, []string{"C"}, rounds[0].Eliminated)
+		require.Equal(t, map[string]int32{"A": 2, "B": 3}, rounds[1].Counts)
+	})
+
+	t.Run("Exhausted ballots", func(t *testing.T) {
+		poll := newPoll(map[string][]string{
+			"user1": {"A"},
+			"user2": {"A"},
+			"user3": {"B"},
+			"user4": {"B"},
+			"user5": {"C"},
+		})
+
+		rounds, winner := storage.InstantRunoff(poll)
+		require.Empty(t, winner)
+		require.Len(t, rounds, 2)
+		require.Equal(t, []string{"A", "B"}, rounds[1].Eliminated)
+	})
+
+	t.Run("No ballots", func(t *testing.T) {
+		rounds, winner := storage.InstantRunoff(newPoll(map[string][]string{}))
+		require.Empty(t, winner)
+		require.Len(t, rounds, 1)
+	})
+}
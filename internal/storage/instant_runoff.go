@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"matterpoll-bot/internal/entities"
+	"sort"
+)
+
+// RunoffRound представляет один тур подсчета голосов методом мгновенного второго тура.
+type RunoffRound struct {
+	Counts     map[string]int32 // Counts - количество голосов за каждый оставшийся вариант в туре.
+	Eliminated []string         // Eliminated - варианты, выбывшие по итогам тура.
+}
+
+// InstantRunoff подсчитывает результаты рейтингового опроса методом мгновенного второго тура.
+// В каждом туре голос бюллетеня (с весом голоса его автора) отдается наиболее предпочтительному из оставшихся вариантов,
+// а варианты с наименьшим количеством голосов выбывают, пока один из вариантов не наберет
+// больше половины голосов или не останется единственным.
+// Возвращает туры подсчета и победителя (пустая строка, если победитель не определен).
+func InstantRunoff(poll *entities.Poll) ([]RunoffRound, string) {
+	active := make(map[string]bool, len(poll.Options))
+	for option := range poll.Options {
+		active[option] = true
+	}
+
+	var rounds []RunoffRound
+	for len(active) > 0 {
+		counts := make(map[string]int32, len(active))
+		for option := range active {
+			counts[option] = 0
+		}
+
+		var total int32
+		for userId, ballot := range poll.Voters {
+			weight := VoterWeight(poll, userId)
+			for _, choice := range ballot {
+				if active[choice] {
+					counts[choice] += weight
+					total += weight
+					break
+				}
+			}
+		}
+
+		round := RunoffRound{Counts: counts}
+		if total == 0 {
+			return append(rounds, round), ""
+		}
+
+		for option, count := range counts {
+			if count*2 > total || len(active) == 1 {
+				return append(rounds, round), option
+			}
+		}
+
+		minCount := total
+		for _, count := range counts {
+			minCount = min(minCount, count)
+		}
+		for option, count := range counts {
+			if count == minCount {
+				round.Eliminated = append(round.Eliminated, option)
+			}
+		}
+		sort.Strings(round.Eliminated)
+		rounds = append(rounds, round)
+
+		// Все оставшиеся варианты набрали поровну голосов — победитель не определен
+		if len(round.Eliminated) == len(active) {
+			return rounds, ""
+		}
+
+		for _, option := range round.Eliminated {
+			delete(active, option)
+		}
+	}
+
+	return rounds, ""
+}
@@ -1,7 +1,6 @@
 package memory
 
 import (
-	"fmt"
 	"matterpoll-bot/internal/entities"
 	"matterpoll-bot/internal/storage"
 	"sync"
@@ -9,6 +8,9 @@ import (
 
 type Memory struct {
 	polls     map[string]*entities.Poll
+	byChannel map[string]map[string]bool // byChannel - идентификаторы опросов каждого канала.
+	byCreator map[string]map[string]bool // byCreator - идентификаторы опросов каждого создателя.
+	schedules map[string]*entities.Schedule
 	cmdTokens map[string]string
 	mu        sync.RWMutex
 }
@@ -17,6 +19,9 @@ type Memory struct {
 func NewMemoryStore() *Memory {
 	return &Memory{
 		polls:     map[string]*entities.Poll{},
+		byChannel: map[string]map[string]bool{},
+		byCreator: map[string]map[string]bool{},
+		schedules: map[string]*entities.Schedule{},
 		cmdTokens: map[string]string{},
 	}
 }
@@ -26,79 +31,324 @@ func (m *Memory) CreatePoll(poll *entities.Poll) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.polls[poll.PollId] = poll
+	addToIndex(m.byChannel, poll.ChannelId, poll.PollId)
+	addToIndex(m.byCreator, poll.Creator, poll.PollId)
+
+	return nil
+}
+
+// GetPoll возвращает копию опроса из внутренней памяти.
+func (m *Memory) GetPoll(pollId string) (*entities.Poll, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	poll, err := m.getPoll(pollId)
+	if err != nil {
+		return nil, err
+	}
+
+	return copyPoll(poll), nil
+}
+
+// SetPollPost сохраняет идентификатор сообщения, в котором опубликован опрос.
+func (m *Memory) SetPollPost(pollId, postId string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	poll, err := m.getPoll(pollId)
+	if err != nil {
+		return err
+	}
+	poll.PostId = postId
 
 	return nil
 }
 
 // Vote регистрирует голос пользователя в опросе,
 // в соответствии с выбранным вариантом и обновляет данные во внутренней памяти.
-func (m *Memory) Vote(voice *entities.Voice) (string, error) {
+func (m *Memory) Vote(voice *entities.Voice) (*entities.Message, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	poll := m.polls[voice.PollId]
-	if poll == nil {
-		return "", entities.NewUserError("**Invalid Poll_ID or not exists!**")
+	poll, err := m.getPoll(voice.PollId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.AddVoice(poll, voice); err != nil {
+		return nil, err
 	}
 
-	if err := storage.ValidateVoice(poll, voice); err != nil {
-		return "", err
+	return entities.NewMessage("vote.recorded"), nil
+}
+
+// RetractVote отзывает голос пользователя в опросе и обновляет данные во внутренней памяти.
+// Если вариант в голосе не указан, отзываются все варианты, выбранные пользователем.
+func (m *Memory) RetractVote(voice *entities.Voice) (*entities.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	poll, err := m.getPoll(voice.PollId)
+	if err != nil {
+		return nil, err
 	}
 
-	poll.Options[voice.Option]++
-	poll.Voters[voice.UserId] = true
+	if err := storage.RemoveVoice(poll, voice); err != nil {
+		return nil, err
+	}
 
-	return "**Voice recorded!**", nil
+	return entities.NewMessage("vote.retracted"), nil
 }
 
-// GetPollResult получает результаты опроса из внутренней памяти.
-func (m *Memory) GetPollResult(pollId string) (string, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+// ChangeVote заменяет выбор пользователя в опросе на новый вариант и обновляет данные во внутренней памяти.
+func (m *Memory) ChangeVote(voice *entities.Voice) (*entities.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	poll, err := m.getPoll(voice.PollId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.ChangeVoice(poll, voice); err != nil {
+		return nil, err
+	}
+
+	return entities.NewMessage("vote.changed"), nil
+}
+
+// AddOption добавляет в опрос с открытыми вариантами новый вариант и обновляет данные во внутренней памяти.
+func (m *Memory) AddOption(pollId, option string) (*entities.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	poll, err := m.getPoll(pollId)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	tbl := storage.PrintTable(poll)
+	if err := storage.AddOption(poll, option); err != nil {
+		return nil, err
+	}
+
+	return entities.NewMessage("option.added"), nil
+}
+
+// ListPolls возвращает копии опросов, удовлетворяющих условиям filter, и общее количество таких опросов.
+// Если в фильтре указан канал или создатель, опросы выбираются по соответствующему индексу.
+func (m *Memory) ListPolls(filter *entities.PollFilter) ([]*entities.Poll, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var candidates []*entities.Poll
+	switch {
+	case filter.ChannelId != "":
+		candidates = m.indexedPolls(m.byChannel[filter.ChannelId])
+	case filter.Creator != "":
+		candidates = m.indexedPolls(m.byCreator[filter.Creator])
+	default:
+		candidates = make([]*entities.Poll, 0, len(m.polls))
+		for _, poll := range m.polls {
+			candidates = append(candidates, poll)
+		}
+	}
+
+	page, total := storage.FilterPolls(candidates, filter)
+	polls := make([]*entities.Poll, 0, len(page))
+	for _, poll := range page {
+		polls = append(polls, copyPoll(poll))
+	}
 
-	return tbl, nil
+	return polls, total, nil
 }
 
-// ClosePoll закрывает опрос и обновляет данные во внутренней памяти.
-func (m *Memory) ClosePoll(pollId, userId string) (string, error) {
+// ListDuePolls возвращает копии открытых опросов, срок которых истек к моменту now (в формате Unix).
+func (m *Memory) ListDuePolls(now int64) ([]*entities.Poll, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	polls := []*entities.Poll{}
+	for _, poll := range m.polls {
+		if !poll.Closed && poll.EndsAt > 0 && poll.EndsAt <= now {
+			polls = append(polls, copyPoll(poll))
+		}
+	}
+
+	return polls, nil
+}
+
+// ClosePoll закрывает опрос, сохраняет количество проголосовавших requiredVoters, необходимое для кворума,
+// и обновляет данные во внутренней памяти.
+// Действие разрешено создателю опроса userId или модератору (moderator).
+func (m *Memory) ClosePoll(pollId, userId string, moderator bool, requiredVoters int32) (*entities.Message, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	poll, err := m.getPoll(pollId)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if poll.Closed {
-		return "", entities.NewUserError(fmt.Sprintf("*Poll*: `%s` **has already been closed!**", pollId))
+		return nil, entities.NewUserError("poll.already_closed", pollId)
 	}
-	if poll.Creator != userId {
-		return "", entities.NewUserError("**You don't have the permission to close a vote!**")
+	if !storage.CanManagePoll(poll, userId, moderator) {
+		return nil, entities.NewUserError("poll.close_forbidden")
 	}
 	poll.Closed = true
+	poll.RequiredVoters = requiredVoters
 
-	return fmt.Sprintf("*Poll*: `%s` **has been successfully closed!**", pollId), nil
+	return entities.NewMessage("poll.closed", pollId), nil
+}
+
+// ReopenPoll снова открывает закрытый опрос и снимает срок его автоматического закрытия.
+// Действие разрешено создателю опроса userId или модератору (moderator).
+func (m *Memory) ReopenPoll(pollId, userId string, moderator bool) (*entities.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	poll, err := m.getPoll(pollId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !poll.Closed {
+		return nil, entities.NewUserError("poll.not_closed", pollId)
+	}
+	if !storage.CanManagePoll(poll, userId, moderator) {
+		return nil, entities.NewUserError("poll.reopen_forbidden")
+	}
+	poll.Closed = false
+	poll.EndsAt = 0
+
+	return entities.NewMessage("poll.reopened", pollId), nil
 }
 
 // DeletePoll удаляет опрос из внутренней памяти.
-func (m *Memory) DeletePoll(pollId, userId string) (string, error) {
+// Действие разрешено создателю опроса userId или модератору (moderator).
+func (m *Memory) DeletePoll(pollId, userId string, moderator bool) (*entities.Message, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	poll, err := m.getPoll(pollId)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if poll.Creator != userId {
-		return "", entities.NewUserError("**You don't have the permission to delete a vote!**")
+	if !storage.CanManagePoll(poll, userId, moderator) {
+		return nil, entities.NewUserError("poll.delete_forbidden")
 	}
 	delete(m.polls, pollId)
+	removeFromIndex(m.byChannel, poll.ChannelId, pollId)
+	removeFromIndex(m.byCreator, poll.Creator, pollId)
 
-	return fmt.Sprintf("*Poll*: `%s` **has been successfully delete!**", pollId), nil
+	return entities.NewMessage("poll.deleted", pollId), nil
+}
+
+// CreateSchedule сохраняет новое расписание повторяющегося опроса во внутренней памяти.
+func (m *Memory) CreateSchedule(schedule *entities.Schedule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schedules[schedule.ScheduleId] = schedule
+
+	return nil
+}
+
+// GetSchedule возвращает копию расписания из внутренней памяти.
+func (m *Memory) GetSchedule(scheduleId string) (*entities.Schedule, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	schedule, err := m.getSchedule(scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	return copySchedule(schedule), nil
+}
+
+// ListSchedules возвращает копии расписаний канала channelId, упорядоченные по времени создания.
+func (m *Memory) ListSchedules(channelId string) ([]*entities.Schedule, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	schedules := []*entities.Schedule{}
+	for _, schedule := range m.schedules {
+		if schedule.ChannelId == channelId {
+			schedules = append(schedules, copySchedule(schedule))
+		}
+	}
+	storage.SortSchedules(schedules)
+
+	return schedules, nil
+}
+
+// ListDueSchedules возвращает копии активных расписаний, время запуска которых наступило к моменту now (в формате Unix).
+func (m *Memory) ListDueSchedules(now int64) ([]*entities.Schedule, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	schedules := []*entities.Schedule{}
+	for _, schedule := range m.schedules {
+		if !schedule.Paused && schedule.NextRunAt > 0 && schedule.NextRunAt <= now {
+			schedules = append(schedules, copySchedule(schedule))
+		}
+	}
+
+	return schedules, nil
+}
+
+// SetScheduleNextRun сохраняет время следующего запуска расписания.
+func (m *Memory) SetScheduleNextRun(scheduleId string, nextRunAt int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	schedule, err := m.getSchedule(scheduleId)
+	if err != nil {
+		return err
+	}
+	schedule.NextRunAt = nextRunAt
+
+	return nil
+}
+
+// PauseSchedule приостанавливает расписание и обновляет данные во внутренней памяти.
+func (m *Memory) PauseSchedule(scheduleId, userId string) (*entities.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	schedule, err := m.getSchedule(scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.SetSchedulePaused(schedule, userId, true); err != nil {
+		return nil, err
+	}
+
+	return storage.PauseMessage(scheduleId, true), nil
+}
+
+// ResumeSchedule возобновляет расписание и вместе с этим сохраняет время следующего запуска nextRunAt.
+func (m *Memory) ResumeSchedule(scheduleId, userId string, nextRunAt int64) (*entities.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	schedule, err := m.getSchedule(scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.SetSchedulePaused(schedule, userId, false); err != nil {
+		return nil, err
+	}
+	schedule.NextRunAt = nextRunAt
+
+	return storage.PauseMessage(scheduleId, false), nil
+}
+
+// DeleteSchedule удаляет расписание из внутренней памяти.
+func (m *Memory) DeleteSchedule(scheduleId, userId string) (*entities.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	schedule, err := m.getSchedule(scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if schedule.Creator != userId {
+		return nil, entities.NewUserError("schedule.remove_forbidden")
+	}
+	delete(m.schedules, scheduleId)
+
+	return entities.NewMessage("schedule.removed", scheduleId), nil
 }
 
 func (m *Memory) AddCmdToken(cmdPath, token string) error {
@@ -121,8 +371,76 @@ func (m *Memory) ValidateCmdToken(cmdPath, token string) bool {
 func (m *Memory) getPoll(pollId string) (*entities.Poll, error) {
 	poll := m.polls[pollId]
 	if poll == nil {
-		return nil, entities.NewUserError("**Invalid Poll_ID or not exists!**")
+		return nil, entities.NewUserError("poll.not_found")
 	}
-	
+
 	return poll, nil
 }
+
+// getSchedule получает структуру расписания по Id и проверяет ее существование.
+func (m *Memory) getSchedule(scheduleId string) (*entities.Schedule, error) {
+	schedule := m.schedules[scheduleId]
+	if schedule == nil {
+		return nil, entities.NewUserError("schedule.not_found")
+	}
+
+	return schedule, nil
+}
+
+// indexedPolls возвращает опросы с идентификаторами из ids.
+func (m *Memory) indexedPolls(ids map[string]bool) []*entities.Poll {
+	polls := make([]*entities.Poll, 0, len(ids))
+	for pollId := range ids {
+		polls = append(polls, m.polls[pollId])
+	}
+
+	return polls
+}
+
+// addToIndex добавляет идентификатор опроса pollId в индекс index по ключу key.
+func addToIndex(index map[string]map[string]bool, key, pollId string) {
+	if index[key] == nil {
+		index[key] = map[string]bool{}
+	}
+	index[key][pollId] = true
+}
+
+// removeFromIndex удаляет идентификатор опроса pollId из индекса index по ключу key.
+func removeFromIndex(index map[string]map[string]bool, key, pollId string) {
+	delete(index[key], pollId)
+	if len(index[key]) == 0 {
+		delete(index, key)
+	}
+}
+
+// copyPoll возвращает копию опроса, которую можно использовать без блокировки хранилища.
+func copyPoll(poll *entities.Poll) *entities.Poll {
+	cp := *poll
+
+	cp.Options = make(map[string]int32, len(poll.Options))
+	for option, count := range poll.Options {
+		cp.Options[option] = count
+	}
+
+	cp.Voters = make(map[string][]string, len(poll.Voters))
+	for userId, choices := range poll.Voters {
+		cp.Voters[userId] = append([]string(nil), choices...)
+	}
+
+	if poll.Weights != nil {
+		cp.Weights = make(map[string]int32, len(poll.Weights))
+		for userId, weight := range poll.Weights {
+			cp.Weights[userId] = weight
+		}
+	}
+
+	return &cp
+}
+
+// copySchedule возвращает копию расписания, которую можно использовать без блокировки хранилища.
+func copySchedule(schedule *entities.Schedule) *entities.Schedule {
+	cp := *schedule
+	cp.Options = append([]string(nil), schedule.Options...)
+
+	return &cp
+}
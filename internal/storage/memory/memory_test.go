@@ -12,10 +12,11 @@ func TestCreatePoll(t *testing.T) {
 	store := NewMemoryStore()
 
 	poll := &entities.Poll{
-		PollId:  "poll1",
-		Options: map[string]int32{"option1": 0, "option2": 0},
-		Voters:  map[string]bool{},
-		Creator: "user1",
+		PollId:   "poll1",
+		Options:  map[string]int32{"option1": 0, "option2": 0},
+		Voters:   map[string][]string{},
+		MaxVotes: 1,
+		Creator:  "user1",
 	}
 
 	err := store.CreatePoll(poll)
@@ -29,11 +30,12 @@ func TestClosePoll(t *testing.T) {
 	store := NewMemoryStore()
 
 	poll := &entities.Poll{
-		PollId:  "poll1",
-		Options: map[string]int32{"option1": 0, "option2": 0},
-		Voters:  map[string]bool{},
-		Creator: "user1",
-		Closed:  false,
+		PollId:   "poll1",
+		Options:  map[string]int32{"option1": 0, "option2": 0},
+		Voters:   map[string][]string{},
+		MaxVotes: 1,
+		Creator:  "user1",
+		Closed:   false,
 	}
 
 	err := store.CreatePoll(poll)
@@ -42,27 +44,28 @@ func TestClosePoll(t *testing.T) {
 	t.Run("Success closed Poll", func(t *testing.T) {
 		pollId := "poll1"
 		userId := "user1"
-		msg, err := store.ClosePoll(pollId, userId)
+		msg, err := store.ClosePoll(pollId, userId, false, 3)
 		require.NoError(t, err)
-		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully closed!**", poll.PollId), msg)
+		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully closed!**", poll.PollId), msg.String())
 
 		closedPoll, exists := store.polls["poll1"]
 		require.True(t, exists)
 		require.True(t, closedPoll.Closed)
+		require.Equal(t, int32(3), closedPoll.RequiredVoters)
 	})
 
 	t.Run("Already Closed", func(t *testing.T) {
 		pollId := "poll1"
 		userId := "user1"
-		_, err := store.ClosePoll(pollId, userId)
+		_, err := store.ClosePoll(pollId, userId, false, 0)
 		require.Error(t, err)
-		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has already been closed!**", poll.PollId), err.Error())
+		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **is already closed!**", poll.PollId), err.Error())
 	})
 
 	t.Run("Invalid PollId", func(t *testing.T) {
 		pollId := "invalid_poll"
 		userId := "user1"
-		_, err := store.ClosePoll(pollId, userId)
+		_, err := store.ClosePoll(pollId, userId, false, 0)
 		require.Error(t, err)
 		require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
 	})
@@ -71,21 +74,30 @@ func TestClosePoll(t *testing.T) {
 		pollId := "poll1"
 		userId := "user2"
 		poll.Closed = false
-		msg, err := store.ClosePoll(pollId, userId)
+		msg, err := store.ClosePoll(pollId, userId, false, 0)
 		require.Error(t, err)
 		require.Equal(t, "**You don't have the permission to close a vote!**", err.Error())
 		require.Empty(t, msg)
 	})
+
+	t.Run("Moderator", func(t *testing.T) {
+		poll.Closed = false
+		msg, err := store.ClosePoll("poll1", "admin", true, 0)
+		require.NoError(t, err)
+		require.Equal(t, "*Poll*: `poll1` **has been successfully closed!**", msg.String())
+		require.True(t, store.polls["poll1"].Closed)
+	})
 }
 func TestVote(t *testing.T) {
 	store := NewMemoryStore()
 
 	poll := &entities.Poll{
-		PollId:  "poll1",
-		Options: map[string]int32{"option1": 0, "option2": 0},
-		Voters:  map[string]bool{},
-		Creator: "user1",
-		Closed:  false,
+		PollId:   "poll1",
+		Options:  map[string]int32{"option1": 0, "option2": 0},
+		Voters:   map[string][]string{},
+		MaxVotes: 1,
+		Creator:  "user1",
+		Closed:   false,
 	}
 
 	err := store.CreatePoll(poll)
@@ -99,9 +111,9 @@ func TestVote(t *testing.T) {
 		}
 		msg, err := store.Vote(voice)
 		require.NoError(t, err)
-		require.Equal(t, "**Voice recorded!**", msg)
+		require.Equal(t, "**Voice recorded!**", msg.String())
 		require.Equal(t, int32(1), poll.Options["option1"])
-		require.True(t, poll.Voters["user2"])
+		require.Equal(t, []string{"option1"}, poll.Voters["user2"])
 	})
 
 	t.Run("Invalid PollId", func(t *testing.T) {
@@ -149,43 +161,279 @@ func TestVote(t *testing.T) {
 		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **is already closed!**", voice.PollId), err.Error())
 	})
 }
-func TestGetPollResult(t *testing.T) {
+func TestVoteMultipleChoice(t *testing.T) {
 	store := NewMemoryStore()
 
 	poll := &entities.Poll{
-		PollId:  "poll1",
-		Options: map[string]int32{"option1": 5, "option2": 3},
-		Voters:  map[string]bool{"user1": true, "user2": true},
-		Creator: "user1",
-		Closed:  false,
+		PollId:   "poll1",
+		Options:  map[string]int32{"option1": 0, "option2": 0, "option3": 0},
+		Voters:   map[string][]string{},
+		MaxVotes: 2,
+		Creator:  "user1",
 	}
 
 	err := store.CreatePoll(poll)
 	require.NoError(t, err)
 
-	t.Run("Valid PollId", func(t *testing.T) {
-		pollId := "poll1"
-		result, err := store.GetPollResult(pollId)
+	for _, option := range []string{"option1", "option2"} {
+		msg, err := store.Vote(&entities.Voice{PollId: "poll1", Option: option, UserId: "user2"})
 		require.NoError(t, err)
-		require.NotEmpty(t, result)
+		require.Equal(t, "**Voice recorded!**", msg.String())
+	}
+	require.Equal(t, []string{"option1", "option2"}, poll.Voters["user2"])
+	require.Equal(t, int32(1), poll.Options["option1"])
+	require.Equal(t, int32(1), poll.Options["option2"])
+
+	_, err = store.Vote(&entities.Voice{PollId: "poll1", Option: "option3", UserId: "user2"})
+	require.Error(t, err)
+	require.Equal(t, "**You can't choose more than 2 options!**", err.Error())
+	require.Equal(t, int32(0), poll.Options["option3"])
+}
+func TestVoteRanked(t *testing.T) {
+	store := NewMemoryStore()
+
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Options:  map[string]int32{"option1": 0, "option2": 0, "option3": 0},
+		Voters:   map[string][]string{},
+		MaxVotes: 1,
+		Ranked:   true,
+		Creator:  "user1",
+	}
+
+	err := store.CreatePoll(poll)
+	require.NoError(t, err)
+
+	msg, err := store.Vote(&entities.Voice{PollId: "poll1", UserId: "user2", Ranking: []string{"option3", "option1"}})
+	require.NoError(t, err)
+	require.Equal(t, "**Voice recorded!**", msg.String())
+	require.Equal(t, []string{"option3", "option1"}, poll.Voters["user2"])
+	require.Equal(t, int32(1), poll.Options["option3"])
+	require.Equal(t, int32(0), poll.Options["option1"])
+}
+
+func TestRetractVote(t *testing.T) {
+	store := NewMemoryStore()
+
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Options:  map[string]int32{"option1": 1, "option2": 0},
+		Voters:   map[string][]string{"user2": {"option1"}},
+		MaxVotes: 1,
+		Creator:  "user1",
+	}
+
+	err := store.CreatePoll(poll)
+	require.NoError(t, err)
+
+	t.Run("Valid retract", func(t *testing.T) {
+		msg, err := store.RetractVote(&entities.Voice{PollId: "poll1", UserId: "user2"})
+		require.NoError(t, err)
+		require.Equal(t, "**Voice retracted!**", msg.String())
+		require.Equal(t, int32(0), poll.Options["option1"])
+		require.NotContains(t, poll.Voters, "user2")
+	})
+
+	t.Run("Not voted", func(t *testing.T) {
+		_, err := store.RetractVote(&entities.Voice{PollId: "poll1", UserId: "user2"})
+		require.Error(t, err)
+		require.Equal(t, "**You haven't voted in this poll!**", err.Error())
 	})
 
 	t.Run("Invalid PollId", func(t *testing.T) {
-		pollId := "invalid_poll"
-		_, err := store.GetPollResult(pollId)
+		_, err := store.RetractVote(&entities.Voice{PollId: "invalid_poll", UserId: "user2"})
 		require.Error(t, err)
 		require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
 	})
 }
+
+func TestChangeVote(t *testing.T) {
+	store := NewMemoryStore()
+
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Options:  map[string]int32{"option1": 1, "option2": 0},
+		Voters:   map[string][]string{"user2": {"option1"}},
+		MaxVotes: 1,
+		Creator:  "user1",
+	}
+
+	err := store.CreatePoll(poll)
+	require.NoError(t, err)
+
+	t.Run("Valid change", func(t *testing.T) {
+		msg, err := store.ChangeVote(&entities.Voice{PollId: "poll1", UserId: "user2", Option: "option2"})
+		require.NoError(t, err)
+		require.Equal(t, "**Voice changed!**", msg.String())
+		require.Equal(t, int32(0), poll.Options["option1"])
+		require.Equal(t, int32(1), poll.Options["option2"])
+		require.Equal(t, []string{"option2"}, poll.Voters["user2"])
+	})
+
+	t.Run("Invalid option", func(t *testing.T) {
+		_, err := store.ChangeVote(&entities.Voice{PollId: "poll1", UserId: "user2", Option: "option3"})
+		require.Error(t, err)
+		require.Equal(t, "**Invalid option!**", err.Error())
+		require.Equal(t, []string{"option2"}, poll.Voters["user2"])
+	})
+}
+
+func TestReopenPoll(t *testing.T) {
+	store := NewMemoryStore()
+
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Options:  map[string]int32{"option1": 0, "option2": 0},
+		Voters:   map[string][]string{},
+		MaxVotes: 1,
+		Creator:  "user1",
+		Closed:   true,
+		EndsAt:   100,
+	}
+
+	err := store.CreatePoll(poll)
+	require.NoError(t, err)
+
+	t.Run("No permission", func(t *testing.T) {
+		_, err := store.ReopenPoll("poll1", "user2", false)
+		require.Error(t, err)
+		require.Equal(t, "**You don't have the permission to reopen a vote!**", err.Error())
+	})
+
+	t.Run("Success reopened Poll", func(t *testing.T) {
+		msg, err := store.ReopenPoll("poll1", "user1", false)
+		require.NoError(t, err)
+		require.Equal(t, "*Poll*: `poll1` **has been successfully reopened!**", msg.String())
+		require.False(t, store.polls["poll1"].Closed)
+		require.Zero(t, store.polls["poll1"].EndsAt)
+	})
+
+	t.Run("Not closed", func(t *testing.T) {
+		_, err := store.ReopenPoll("poll1", "user1", false)
+		require.Error(t, err)
+		require.Equal(t, "*Poll*: `poll1` **is not closed!**", err.Error())
+	})
+}
+
+func TestAddOption(t *testing.T) {
+	store := NewMemoryStore()
+
+	poll := &entities.Poll{
+		PollId:      "poll1",
+		Options:     map[string]int32{"option1": 0, "option2": 0},
+		Voters:      map[string][]string{},
+		MaxVotes:    1,
+		Creator:     "user1",
+		OpenOptions: true,
+	}
+
+	err := store.CreatePoll(poll)
+	require.NoError(t, err)
+
+	t.Run("Success added option", func(t *testing.T) {
+		msg, err := store.AddOption("poll1", "option3")
+		require.NoError(t, err)
+		require.Equal(t, "**Option added!**", msg.String())
+		require.Contains(t, store.polls["poll1"].Options, "option3")
+	})
+
+	t.Run("Duplicated option", func(t *testing.T) {
+		_, err := store.AddOption("poll1", "option3")
+		require.Error(t, err)
+		require.Equal(t, "**Option `option3` already exists!**", err.Error())
+	})
+
+	t.Run("Invalid PollId", func(t *testing.T) {
+		_, err := store.AddOption("invalid_poll", "option3")
+		require.Error(t, err)
+		require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
+	})
+}
+
+func TestListPolls(t *testing.T) {
+	store := NewMemoryStore()
+
+	for _, poll := range []*entities.Poll{
+		{PollId: "poll1", Question: "B", ChannelId: "channel1", Creator: "user1", Options: map[string]int32{}, Voters: map[string][]string{}},
+		{PollId: "poll2", Question: "A", ChannelId: "channel1", Creator: "user2", Options: map[string]int32{}, Voters: map[string][]string{}},
+		{PollId: "poll3", Question: "C", ChannelId: "channel1", Creator: "user1", Options: map[string]int32{}, Voters: map[string][]string{}, Closed: true},
+		{PollId: "poll4", Question: "D", ChannelId: "channel2", Creator: "user1", Options: map[string]int32{}, Voters: map[string][]string{}},
+	} {
+		require.NoError(t, store.CreatePoll(poll))
+	}
+
+	open, closed := false, true
+	tests := []struct {
+		name     string
+		filter   *entities.PollFilter
+		expected []string
+		total    int
+	}{
+		{"Channel", &entities.PollFilter{ChannelId: "channel1"}, []string{"poll2", "poll1", "poll3"}, 3},
+		{"Creator", &entities.PollFilter{Creator: "user1"}, []string{"poll1", "poll4", "poll3"}, 3},
+		{"Channel and creator", &entities.PollFilter{ChannelId: "channel1", Creator: "user1"}, []string{"poll1", "poll3"}, 2},
+		{"Open", &entities.PollFilter{Closed: &open}, []string{"poll2", "poll1", "poll4"}, 3},
+		{"Closed", &entities.PollFilter{ChannelId: "channel1", Closed: &closed}, []string{"poll3"}, 1},
+		{"Page", &entities.PollFilter{Offset: 2, Limit: 2}, []string{"poll4", "poll3"}, 4},
+		{"Unknown channel", &entities.PollFilter{ChannelId: "channel3"}, []string{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls, total, err := store.ListPolls(tt.filter)
+			require.NoError(t, err)
+			require.Equal(t, tt.total, total)
+
+			ids := []string{}
+			for _, poll := range polls {
+				ids = append(ids, poll.PollId)
+			}
+			require.Equal(t, tt.expected, ids)
+		})
+	}
+
+	t.Run("Deleted poll", func(t *testing.T) {
+		_, err := store.DeletePoll("poll4", "user1", false)
+		require.NoError(t, err)
+
+		polls, total, err := store.ListPolls(&entities.PollFilter{ChannelId: "channel2"})
+		require.NoError(t, err)
+		require.Zero(t, total)
+		require.Empty(t, polls)
+		require.NotContains(t, store.byChannel, "channel2")
+	})
+}
+
+func TestListDuePolls(t *testing.T) {
+	store := NewMemoryStore()
+
+	polls := []*entities.Poll{
+		{PollId: "due", Options: map[string]int32{}, Voters: map[string][]string{}, EndsAt: 100},
+		{PollId: "future", Options: map[string]int32{}, Voters: map[string][]string{}, EndsAt: 300},
+		{PollId: "no_deadline", Options: map[string]int32{}, Voters: map[string][]string{}},
+		{PollId: "closed", Options: map[string]int32{}, Voters: map[string][]string{}, EndsAt: 100, Closed: true},
+	}
+	for _, poll := range polls {
+		require.NoError(t, store.CreatePoll(poll))
+	}
+
+	duePolls, err := store.ListDuePolls(200)
+	require.NoError(t, err)
+	require.Len(t, duePolls, 1)
+	require.Equal(t, "due", duePolls[0].PollId)
+	require.NotSame(t, polls[0], duePolls[0])
+}
+
 func TestDeletePoll(t *testing.T) {
 	store := NewMemoryStore()
 
 	poll := &entities.Poll{
-		PollId:  "poll1",
-		Options: map[string]int32{"option1": 0, "option2": 0},
-		Voters:  map[string]bool{},
-		Creator: "user1",
-		Closed:  false,
+		PollId:   "poll1",
+		Options:  map[string]int32{"option1": 0, "option2": 0},
+		Voters:   map[string][]string{},
+		MaxVotes: 1,
+		Creator:  "user1",
+		Closed:   false,
 	}
 
 	err := store.CreatePoll(poll)
@@ -194,9 +442,9 @@ func TestDeletePoll(t *testing.T) {
 	t.Run("Successful Deletion", func(t *testing.T) {
 		pollId := "poll1"
 		userId := "user1"
-		msg, err := store.DeletePoll(pollId, userId)
+		msg, err := store.DeletePoll(pollId, userId, false)
 		require.NoError(t, err)
-		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully delete!**", pollId), msg)
+		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **has been successfully deleted!**", pollId), msg.String())
 
 		_, exists := store.polls[pollId]
 		require.False(t, exists)
@@ -205,38 +453,49 @@ func TestDeletePoll(t *testing.T) {
 	t.Run("Invalid PollId", func(t *testing.T) {
 		pollId := "invalid_poll"
 		userId := "user1"
-		_, err := store.DeletePoll(pollId, userId)
+		_, err := store.DeletePoll(pollId, userId, false)
 		require.Error(t, err)
 		require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
 	})
 
 	t.Run("Don't have the permission", func(t *testing.T) {
 		poll := &entities.Poll{
-			PollId:  "poll2",
-			Options: map[string]int32{"option1": 0, "option2": 0},
-			Voters:  map[string]bool{},
-			Creator: "user1",
-			Closed:  false,
+			PollId:   "poll2",
+			Options:  map[string]int32{"option1": 0, "option2": 0},
+			Voters:   map[string][]string{},
+			MaxVotes: 1,
+			Creator:  "user1",
+			Closed:   false,
 		}
 		err := store.CreatePoll(poll)
 		require.NoError(t, err)
 
 		pollId := "poll2"
 		userId := "user2"
-		_, err = store.DeletePoll(pollId, userId)
+		_, err = store.DeletePoll(pollId, userId, false)
 		require.Error(t, err)
 		require.Equal(t, "**You don't have the permission to delete a vote!**", err.Error())
 	})
+
+	t.Run("Moderator", func(t *testing.T) {
+		_, err := store.DeletePoll("poll2", "admin", true)
+		require.NoError(t, err)
+
+		_, exists := store.polls["poll2"]
+		require.False(t, exists)
+	})
 }
 func TestGetPoll(t *testing.T) {
 	store := NewMemoryStore()
 
 	poll := &entities.Poll{
-		PollId:  "poll1",
-		Options: map[string]int32{"option1": 0, "option2": 0},
-		Voters:  map[string]bool{},
-		Creator: "user1",
-		Closed:  false,
+		PollId:   "poll1",
+		Options:  map[string]int32{"option1": 0, "option2": 0},
+		Voters:   map[string][]string{},
+		Weights:  map[string]int32{"user2": 2},
+		MaxVotes: 1,
+		Creator:  "user1",
+		Closed:   false,
 	}
 
 	err := store.CreatePoll(poll)
@@ -255,3 +514,171 @@ func TestGetPoll(t *testing.T) {
 		require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
 	})
 }
+
+func TestGetPollCopy(t *testing.T) {
+	store := NewMemoryStore()
+
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Options:  map[string]int32{"option1": 0, "option2": 0},
+		Voters:   map[string][]string{},
+		Weights:  map[string]int32{"user2": 2},
+		MaxVotes: 1,
+		Creator:  "user1",
+		Closed:   false,
+	}
+
+	err := store.CreatePoll(poll)
+	require.NoError(t, err)
+
+	t.Run("Valid PollId", func(t *testing.T) {
+		copiedPoll, err := store.GetPoll("poll1")
+		require.NoError(t, err)
+		require.Equal(t, poll, copiedPoll)
+
+		// Изменение копии не должно влиять на опрос в хранилище
+		copiedPoll.Options["option1"]++
+		copiedPoll.Voters["user2"] = []string{"option1"}
+		copiedPoll.Weights["user2"] = 5
+		require.Equal(t, int32(0), poll.Options["option1"])
+		require.Empty(t, poll.Voters)
+		require.Equal(t, int32(2), poll.Weights["user2"])
+	})
+
+	t.Run("Invalid PollId", func(t *testing.T) {
+		_, err := store.GetPoll("invalid_poll")
+		require.Error(t, err)
+		require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
+	})
+}
+
+func TestSetPollPost(t *testing.T) {
+	store := NewMemoryStore()
+
+	poll := &entities.Poll{
+		PollId:   "poll1",
+		Options:  map[string]int32{"option1": 0, "option2": 0},
+		Voters:   map[string][]string{},
+		MaxVotes: 1,
+		Creator:  "user1",
+	}
+
+	err := store.CreatePoll(poll)
+	require.NoError(t, err)
+
+	t.Run("Valid PollId", func(t *testing.T) {
+		err := store.SetPollPost("poll1", "post1")
+		require.NoError(t, err)
+		require.Equal(t, "post1", store.polls["poll1"].PostId)
+	})
+
+	t.Run("Invalid PollId", func(t *testing.T) {
+		err := store.SetPollPost("invalid_poll", "post1")
+		require.Error(t, err)
+		require.Equal(t, "**Invalid Poll_ID or not exists!**", err.Error())
+	})
+}
+
+func TestListSchedules(t *testing.T) {
+	store := NewMemoryStore()
+
+	schedules := []*entities.Schedule{
+		{ScheduleId: "second", ChannelId: "channel1", Options: []string{"A", "B"}, CreatedAt: 200},
+		{ScheduleId: "first", ChannelId: "channel1", Options: []string{"A", "B"}, CreatedAt: 100},
+		{ScheduleId: "other", ChannelId: "channel2", Options: []string{"A", "B"}, CreatedAt: 100},
+	}
+	for _, schedule := range schedules {
+		require.NoError(t, store.CreateSchedule(schedule))
+	}
+
+	list, err := store.ListSchedules("channel1")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "first", list[0].ScheduleId)
+	require.Equal(t, "second", list[1].ScheduleId)
+	require.NotSame(t, schedules[1], list[0])
+
+	schedule, err := store.GetSchedule("other")
+	require.NoError(t, err)
+	require.Equal(t, schedules[2], schedule)
+
+	_, err = store.GetSchedule("invalid_schedule")
+	require.Error(t, err)
+	require.Equal(t, "**Invalid Schedule_ID or not exists!**", err.Error())
+}
+
+func TestListDueSchedules(t *testing.T) {
+	store := NewMemoryStore()
+
+	schedules := []*entities.Schedule{
+		{ScheduleId: "due", NextRunAt: 100},
+		{ScheduleId: "future", NextRunAt: 300},
+		{ScheduleId: "finished"},
+		{ScheduleId: "paused", NextRunAt: 100, Paused: true},
+	}
+	for _, schedule := range schedules {
+		require.NoError(t, store.CreateSchedule(schedule))
+	}
+
+	dueSchedules, err := store.ListDueSchedules(200)
+	require.NoError(t, err)
+	require.Len(t, dueSchedules, 1)
+	require.Equal(t, "due", dueSchedules[0].ScheduleId)
+
+	require.NoError(t, store.SetScheduleNextRun("due", 400))
+	dueSchedules, err = store.ListDueSchedules(200)
+	require.NoError(t, err)
+	require.Empty(t, dueSchedules)
+}
+
+func TestPauseSchedule(t *testing.T) {
+	store := NewMemoryStore()
+	require.NoError(t, store.CreateSchedule(&entities.Schedule{ScheduleId: "schedule1", Creator: "user1"}))
+
+	t.Run("No permission", func(t *testing.T) {
+		msg, err := store.PauseSchedule("schedule1", "user2")
+		require.Error(t, err)
+		require.Empty(t, msg)
+		require.Equal(t, "**You don't have the permission to pause a schedule!**", err.Error())
+	})
+
+	t.Run("Pause and resume", func(t *testing.T) {
+		msg, err := store.PauseSchedule("schedule1", "user1")
+		require.NoError(t, err)
+		require.Equal(t, "*Schedule*: `schedule1` **has been paused!**", msg.String())
+		require.True(t, store.schedules["schedule1"].Paused)
+
+		_, err = store.PauseSchedule("schedule1", "user1")
+		require.Error(t, err)
+		require.Equal(t, "*Schedule*: `schedule1` **is already paused!**", err.Error())
+
+		_, err = store.ResumeSchedule("schedule1", "user2", 500)
+		require.Error(t, err)
+		require.Equal(t, "**You don't have the permission to resume a schedule!**", err.Error())
+
+		msg, err = store.ResumeSchedule("schedule1", "user1", 500)
+		require.NoError(t, err)
+		require.Equal(t, "*Schedule*: `schedule1` **has been resumed!**", msg.String())
+		require.False(t, store.schedules["schedule1"].Paused)
+		require.Equal(t, int64(500), store.schedules["schedule1"].NextRunAt)
+	})
+}
+
+func TestDeleteSchedule(t *testing.T) {
+	store := NewMemoryStore()
+	require.NoError(t, store.CreateSchedule(&entities.Schedule{ScheduleId: "schedule1", Creator: "user1"}))
+
+	msg, err := store.DeleteSchedule("schedule1", "user2")
+	require.Error(t, err)
+	require.Empty(t, msg)
+	require.Equal(t, "**You don't have the permission to remove a schedule!**", err.Error())
+
+	msg, err = store.DeleteSchedule("schedule1", "user1")
+	require.NoError(t, err)
+	require.Equal(t, "*Schedule*: `schedule1` **has been removed!**", msg.String())
+	require.NotContains(t, store.schedules, "schedule1")
+
+	_, err = store.DeleteSchedule("schedule1", "user1")
+	require.Error(t, err)
+	require.Equal(t, "**Invalid Schedule_ID or not exists!**", err.Error())
+}
@@ -0,0 +1,116 @@
+package storage_test
+
+import (
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/storage"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPrintTable тестирует формирование таблицы с результатами опроса.
+func TestPrintTable(t *testing.T) {
+	poll := &entities.Poll{
+		Question: "Question",
+		Options:  map[string]int32{"1": 2, "2": 1},
+		Voters:   map[string][]string{"user1": {"1"}, "user2": {"1", "2"}},
+		MaxVotes: 2,
+	}
+
+	t.Run("Anonymous poll", func(t *testing.T) {
+		table := storage.PrintTable(poll, map[string]string{"user1": "alice"}, "en")
+		require.Contains(t, table, "| Options | Voices | Percent |\n")
+		require.Contains(t, table, "| `1` | `2` | `100.0％` |\n")
+		require.NotContains(t, table, "alice")
+		require.NotContains(t, table, "user1")
+	})
+
+	t.Run("Public poll", func(t *testing.T) {
+		publicPoll := *poll
+		publicPoll.Public = true
+
+		table := storage.PrintTable(&publicPoll, map[string]string{"user1": "alice", "user2": "bob"}, "en")
+		require.Contains(t, table, "| Options | Voices | Percent | Voters |\n")
+		require.Contains(t, table, "| `1` | `2` | `100.0％` | @alice, @bob |\n")
+		require.Contains(t, table, "| `2` | `1` | `50.0％` | @bob |\n")
+	})
+
+	t.Run("Weighted poll", func(t *testing.T) {
+		weightedPoll := *poll
+		weightedPoll.Options = map[string]int32{"1": 4, "2": 3}
+		weightedPoll.Weights = map[string]int32{"user2": 3}
+
+		table := storage.PrintTable(&weightedPoll, nil, "en")
+		require.Contains(t, table, "| Options | Voices | Weighted | Percent |\n")
+		require.Contains(t, table, "| `1` | `2` | `4` | `100.0％` |\n")
+		require.Contains(t, table, "| `2` | `1` | `3` | `75.0％` |\n")
+		require.Contains(t, table, "| *Voters*: `2` |\n| *Total weight*: `4` |\n")
+	})
+
+	t.Run("Public poll without names", func(t *testing.T) {
+		publicPoll := *poll
+		publicPoll.Public = true
+
+		table := storage.PrintTable(&publicPoll, nil, "en")
+		require.Contains(t, table, "| `1` | `2` | `100.0％` | user1, user2 |\n")
+	})
+}
+
+// TestPrintHiddenTable тестирует формирование таблицы опроса со скрытыми результатами.
+func TestPrintHiddenTable(t *testing.T) {
+	poll := &entities.Poll{
+		Question:    "Question",
+		Options:     map[string]int32{"1": 2, "2": 1},
+		Voters:      map[string][]string{"user1": {"1"}, "user2": {"1", "2"}},
+		MaxVotes:    2,
+		HideResults: true,
+	}
+
+	table := storage.PrintHiddenTable(poll, "en")
+	require.Equal(t, "| Options |\n|---------|\n| `1` |\n| `2` |\n"+
+		"| *Question*: `Question` |\n| *Voters*: `2` |\n| *Status:* 🟢 (Active) |", table)
+}
+
+// TestPrintRunoff тестирует формирование потуровых результатов рейтингового опроса.
+func TestPrintRunoff(t *testing.T) {
+	poll := &entities.Poll{
+		Options: map[string]int32{"A": 2, "B": 2, "C": 1},
+		Voters: map[string][]string{
+			"user1": {"A"},
+			"user2": {"A"},
+			"user3": {"B"},
+			"user4": {"B"},
+			"user5": {"C", "B"},
+		},
+		Ranked: true,
+	}
+
+	result := storage.PrintRunoff(poll, "en")
+	require.Equal(t, "*Round 1*: `A` 2, `B` 2, `C` 1 — eliminated `C`\n*Round 2*: `B` 3, `A` 2\n**Winner**: `B`", result)
+
+	poll.Voters["user5"] = []string{"C"}
+	result = storage.PrintRunoff(poll, "en")
+	require.Contains(t, result, "eliminated `A`, `B`\n**No winner**")
+}
+
+// TestPrintList тестирует формирование таблицы со списком опросов.
+func TestPrintList(t *testing.T) {
+	require.Equal(t, "**No polls found!**", storage.PrintList(nil, "en"))
+
+	polls := []*entities.Poll{
+		{PollId: "poll3", Question: "A"},
+		{PollId: "poll2", Question: "C", Voters: map[string][]string{"user1": {"1"}}},
+		{PollId: "poll1", Question: "B", Closed: true},
+	}
+
+	require.Equal(t, "| Poll_ID | Question | Voters | Status |\n"+
+		"|---------|----------|--------|--------|\n"+
+		"| `poll3` | A | `0` | 🟢 (Active) |\n"+
+		"| `poll2` | C | `1` | 🟢 (Active) |\n"+
+		"| `poll1` | B | `0` | 🔴 (Completed) |", storage.PrintList(polls, "en"))
+
+	require.Equal(t, "**Опросы не найдены!**", storage.PrintList(nil, "ru"))
+	require.Equal(t, "| Poll_ID | Вопрос | Проголосовавшие | Статус |\n"+
+		"|---------|--------|-----------------|--------|\n"+
+		"| `poll1` | B | `0` | 🔴 (Завершен) |", storage.PrintList(polls[2:], "ru"))
+}
@@ -3,33 +3,194 @@ package storage
 import (
 	"fmt"
 	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
+	"sort"
 	"strings"
 )
 
-// PrintTable принимает объект типа *entities.Poll и возвращает строку, представляющую таблицу с информацией о голосовании.
-func PrintTable(poll *entities.Poll) string {
+// PrintTable принимает объект типа *entities.Poll и возвращает строку, представляющую таблицу с информацией о голосовании,
+// на языке locale. Для публичного опроса в таблицу добавляется столбец с проголосовавшими за каждый вариант пользователями,
+// имена которых берутся из voterNames по идентификатору (при отсутствии имени выводится идентификатор).
+// Для взвешенного опроса выводятся и количество голосов, и их суммарный вес, а процент считается от общего веса проголосовавших.
+func PrintTable(poll *entities.Poll, voterNames map[string]string, locale string) string {
 	var sb strings.Builder
 
-	sb.WriteString("| Options | Voices | Percent |\n")
-	sb.WriteString("|---------|--------|---------|\n")
+	weighted := len(poll.Weights) != 0
+	columns := []string{"table.options", "table.voices"}
+	if weighted {
+		columns = append(columns, "table.weighted")
+	}
+	columns = append(columns, "table.percent")
+	if poll.Public {
+		columns = append(columns, "table.voters")
+	}
+	sb.WriteString(printHeader(locale, columns...))
 
 	totalVote := len(poll.Voters)
+	totalWeight := TotalWeight(poll)
+	ballots := RawBallots(poll)
+
+	var optionVoters map[string][]string
+	if poll.Public {
+		optionVoters = votersByOption(poll, voterNames)
+	}
 
-	for option, count := range poll.Options {
+	for _, option := range SortedOptions(poll) {
+		count := poll.Options[option]
 		var percent float64
-		if totalVote != 0 {
-			percent = (float64(count) / float64(totalVote)) * 100
+		if totalWeight != 0 {
+			percent = (float64(count) / float64(totalWeight)) * 100
+		}
+		if weighted {
+			sb.WriteString(fmt.Sprintf("| `%s` | `%d` | `%d` | `%.1f％` |", option, ballots[option], count, percent))
+		} else {
+			sb.WriteString(fmt.Sprintf("| `%s` | `%d` | `%.1f％` |", option, count, percent))
+		}
+		if poll.Public {
+			sb.WriteString(fmt.Sprintf(" %s |", strings.Join(optionVoters[option], ", ")))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("| *%s*: `%s` |\n", i18n.T(locale, "table.question"), poll.Question))
+	sb.WriteString(fmt.Sprintf("| *%s*: `%d` |\n", i18n.T(locale, "table.voters"), totalVote))
+	if weighted {
+		sb.WriteString(fmt.Sprintf("| *%s*: `%d` |\n", i18n.T(locale, "table.total_weight"), totalWeight))
+	}
+	sb.WriteString(fmt.Sprintf("| *%s:* %s |", i18n.T(locale, "table.status"), pollStatus(poll, locale)))
+
+	return sb.String()
+}
+
+// PrintHiddenTable возвращает таблицу опроса со скрытыми результатами на языке locale:
+// варианты ответа без количества голосов, вопрос, число проголосовавших и статус опроса.
+func PrintHiddenTable(poll *entities.Poll, locale string) string {
+	var sb strings.Builder
+
+	sb.WriteString(printHeader(locale, "table.options"))
+	for _, option := range SortedOptions(poll) {
+		sb.WriteString(fmt.Sprintf("| `%s` |\n", option))
+	}
+
+	sb.WriteString(fmt.Sprintf("| *%s*: `%s` |\n", i18n.T(locale, "table.question"), poll.Question))
+	sb.WriteString(fmt.Sprintf("| *%s*: `%d` |\n", i18n.T(locale, "table.voters"), len(poll.Voters)))
+	sb.WriteString(fmt.Sprintf("| *%s:* %s |", i18n.T(locale, "table.status"), pollStatus(poll, locale)))
+
+	return sb.String()
+}
+
+// printHeader возвращает заголовок markdown-таблицы из столбцов с ключами каталога сообщений columns на языке locale.
+// Столбцы, названия которых не являются ключами каталога, выводятся как есть.
+func printHeader(locale string, columns ...string) string {
+	var header, separator strings.Builder
+
+	header.WriteString("|")
+	separator.WriteString("|")
+	for _, column := range columns {
+		title := i18n.T(locale, column)
+		header.WriteString(" " + title + " |")
+		separator.WriteString(strings.Repeat("-", len([]rune(title))+2) + "|")
+	}
+
+	return header.String() + "\n" + separator.String() + "\n"
+}
+
+// pollStatus возвращает статус опроса poll на языке locale.
+func pollStatus(poll *entities.Poll, locale string) string {
+	if poll.Closed {
+		return i18n.T(locale, "table.completed")
+	}
+
+	return i18n.T(locale, "table.active")
+}
+
+// votersByOption возвращает отсортированные имена пользователей, проголосовавших за каждый вариант ответа.
+func votersByOption(poll *entities.Poll, voterNames map[string]string) map[string][]string {
+	optionVoters := make(map[string][]string, len(poll.Options))
+	for userId, choices := range poll.Voters {
+		name := userId
+		if username, ok := voterNames[userId]; ok {
+			name = "@" + username
+		}
+
+		// В рейтинговом опросе пользователь учитывается только в своем первом предпочтении
+		if poll.Ranked && len(choices) > 0 {
+			choices = choices[:1]
+		}
+		for _, choice := range choices {
+			optionVoters[choice] = append(optionVoters[choice], name)
 		}
-		sb.WriteString(fmt.Sprintf("| `%s` | `%d` | `%.1f％` |\n", option, count, percent))
 	}
 
-	voteStatus := "🔴 (Completed)"
-	if !poll.Closed {
-		voteStatus = "🟢 (Active)"
+	for option := range optionVoters {
+		sort.Strings(optionVoters[option])
 	}
 
-	sb.WriteString(fmt.Sprintf("| *Question*: `%s` |\n", poll.Question))
-	sb.WriteString(fmt.Sprintf("| *Status:* %s |", voteStatus))
+	return optionVoters
+}
+
+// PrintList возвращает строку с таблицей опросов на языке locale в порядке, в котором они переданы.
+func PrintList(polls []*entities.Poll, locale string) string {
+	if len(polls) == 0 {
+		return i18n.T(locale, "list.empty")
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(printHeader(locale, "Poll_ID", "table.question", "table.voters", "table.status"))
+	for _, poll := range polls {
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | `%d` | %s |\n", poll.PollId, poll.Question, len(poll.Voters), pollStatus(poll, locale)))
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// PrintRunoff возвращает строку с потуровыми результатами подсчета рейтингового опроса
+// методом мгновенного второго тура и его победителем на языке locale.
+func PrintRunoff(poll *entities.Poll, locale string) string {
+	var sb strings.Builder
+
+	rounds, winner := InstantRunoff(poll)
+	for i, round := range rounds {
+		options := make([]string, 0, len(round.Counts))
+		for option := range round.Counts {
+			options = append(options, option)
+		}
+		sort.Slice(options, func(a, b int) bool {
+			if round.Counts[options[a]] != round.Counts[options[b]] {
+				return round.Counts[options[a]] > round.Counts[options[b]]
+			}
+			return options[a] < options[b]
+		})
+
+		counts := make([]string, 0, len(options))
+		for _, option := range options {
+			counts = append(counts, fmt.Sprintf("`%s` %d", option, round.Counts[option]))
+		}
+		sb.WriteString(i18n.T(locale, "runoff.round", i+1, strings.Join(counts, ", ")))
+
+		if len(round.Eliminated) != 0 {
+			sb.WriteString(i18n.T(locale, "runoff.eliminated", strings.Join(round.Eliminated, "`, `")))
+		}
+		sb.WriteString("\n")
+	}
+
+	if winner == "" {
+		sb.WriteString(i18n.T(locale, "runoff.no_winner"))
+	} else {
+		sb.WriteString(i18n.T(locale, "runoff.winner", winner))
+	}
 
 	return sb.String()
 }
+
+// SortedOptions возвращает варианты ответа опроса в алфавитном порядке.
+func SortedOptions(poll *entities.Poll) []string {
+	options := make([]string, 0, len(poll.Options))
+	for option := range poll.Options {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+
+	return options
+}
@@ -0,0 +1,54 @@
+package storage_test
+
+import (
+	"encoding/xml"
+	"io"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/storage"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRenderChart тестирует формирование диаграммы результатов опроса.
+func TestRenderChart(t *testing.T) {
+	poll := &entities.Poll{
+		Question: `Best <tag> & "quote"?`,
+		Options:  map[string]int32{"1": 2, "2": 1, strings.Repeat("long", 10): 0},
+		Voters:   map[string][]string{"user1": {"1"}, "user2": {"1", "2"}},
+		MaxVotes: 2,
+	}
+
+	chart := string(storage.RenderChart(poll, "en"))
+	require.True(t, strings.HasPrefix(chart, `<svg xmlns="http://www.w3.org/2000/svg"`))
+	require.Contains(t, chart, "Best &lt;tag&gt; &amp; &#34;quote&#34;?")
+	require.Contains(t, chart, ">2 (100.0%)</text>")
+	require.Contains(t, chart, ">1 (50.0%)</text>")
+	require.Contains(t, chart, ">"+strings.Repeat("long", 7)[:27]+"…</text>")
+	require.Contains(t, chart, `width="150" height="20" fill="#1c58d9"`)
+
+	t.Run("Weighted poll", func(t *testing.T) {
+		weightedPoll := *poll
+		weightedPoll.Options = map[string]int32{"1": 4, "2": 3}
+		weightedPoll.Weights = map[string]int32{"user2": 3}
+
+		chart := string(storage.RenderChart(&weightedPoll, "en"))
+		require.Contains(t, chart, ">votes: 2, weight: 4 (100.0%)</text>")
+		require.Contains(t, chart, ">votes: 1, weight: 3 (75.0%)</text>")
+		require.Contains(t, chart, `width="760"`)
+
+		chart = string(storage.RenderChart(&weightedPoll, "ru"))
+		require.Contains(t, chart, ">голоса: 1, вес: 3 (75.0%)</text>")
+	})
+
+	// Диаграмма должна быть корректным XML-документом
+	decoder := xml.NewDecoder(strings.NewReader(chart))
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			require.ErrorIs(t, err, io.EOF)
+			break
+		}
+	}
+}
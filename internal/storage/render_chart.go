@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"fmt"
+	"html"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
+	"strings"
+	"unicode/utf8"
+)
+
+// Размеры столбчатой диаграммы результатов опроса в пикселях.
+const (
+	chartWidth       = 640
+	chartPadding     = 16
+	chartTitleHeight = 40
+	chartRowHeight   = 32
+	chartLabelWidth  = 200
+	chartBarWidth    = 300
+	chartLabelLength = 28
+	chartWeightWidth = 120
+)
+
+// RenderChart возвращает горизонтальную столбчатую диаграмму результатов опроса в формате SVG:
+// по одному столбцу на каждый вариант ответа с количеством и процентом голосов.
+// Процент голосов считается от общего веса проголосовавших, как и в таблице PrintTable.
+// Для взвешенного опроса подпись столбца на языке locale содержит и количество голосов, и их вес.
+func RenderChart(poll *entities.Poll, locale string) []byte {
+	options := SortedOptions(poll)
+	height := chartTitleHeight + chartRowHeight*len(options) + chartPadding
+	weighted := len(poll.Weights) != 0
+	totalWeight := TotalWeight(poll)
+	ballots := RawBallots(poll)
+	width := chartWidth
+	if weighted {
+		width += chartWeightWidth
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="14">`+"\n",
+		width, height, width, height))
+	sb.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height))
+	sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-size="16" font-weight="bold" fill="#3d3c40">%s</text>`+"\n",
+		chartPadding, chartPadding+12, chartText(poll.Question, 2*chartLabelLength)))
+
+	for i, option := range options {
+		count := poll.Options[option]
+		var percent float64
+		if totalWeight != 0 {
+			percent = (float64(count) / float64(totalWeight)) * 100
+		}
+
+		y := chartTitleHeight + chartRowHeight*i
+		barX := chartPadding + chartLabelWidth
+		barWidth := int(percent / 100 * chartBarWidth)
+
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" fill="#3d3c40">%s</text>`+"\n",
+			chartPadding, y+20, chartText(option, chartLabelLength)))
+		sb.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="#e8e9ed"/>`+"\n",
+			barX, y+6, chartBarWidth, chartRowHeight-12))
+		sb.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="#1c58d9"/>`+"\n",
+			barX, y+6, barWidth, chartRowHeight-12))
+		label := fmt.Sprintf("%d (%.1f%%)", count, percent)
+		if weighted {
+			label = i18n.T(locale, "chart.weighted", ballots[option], count, percent)
+		}
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" fill="#3d3c40">%s</text>`+"\n",
+			barX+chartBarWidth+8, y+20, html.EscapeString(label)))
+	}
+	sb.WriteString("</svg>\n")
+
+	return []byte(sb.String())
+}
+
+// chartText экранирует текст для вставки в SVG, сокращая его до maxLength символов.
+func chartText(text string, maxLength int) string {
+	if utf8.RuneCountInString(text) > maxLength {
+		text = string([]rune(text)[:maxLength-1]) + "…"
+	}
+
+	return html.EscapeString(text)
+}
@@ -0,0 +1,42 @@
+package storage_test
+
+import (
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/storage"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSetSchedulePaused тестирует приостановку и возобновление расписания.
+func TestSetSchedulePaused(t *testing.T) {
+	schedule := &entities.Schedule{ScheduleId: "schedule1", Creator: "user1"}
+
+	err := storage.SetSchedulePaused(schedule, "user2", true)
+	require.Error(t, err)
+	require.Equal(t, "**You don't have the permission to pause a schedule!**", err.Error())
+
+	err = storage.SetSchedulePaused(schedule, "user1", false)
+	require.Error(t, err)
+	require.Equal(t, "*Schedule*: `schedule1` **is not paused!**", err.Error())
+
+	require.NoError(t, storage.SetSchedulePaused(schedule, "user1", true))
+	require.True(t, schedule.Paused)
+}
+
+// TestPrintSchedules тестирует вывод таблицы расписаний.
+func TestPrintSchedules(t *testing.T) {
+	require.Equal(t, "**No schedules found!**", storage.PrintSchedules(nil, "en"))
+
+	schedules := []*entities.Schedule{
+		{ScheduleId: "schedule1", Cron: "0 12 * * 5", Question: "Where do we lunch?", NextRunAt: time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC).Unix()},
+		{ScheduleId: "schedule2", Cron: "@weekly", Question: "Sprint retro mood", Paused: true},
+	}
+
+	expected := "| Schedule_ID | Question | Schedule | Next poll | Status |\n" +
+		"|-------------|----------|----------|-----------|--------|\n" +
+		"| `schedule1` | Where do we lunch? | `0 12 * * 5` | 2025-01-03 12:00 UTC | 🟢 (Active) |\n" +
+		"| `schedule2` | Sprint retro mood | `@weekly` | — | ⏸️ (Paused) |"
+	require.Equal(t, expected, storage.PrintSchedules(schedules, "en"))
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"fmt"
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/i18n"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SetSchedulePaused приостанавливает (paused равен true) или возобновляет расписание повторяющегося опроса
+// от имени пользователя userId. Изменить расписание может только его создатель.
+func SetSchedulePaused(schedule *entities.Schedule, userId string, paused bool) error {
+	if schedule.Creator != userId {
+		if paused {
+			return entities.NewUserError("schedule.pause_forbidden")
+		}
+		return entities.NewUserError("schedule.resume_forbidden")
+	}
+
+	if schedule.Paused == paused {
+		if paused {
+			return entities.NewUserError("schedule.already_paused", schedule.ScheduleId)
+		}
+		return entities.NewUserError("schedule.not_paused", schedule.ScheduleId)
+	}
+	schedule.Paused = paused
+
+	return nil
+}
+
+// PauseMessage возвращает сообщение об успешной приостановке или возобновлении расписания.
+func PauseMessage(scheduleId string, paused bool) *entities.Message {
+	if paused {
+		return entities.NewMessage("schedule.paused", scheduleId)
+	}
+
+	return entities.NewMessage("schedule.resumed", scheduleId)
+}
+
+// SortSchedules упорядочивает расписания по времени создания, а при равенстве - по идентификатору.
+func SortSchedules(schedules []*entities.Schedule) {
+	sort.Slice(schedules, func(i, j int) bool {
+		if schedules[i].CreatedAt != schedules[j].CreatedAt {
+			return schedules[i].CreatedAt < schedules[j].CreatedAt
+		}
+		return schedules[i].ScheduleId < schedules[j].ScheduleId
+	})
+}
+
+// PrintSchedules возвращает строку с таблицей расписаний повторяющихся опросов на языке locale
+// в порядке, в котором они переданы.
+func PrintSchedules(schedules []*entities.Schedule, locale string) string {
+	if len(schedules) == 0 {
+		return i18n.T(locale, "schedule.list_empty")
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(printHeader(locale, "Schedule_ID", "table.question", "table.schedule", "table.next_poll", "table.status"))
+	for _, schedule := range schedules {
+		status := i18n.T(locale, "schedule.status_active")
+		if schedule.Paused {
+			status = i18n.T(locale, "schedule.status_paused")
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | `%s` | %s | %s |\n",
+			schedule.ScheduleId, schedule.Question, schedule.Cron, PrintScheduleTime(schedule.NextRunAt), status))
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// PrintScheduleTime возвращает время запуска расписания в UTC или прочерк, если запусков больше не будет.
+func PrintScheduleTime(unix int64) string {
+	if unix == 0 {
+		return "—"
+	}
+
+	return time.Unix(unix, 0).UTC().Format("2006-01-02 15:04 UTC")
+}
@@ -6,10 +6,25 @@ import "matterpoll-bot/internal/entities"
 // используемым в приложении для управления опросами.
 type StoreInterface interface {
 	CreatePoll(poll *entities.Poll) error
-	Vote(voice *entities.Voice) (string, error)
-	GetPollResult(pollId string) (string, error)
-	ClosePoll(pollId, userId string) (string, error)
-	DeletePoll(pollId, userId string) (string, error)
+	GetPoll(pollId string) (*entities.Poll, error)
+	SetPollPost(pollId, postId string) error
+	Vote(voice *entities.Voice) (*entities.Message, error)
+	RetractVote(voice *entities.Voice) (*entities.Message, error)
+	ChangeVote(voice *entities.Voice) (*entities.Message, error)
+	AddOption(pollId, option string) (*entities.Message, error)
+	ListPolls(filter *entities.PollFilter) ([]*entities.Poll, int, error)
+	ListDuePolls(now int64) ([]*entities.Poll, error)
+	ClosePoll(pollId, userId string, moderator bool, requiredVoters int32) (*entities.Message, error)
+	ReopenPoll(pollId, userId string, moderator bool) (*entities.Message, error)
+	DeletePoll(pollId, userId string, moderator bool) (*entities.Message, error)
+	CreateSchedule(schedule *entities.Schedule) error
+	GetSchedule(scheduleId string) (*entities.Schedule, error)
+	ListSchedules(channelId string) ([]*entities.Schedule, error)
+	ListDueSchedules(now int64) ([]*entities.Schedule, error)
+	SetScheduleNextRun(scheduleId string, nextRunAt int64) error
+	PauseSchedule(scheduleId, userId string) (*entities.Message, error)
+	ResumeSchedule(scheduleId, userId string, nextRunAt int64) (*entities.Message, error)
+	DeleteSchedule(scheduleId, userId string) (*entities.Message, error)
 	AddCmdToken(cmdPath, token string) error
 	ValidateCmdToken(cmdPath, token string) bool
 }
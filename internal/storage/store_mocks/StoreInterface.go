@@ -31,27 +31,89 @@ func (_m *StoreInterface) AddCmdToken(cmdPath string, token string) error {
 	return r0
 }
 
-// ClosePoll provides a mock function with given fields: pollId, userId
-func (_m *StoreInterface) ClosePoll(pollId string, userId string) (string, error) {
-	ret := _m.Called(pollId, userId)
+// AddOption provides a mock function with given fields: pollId, option
+func (_m *StoreInterface) AddOption(pollId string, option string) (*entities.Message, error) {
+	ret := _m.Called(pollId, option)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ClosePoll")
+		panic("no return value specified for AddOption")
 	}
 
-	var r0 string
+	var r0 *entities.Message
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
-		return rf(pollId, userId)
+	if rf, ok := ret.Get(0).(func(string, string) (*entities.Message, error)); ok {
+		return rf(pollId, option)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(pollId, userId)
+	if rf, ok := ret.Get(0).(func(string, string) *entities.Message); ok {
+		r0 = rf(pollId, option)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Message)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(pollId, userId)
+		r1 = rf(pollId, option)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeVote provides a mock function with given fields: voice
+func (_m *StoreInterface) ChangeVote(voice *entities.Voice) (*entities.Message, error) {
+	ret := _m.Called(voice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeVote")
+	}
+
+	var r0 *entities.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*entities.Voice) (*entities.Message, error)); ok {
+		return rf(voice)
+	}
+	if rf, ok := ret.Get(0).(func(*entities.Voice) *entities.Message); ok {
+		r0 = rf(voice)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*entities.Voice) error); ok {
+		r1 = rf(voice)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClosePoll provides a mock function with given fields: pollId, userId, moderator, requiredVoters
+func (_m *StoreInterface) ClosePoll(pollId string, userId string, moderator bool, requiredVoters int32) (*entities.Message, error) {
+	ret := _m.Called(pollId, userId, moderator, requiredVoters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClosePoll")
+	}
+
+	var r0 *entities.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, bool, int32) (*entities.Message, error)); ok {
+		return rf(pollId, userId, moderator, requiredVoters)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, bool, int32) *entities.Message); ok {
+		r0 = rf(pollId, userId, moderator, requiredVoters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, bool, int32) error); ok {
+		r1 = rf(pollId, userId, moderator, requiredVoters)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -77,27 +139,77 @@ func (_m *StoreInterface) CreatePoll(poll *entities.Poll) error {
 	return r0
 }
 
-// DeletePoll provides a mock function with given fields: pollId, userId
-func (_m *StoreInterface) DeletePoll(pollId string, userId string) (string, error) {
-	ret := _m.Called(pollId, userId)
+// CreateSchedule provides a mock function with given fields: schedule
+func (_m *StoreInterface) CreateSchedule(schedule *entities.Schedule) error {
+	ret := _m.Called(schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.Schedule) error); ok {
+		r0 = rf(schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePoll provides a mock function with given fields: pollId, userId, moderator
+func (_m *StoreInterface) DeletePoll(pollId string, userId string, moderator bool) (*entities.Message, error) {
+	ret := _m.Called(pollId, userId, moderator)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeletePoll")
 	}
 
-	var r0 string
+	var r0 *entities.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, bool) (*entities.Message, error)); ok {
+		return rf(pollId, userId, moderator)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, bool) *entities.Message); ok {
+		r0 = rf(pollId, userId, moderator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, bool) error); ok {
+		r1 = rf(pollId, userId, moderator)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSchedule provides a mock function with given fields: scheduleId, userId
+func (_m *StoreInterface) DeleteSchedule(scheduleId string, userId string) (*entities.Message, error) {
+	ret := _m.Called(scheduleId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSchedule")
+	}
+
+	var r0 *entities.Message
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
-		return rf(pollId, userId)
+	if rf, ok := ret.Get(0).(func(string, string) (*entities.Message, error)); ok {
+		return rf(scheduleId, userId)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(pollId, userId)
+	if rf, ok := ret.Get(0).(func(string, string) *entities.Message); ok {
+		r0 = rf(scheduleId, userId)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Message)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(pollId, userId)
+		r1 = rf(scheduleId, userId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -105,23 +217,25 @@ func (_m *StoreInterface) DeletePoll(pollId string, userId string) (string, erro
 	return r0, r1
 }
 
-// GetPollResult provides a mock function with given fields: pollId
-func (_m *StoreInterface) GetPollResult(pollId string) (string, error) {
+// GetPoll provides a mock function with given fields: pollId
+func (_m *StoreInterface) GetPoll(pollId string) (*entities.Poll, error) {
 	ret := _m.Called(pollId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPollResult")
+		panic("no return value specified for GetPoll")
 	}
 
-	var r0 string
+	var r0 *entities.Poll
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (*entities.Poll, error)); ok {
 		return rf(pollId)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
+	if rf, ok := ret.Get(0).(func(string) *entities.Poll); ok {
 		r0 = rf(pollId)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Poll)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -133,6 +247,319 @@ func (_m *StoreInterface) GetPollResult(pollId string) (string, error) {
 	return r0, r1
 }
 
+// GetSchedule provides a mock function with given fields: scheduleId
+func (_m *StoreInterface) GetSchedule(scheduleId string) (*entities.Schedule, error) {
+	ret := _m.Called(scheduleId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSchedule")
+	}
+
+	var r0 *entities.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*entities.Schedule, error)); ok {
+		return rf(scheduleId)
+	}
+	if rf, ok := ret.Get(0).(func(string) *entities.Schedule); ok {
+		r0 = rf(scheduleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Schedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(scheduleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDuePolls provides a mock function with given fields: now
+func (_m *StoreInterface) ListDuePolls(now int64) ([]*entities.Poll, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDuePolls")
+	}
+
+	var r0 []*entities.Poll
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]*entities.Poll, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []*entities.Poll); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Poll)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDueSchedules provides a mock function with given fields: now
+func (_m *StoreInterface) ListDueSchedules(now int64) ([]*entities.Schedule, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDueSchedules")
+	}
+
+	var r0 []*entities.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]*entities.Schedule, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []*entities.Schedule); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Schedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPolls provides a mock function with given fields: filter
+func (_m *StoreInterface) ListPolls(filter *entities.PollFilter) ([]*entities.Poll, int, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPolls")
+	}
+
+	var r0 []*entities.Poll
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*entities.PollFilter) ([]*entities.Poll, int, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*entities.PollFilter) []*entities.Poll); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Poll)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*entities.PollFilter) int); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(*entities.PollFilter) error); ok {
+		r2 = rf(filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListSchedules provides a mock function with given fields: channelId
+func (_m *StoreInterface) ListSchedules(channelId string) ([]*entities.Schedule, error) {
+	ret := _m.Called(channelId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSchedules")
+	}
+
+	var r0 []*entities.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*entities.Schedule, error)); ok {
+		return rf(channelId)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*entities.Schedule); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Schedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PauseSchedule provides a mock function with given fields: scheduleId, userId
+func (_m *StoreInterface) PauseSchedule(scheduleId string, userId string) (*entities.Message, error) {
+	ret := _m.Called(scheduleId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseSchedule")
+	}
+
+	var r0 *entities.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*entities.Message, error)); ok {
+		return rf(scheduleId, userId)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *entities.Message); ok {
+		r0 = rf(scheduleId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(scheduleId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReopenPoll provides a mock function with given fields: pollId, userId, moderator
+func (_m *StoreInterface) ReopenPoll(pollId string, userId string, moderator bool) (*entities.Message, error) {
+	ret := _m.Called(pollId, userId, moderator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReopenPoll")
+	}
+
+	var r0 *entities.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, bool) (*entities.Message, error)); ok {
+		return rf(pollId, userId, moderator)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, bool) *entities.Message); ok {
+		r0 = rf(pollId, userId, moderator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, bool) error); ok {
+		r1 = rf(pollId, userId, moderator)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResumeSchedule provides a mock function with given fields: scheduleId, userId, nextRunAt
+func (_m *StoreInterface) ResumeSchedule(scheduleId string, userId string, nextRunAt int64) (*entities.Message, error) {
+	ret := _m.Called(scheduleId, userId, nextRunAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeSchedule")
+	}
+
+	var r0 *entities.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int64) (*entities.Message, error)); ok {
+		return rf(scheduleId, userId, nextRunAt)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int64) *entities.Message); ok {
+		r0 = rf(scheduleId, userId, nextRunAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int64) error); ok {
+		r1 = rf(scheduleId, userId, nextRunAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetractVote provides a mock function with given fields: voice
+func (_m *StoreInterface) RetractVote(voice *entities.Voice) (*entities.Message, error) {
+	ret := _m.Called(voice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetractVote")
+	}
+
+	var r0 *entities.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*entities.Voice) (*entities.Message, error)); ok {
+		return rf(voice)
+	}
+	if rf, ok := ret.Get(0).(func(*entities.Voice) *entities.Message); ok {
+		r0 = rf(voice)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*entities.Voice) error); ok {
+		r1 = rf(voice)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetPollPost provides a mock function with given fields: pollId, postId
+func (_m *StoreInterface) SetPollPost(pollId string, postId string) error {
+	ret := _m.Called(pollId, postId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPollPost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(pollId, postId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetScheduleNextRun provides a mock function with given fields: scheduleId, nextRunAt
+func (_m *StoreInterface) SetScheduleNextRun(scheduleId string, nextRunAt int64) error {
+	ret := _m.Called(scheduleId, nextRunAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetScheduleNextRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(scheduleId, nextRunAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ValidateCmdToken provides a mock function with given fields: cmdPath, token
 func (_m *StoreInterface) ValidateCmdToken(cmdPath string, token string) bool {
 	ret := _m.Called(cmdPath, token)
@@ -152,22 +579,24 @@ func (_m *StoreInterface) ValidateCmdToken(cmdPath string, token string) bool {
 }
 
 // Vote provides a mock function with given fields: voice
-func (_m *StoreInterface) Vote(voice *entities.Voice) (string, error) {
+func (_m *StoreInterface) Vote(voice *entities.Voice) (*entities.Message, error) {
 	ret := _m.Called(voice)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Vote")
 	}
 
-	var r0 string
+	var r0 *entities.Message
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*entities.Voice) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(*entities.Voice) (*entities.Message, error)); ok {
 		return rf(voice)
 	}
-	if rf, ok := ret.Get(0).(func(*entities.Voice) string); ok {
+	if rf, ok := ret.Get(0).(func(*entities.Voice) *entities.Message); ok {
 		r0 = rf(voice)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Message)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(*entities.Voice) error); ok {
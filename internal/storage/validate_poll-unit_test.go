@@ -11,70 +11,222 @@ import (
 
 // TestValidateVoice тестирует функционал проверки корректности голосования в опросе.
 func TestValidateVoice(t *testing.T) {
-    t.Run("Invalid option", func(t *testing.T) {
-        poll := &entities.Poll{
-            Options: map[string]int32{"1": 1},
-            Voters:  map[string]bool{},
-            Closed:  false,
-        }
-        voice := &entities.Voice{
-            Option: "2",
-            UserId: "user1",
-            PollId: "poll1",
-        }
-
-        err := storage.ValidateVoice(poll, voice)
-        require.Error(t, err)
-        require.Equal(t, "**Invalid option!**", err.Error())
-    })
-
-    t.Run("Repeat voice", func(t *testing.T) {
-        poll := &entities.Poll{
-            Options: map[string]int32{"1": 1},
-            Voters:  map[string]bool{"user1": true},
-            Closed:  false,
-        }
-        voice := &entities.Voice{
-            Option: "1",
-            UserId: "user1",
-            PollId: "poll1",
-        }
-
-        err := storage.ValidateVoice(poll, voice)
-        require.Error(t, err)
-        require.Equal(t, "**You can't vote again!**", err.Error())
-    })
-
-    t.Run("Closed poll", func(t *testing.T) {
-        poll := &entities.Poll{
-            Options: map[string]int32{"1": 1},
-            Voters:  map[string]bool{},
-            Closed:  true,
-        }
-        voice := &entities.Voice{
-            Option: "1",
-            UserId: "user1",
-            PollId: "poll1",
-        }
-
-        err := storage.ValidateVoice(poll, voice)
-        require.Error(t, err)
-        require.Equal(t, fmt.Sprintf("*Poll*: `%s` **is already closed!**", voice.PollId), err.Error())
-    })
-
-    t.Run("Valid vote", func(t *testing.T) {
-        poll := &entities.Poll{
-            Options: map[string]int32{"1": 1},
-            Voters:  map[string]bool{},
-            Closed:  false,
-        }
-        voice := &entities.Voice{
-            Option: "1",
-            UserId: "user1",
-            PollId: "poll1",
-        }
-
-        err := storage.ValidateVoice(poll, voice)
-        require.NoError(t, err)
-    })
+	t.Run("Invalid option", func(t *testing.T) {
+		poll := &entities.Poll{
+			Options:  map[string]int32{"1": 1},
+			Voters:   map[string][]string{},
+			MaxVotes: 1,
+			Closed:   false,
+		}
+		voice := &entities.Voice{
+			Option: "2",
+			UserId: "user1",
+			PollId: "poll1",
+		}
+
+		err := storage.ValidateVoice(poll, voice)
+		require.Error(t, err)
+		require.Equal(t, "**Invalid option!**", err.Error())
+	})
+
+	t.Run("Repeat voice", func(t *testing.T) {
+		poll := &entities.Poll{
+			Options:  map[string]int32{"1": 1},
+			Voters:   map[string][]string{"user1": {"1"}},
+			MaxVotes: 1,
+			Closed:   false,
+		}
+		voice := &entities.Voice{
+			Option: "1",
+			UserId: "user1",
+			PollId: "poll1",
+		}
+
+		err := storage.ValidateVoice(poll, voice)
+		require.Error(t, err)
+		require.Equal(t, "**You can't vote again!**", err.Error())
+	})
+
+	t.Run("Closed poll", func(t *testing.T) {
+		poll := &entities.Poll{
+			Options:  map[string]int32{"1": 1},
+			Voters:   map[string][]string{},
+			MaxVotes: 1,
+			Closed:   true,
+		}
+		voice := &entities.Voice{
+			Option: "1",
+			UserId: "user1",
+			PollId: "poll1",
+		}
+
+		err := storage.ValidateVoice(poll, voice)
+		require.Error(t, err)
+		require.Equal(t, fmt.Sprintf("*Poll*: `%s` **is already closed!**", voice.PollId), err.Error())
+	})
+
+	t.Run("Valid vote", func(t *testing.T) {
+		poll := &entities.Poll{
+			Options:  map[string]int32{"1": 1},
+			Voters:   map[string][]string{},
+			MaxVotes: 1,
+			Closed:   false,
+		}
+		voice := &entities.Voice{
+			Option: "1",
+			UserId: "user1",
+			PollId: "poll1",
+		}
+
+		err := storage.ValidateVoice(poll, voice)
+		require.NoError(t, err)
+	})
+}
+
+// TestValidateVoiceMultipleChoice тестирует проверку голосов в опросах с несколькими вариантами выбора.
+func TestValidateVoiceMultipleChoice(t *testing.T) {
+	t.Run("Limit reached", func(t *testing.T) {
+		poll := &entities.Poll{
+			Options:  map[string]int32{"1": 1, "2": 1, "3": 0},
+			Voters:   map[string][]string{"user1": {"1", "2"}},
+			MaxVotes: 2,
+		}
+		voice := &entities.Voice{Option: "3", UserId: "user1", PollId: "poll1"}
+
+		err := storage.ValidateVoice(poll, voice)
+		require.Error(t, err)
+		require.Equal(t, "**You can't choose more than 2 options!**", err.Error())
+	})
+
+	t.Run("Same option", func(t *testing.T) {
+		poll := &entities.Poll{
+			Options:  map[string]int32{"1": 1, "2": 0},
+			Voters:   map[string][]string{"user1": {"1"}},
+			MaxVotes: 2,
+		}
+		voice := &entities.Voice{Option: "1", UserId: "user1", PollId: "poll1"}
+
+		err := storage.ValidateVoice(poll, voice)
+		require.Error(t, err)
+		require.Equal(t, "**You have already voted for this option!**", err.Error())
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		poll := &entities.Poll{
+			Options:  map[string]int32{"1": 1, "2": 1, "3": 0},
+			Voters:   map[string][]string{"user1": {"1", "2"}},
+			MaxVotes: 0,
+		}
+		voice := &entities.Voice{Option: "3", UserId: "user1", PollId: "poll1"}
+
+		err := storage.ValidateVoice(poll, voice)
+		require.NoError(t, err)
+	})
+}
+
+// TestValidatePoll тестирует проверку настроек нового опроса.
+// TestValidateVoiceRanked тестирует проверку бюллетеня рейтингового опроса.
+func TestValidateVoiceRanked(t *testing.T) {
+	poll := &entities.Poll{
+		Options:  map[string]int32{"1": 1, "2": 0, "3": 0},
+		Voters:   map[string][]string{"user1": {"1", "2"}},
+		MaxVotes: 1,
+		Ranked:   true,
+	}
+
+	t.Run("Valid ranking", func(t *testing.T) {
+		err := storage.ValidateVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user2", Ranking: []string{"3", "1"}})
+		require.NoError(t, err)
+	})
+
+	t.Run("Empty ranking", func(t *testing.T) {
+		err := storage.ValidateVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user2"})
+		require.Error(t, err)
+		require.Equal(t, "**Rank at least one option!**", err.Error())
+	})
+
+	t.Run("Invalid option", func(t *testing.T) {
+		err := storage.ValidateVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user2", Ranking: []string{"1", "4"}})
+		require.Error(t, err)
+		require.Equal(t, "**Invalid option!**", err.Error())
+	})
+
+	t.Run("Duplicated option", func(t *testing.T) {
+		err := storage.ValidateVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user2", Ranking: []string{"1", "2", "1"}})
+		require.Error(t, err)
+		require.Equal(t, "**Option `1` is ranked more than once!**", err.Error())
+	})
+
+	t.Run("Repeat voice", func(t *testing.T) {
+		err := storage.ValidateVoice(poll, &entities.Voice{PollId: "poll1", UserId: "user1", Ranking: []string{"3"}})
+		require.Error(t, err)
+		require.Equal(t, "**You can't vote again!**", err.Error())
+	})
+
+	t.Run("Ranking in not ranked poll", func(t *testing.T) {
+		notRanked := *poll
+		notRanked.Ranked = false
+
+		err := storage.ValidateVoice(&notRanked, &entities.Voice{PollId: "poll1", UserId: "user2", Option: "1", Ranking: []string{"1", "2"}})
+		require.Error(t, err)
+		require.Equal(t, "**This poll is not ranked, choose one option!**", err.Error())
+	})
+}
+
+func TestValidatePoll(t *testing.T) {
+	poll := &entities.Poll{Options: map[string]int32{"1": 0, "2": 0}}
+
+	for _, maxVotes := range []int32{0, 1, 2} {
+		poll.MaxVotes = maxVotes
+		require.NoError(t, storage.ValidatePoll(poll))
+	}
+
+	for _, maxVotes := range []int32{-1, 3} {
+		poll.MaxVotes = maxVotes
+		err := storage.ValidatePoll(poll)
+		require.Error(t, err)
+		require.Equal(t, "**Invalid number of votes per user!** *Expected*: from `0` (unlimited) to `2`", err.Error())
+	}
+
+	poll.Ranked = true
+	poll.MaxVotes = 1
+	require.NoError(t, storage.ValidatePoll(poll))
+
+	poll.MaxVotes = 2
+	err := storage.ValidatePoll(poll)
+	require.Error(t, err)
+	require.Equal(t, "**Ranked polls don't support multiple choice!**", err.Error())
+
+	poll.MaxVotes = 1
+	poll.Weights = map[string]int32{"user1": 3, "user2": storage.MaxWeight}
+	require.NoError(t, storage.ValidatePoll(poll))
+
+	for _, weight := range []int32{0, -1, storage.MaxWeight + 1} {
+		poll.Weights = map[string]int32{"user1": weight}
+		err := storage.ValidatePoll(poll)
+		require.Error(t, err)
+		require.Equal(t, fmt.Sprintf("**Invalid weight!** *Expected*: from `1` to `%d`", storage.MaxWeight), err.Error())
+	}
+
+	poll.Weights = nil
+	poll.Quorum, poll.QuorumPercent, poll.Threshold = 10, 50, 2.0/3
+	require.NoError(t, storage.ValidatePoll(poll))
+
+	poll.Quorum = -1
+	err = storage.ValidatePoll(poll)
+	require.Error(t, err)
+	require.Equal(t, "**Invalid quorum!** *Expected*: a number of voters or a percent of channel members (e.g. `10` or `50%`)", err.Error())
+
+	poll.Quorum, poll.QuorumPercent = 0, 101
+	err = storage.ValidatePoll(poll)
+	require.Error(t, err)
+	require.Equal(t, "**Invalid quorum!** *Expected*: a percent of channel members from `1%` to `100%`", err.Error())
+
+	poll.QuorumPercent = 0
+	for _, threshold := range []float64{-0.5, 1.5} {
+		poll.Threshold = threshold
+		err := storage.ValidatePoll(poll)
+		require.Error(t, err)
+		require.Equal(t, "**Invalid threshold!** *Expected*: a fraction or a percent of votes (e.g. `2/3` or `60%`)", err.Error())
+	}
 }
@@ -1,21 +1,91 @@
 package storage
 
 import (
-	"fmt"
 	"matterpoll-bot/internal/entities"
 )
 
+// ValidatePoll проверяет корректность настроек нового опроса.
+// Если настройки недействительны, возвращается ошибка с описанием причины.
+func ValidatePoll(poll *entities.Poll) error {
+	if poll.MaxVotes < 0 || int(poll.MaxVotes) > len(poll.Options) {
+		return entities.NewUserError("poll.invalid_max_votes", len(poll.Options))
+	}
+	if poll.Ranked && poll.MaxVotes != 1 {
+		return entities.NewUserError("poll.ranked_multiple_choice")
+	}
+	if poll.Quorum < 0 {
+		return entities.NewUserError("poll.invalid_quorum")
+	}
+	if poll.QuorumPercent < 0 || poll.QuorumPercent > 100 {
+		return entities.NewUserError("poll.invalid_quorum_percent")
+	}
+	if poll.Threshold < 0 || poll.Threshold > 1 {
+		return entities.NewUserError("poll.invalid_threshold")
+	}
+	for _, weight := range poll.Weights {
+		if weight < 1 || weight > MaxWeight {
+			return entities.NewUserError("poll.invalid_weight", MaxWeight)
+		}
+	}
+	return nil
+}
+
 // ValidateVoice проверяет корректность голоса пользователя для указанного опроса.
 // Если голос недействителен, возвращается ошибка с описанием причины.
 func ValidateVoice(poll *entities.Poll, voice *entities.Voice) error {
+	if poll.Ranked {
+		return validateRanking(poll, voice)
+	}
+	if len(voice.Ranking) > 1 {
+		return entities.NewUserError("vote.not_ranked")
+	}
 	if _, existsOption := poll.Options[voice.Option]; !existsOption {
-		return entities.NewUserError("**Invalid option!**")
+		return entities.NewUserError("vote.invalid_option")
 	}
-	if poll.Voters[voice.UserId] {
-		return entities.NewUserError("**You can't vote again!**")
+	choices := poll.Voters[voice.UserId]
+	if poll.MaxVotes > 0 && len(choices) >= int(poll.MaxVotes) {
+		if poll.MaxVotes == 1 {
+			return entities.NewUserError("vote.again")
+		}
+		return entities.NewUserError("vote.too_many_options", poll.MaxVotes)
+	}
+	for _, choice := range choices {
+		if choice == voice.Option {
+			return entities.NewUserError("vote.duplicate")
+		}
 	}
 	if poll.Closed {
-		return entities.NewUserError(fmt.Sprintf("*Poll*: `%s` **is already closed!**", voice.PollId))
+		return entities.NewUserError("poll.already_closed", voice.PollId)
 	}
 	return nil
 }
+
+// validateRanking проверяет корректность бюллетеня пользователя для рейтингового опроса.
+func validateRanking(poll *entities.Poll, voice *entities.Voice) error {
+	if len(voice.Ranking) == 0 {
+		return entities.NewUserError("vote.empty_ranking")
+	}
+	ranked := make(map[string]bool, len(voice.Ranking))
+	for _, option := range voice.Ranking {
+		if _, existsOption := poll.Options[option]; !existsOption {
+			return entities.NewUserError("vote.invalid_option")
+		}
+		if ranked[option] {
+			return entities.NewUserError("vote.ranked_twice", option)
+		}
+		ranked[option] = true
+	}
+	if _, voted := poll.Voters[voice.UserId]; voted {
+		return entities.NewUserError("vote.again")
+	}
+	if poll.Closed {
+		return entities.NewUserError("poll.already_closed", voice.PollId)
+	}
+	return nil
+}
+
+// CanManagePoll сообщает, может ли пользователь userId закрыть, снова открыть или удалить опрос poll:
+// это разрешено создателю опроса и модератору (moderator), права которого проверены вызывающей стороной.
+func CanManagePoll(poll *entities.Poll, userId string, moderator bool) bool {
+	return moderator || poll.Creator == userId
+}
@@ -0,0 +1,39 @@
+package storage_test
+
+import (
+	"matterpoll-bot/internal/entities"
+	"matterpoll-bot/internal/storage"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestWeights тестирует подсчет весов голосов и голосов без учета весов.
+func TestWeights(t *testing.T) {
+	poll := &entities.Poll{
+		Options:  map[string]int32{"1": 5, "2": 3},
+		Voters:   map[string][]string{"user1": {"1", "2"}, "user2": {"1"}, "user3": {}},
+		MaxVotes: 2,
+		Weights:  map[string]int32{"user1": 3, "user4": 10},
+	}
+
+	require.Equal(t, int32(3), storage.VoterWeight(poll, "user1"))
+	require.Equal(t, int32(1), storage.VoterWeight(poll, "user2"))
+	require.Equal(t, int32(5), storage.TotalWeight(poll))
+	require.Equal(t, map[string]int32{"1": 2, "2": 1}, storage.RawBallots(poll))
+
+	t.Run("Ranked poll", func(t *testing.T) {
+		rankedPoll := *poll
+		rankedPoll.Ranked = true
+
+		require.Equal(t, map[string]int32{"1": 2}, storage.RawBallots(&rankedPoll))
+	})
+
+	t.Run("Poll without weights", func(t *testing.T) {
+		plainPoll := *poll
+		plainPoll.Weights = nil
+
+		require.Equal(t, int32(1), storage.VoterWeight(&plainPoll, "user1"))
+		require.Equal(t, int32(3), storage.TotalWeight(&plainPoll))
+	})
+}
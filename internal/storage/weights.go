@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"matterpoll-bot/internal/entities"
+)
+
+// MaxWeight - максимальный вес голоса пользователя во взвешенном опросе.
+const MaxWeight = 1000
+
+// VoterWeight возвращает вес голоса пользователя userId в опросе (1, если вес пользователю не назначен).
+func VoterWeight(poll *entities.Poll, userId string) int32 {
+	if weight, ok := poll.Weights[userId]; ok {
+		return weight
+	}
+
+	return 1
+}
+
+// TotalWeight возвращает суммарный вес проголосовавших пользователей.
+// Для опроса без весов он равен количеству проголосовавших.
+func TotalWeight(poll *entities.Poll) int32 {
+	var total int32
+	for userId := range poll.Voters {
+		total += VoterWeight(poll, userId)
+	}
+
+	return total
+}
+
+// RawBallots возвращает количество голосов за каждый вариант ответа без учета весов.
+// В рейтинговом опросе учитывается только первое предпочтение бюллетеня.
+func RawBallots(poll *entities.Poll) map[string]int32 {
+	ballots := make(map[string]int32, len(poll.Options))
+	for _, choices := range poll.Voters {
+		if poll.Ranked && len(choices) > 0 {
+			choices = choices[:1]
+		}
+		for _, choice := range choices {
+			ballots[choice]++
+		}
+	}
+
+	return ballots
+}